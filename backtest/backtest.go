@@ -0,0 +1,126 @@
+// Package backtest replays OHLCV bars through a ScalpingIndicatorSuite and
+// simulates a long/flat strategy driven by its combined signal.
+package backtest
+
+import (
+	"github.com/evdnx/goti/indicator"
+	"github.com/evdnx/goti/suite"
+)
+
+// Action is what a strategy does when a signal fires.
+type Action int
+
+const (
+	// Hold keeps the current position.
+	Hold Action = iota
+	// Enter opens a long position at the bar's close; ignored when already long.
+	Enter
+	// Exit closes the long position at the bar's close; ignored when flat.
+	Exit
+)
+
+// String returns the action name, e.g. "Enter".
+func (a Action) String() string {
+	switch a {
+	case Enter:
+		return "Enter"
+	case Exit:
+		return "Exit"
+	default:
+		return "Hold"
+	}
+}
+
+// Rules maps GetCombinedSignal verdicts ("Strong Bullish", "Weak Bearish", …)
+// to actions. Signals without an entry hold the position.
+type Rules map[string]Action
+
+// Trade is one completed round trip. Indices refer to the bars passed to Run.
+type Trade struct {
+	EntryIndex int     `json:"entryIndex"`
+	ExitIndex  int     `json:"exitIndex"`
+	EntryPrice float64 `json:"entryPrice"`
+	ExitPrice  float64 `json:"exitPrice"`
+	Return     float64 `json:"return"` // ExitPrice/EntryPrice − 1
+}
+
+// Result summarises a backtest.
+type Result struct {
+	Trades []Trade `json:"trades"`
+	// WinRate is the fraction of trades with a positive return, 0 without trades.
+	WinRate float64 `json:"winRate"`
+	// CumulativeReturn is the compounded return of the strategy, Equity[len-1] − 1.
+	CumulativeReturn float64 `json:"cumulativeReturn"`
+	// Equity is the mark-to-market equity after every accepted bar, starting
+	// from 1.
+	Equity []float64 `json:"equity"`
+	// Skipped counts bars the suite rejected; they are left out of the replay.
+	Skipped int `json:"skipped"`
+}
+
+// Run feeds bars to s in order and trades on its combined signal. After each
+// bar the position is marked to market at the close, then the bar's signal is
+// looked up in rules and any resulting entry or exit fills at that same close.
+// Bars before the suite is warm produce no signal. A position still open
+// after the last bar is closed at its close and counted as a trade.
+//
+// s is used as given, so pass a fresh or Reset suite for an independent run.
+func Run(s *suite.ScalpingIndicatorSuite, bars []indicator.OHLCV, rules Rules) Result {
+	res := Result{Equity: make([]float64, 0, len(bars))}
+	equity := 1.0
+	long := false
+	var entry Trade
+	var prevClose float64
+	last := -1
+
+	for i, bar := range bars {
+		if err := s.Add(bar.High, bar.Low, bar.Close, bar.Volume); err != nil {
+			res.Skipped++
+			continue
+		}
+		if long {
+			equity *= bar.Close / prevClose
+		}
+		prevClose, last = bar.Close, i
+		res.Equity = append(res.Equity, equity)
+
+		signal, err := s.GetCombinedSignal()
+		if err != nil {
+			continue
+		}
+		switch rules[signal] {
+		case Enter:
+			if !long {
+				long = true
+				entry = Trade{EntryIndex: i, EntryPrice: bar.Close}
+			}
+		case Exit:
+			if long {
+				long = false
+				res.Trades = append(res.Trades, closeTrade(entry, i, bar.Close))
+			}
+		}
+	}
+	if long {
+		res.Trades = append(res.Trades, closeTrade(entry, last, prevClose))
+	}
+
+	wins := 0
+	for _, t := range res.Trades {
+		if t.Return > 0 {
+			wins++
+		}
+	}
+	if len(res.Trades) > 0 {
+		res.WinRate = float64(wins) / float64(len(res.Trades))
+	}
+	res.CumulativeReturn = equity - 1
+	return res
+}
+
+func closeTrade(t Trade, index int, price float64) Trade {
+	t.ExitIndex = index
+	t.ExitPrice = price
+	t.Return = price/t.EntryPrice - 1
+	return t
+}
@@ -0,0 +1,80 @@
+package backtest
+
+import (
+	"math"
+	"testing"
+
+	"github.com/evdnx/goti/indicator"
+	"github.com/evdnx/goti/suite"
+)
+
+// trendingBars rises 0.4 per bar with a ±1.5 swing every ~12 bars, so the
+// suite sees pullbacks inside a clear uptrend.
+func trendingBars(n int) []indicator.OHLCV {
+	bars := make([]indicator.OHLCV, n)
+	for i := range bars {
+		c := 100 + 0.4*float64(i) + 1.5*math.Sin(float64(i)/2)
+		bars[i] = indicator.OHLCV{
+			Timestamp: int64(i) * 60,
+			Open:      c - 0.2,
+			High:      c + 0.6,
+			Low:       c - 0.6,
+			Close:     c,
+			Volume:    1000 + 100*math.Cos(float64(i)/3),
+		}
+	}
+	return bars
+}
+
+func TestRunTrendFollowingIsProfitable(t *testing.T) {
+	s, err := suite.NewScalpingIndicatorSuite()
+	if err != nil {
+		t.Fatalf("constructor failed: %v", err)
+	}
+	bars := trendingBars(200)
+	rules := Rules{
+		"Strong Bullish": Enter,
+		"Bullish":        Enter,
+		"Bearish":        Exit,
+		"Strong Bearish": Exit,
+	}
+	res := Run(s, bars, rules)
+
+	if len(res.Trades) == 0 {
+		t.Fatal("expected the strategy to trade")
+	}
+	if res.CumulativeReturn <= 0 {
+		t.Fatalf("expected a profitable trend-following run, got %v", res.CumulativeReturn)
+	}
+	if len(res.Equity) != len(bars) || res.Skipped != 0 {
+		t.Fatalf("expected one equity point per bar, got %d (skipped %d)", len(res.Equity), res.Skipped)
+	}
+	if res.WinRate < 0 || res.WinRate > 1 {
+		t.Fatalf("win rate out of range: %v", res.WinRate)
+	}
+
+	// Compounding the trade returns must reproduce the equity curve.
+	compound := 1.0
+	for _, tr := range res.Trades {
+		if tr.ExitIndex < tr.EntryIndex {
+			t.Fatalf("trade exits before it enters: %+v", tr)
+		}
+		compound *= 1 + tr.Return
+	}
+	if math.Abs(compound-1-res.CumulativeReturn) > 1e-9 {
+		t.Fatalf("trade returns compound to %v, equity says %v", compound-1, res.CumulativeReturn)
+	}
+}
+
+func TestRunWithoutRulesStaysFlat(t *testing.T) {
+	s, _ := suite.NewScalpingIndicatorSuite()
+	bars := trendingBars(80)
+	bars[10].High = bars[10].Low - 1 // rejected by the suite
+	res := Run(s, bars, nil)
+	if len(res.Trades) != 0 || res.CumulativeReturn != 0 || res.WinRate != 0 {
+		t.Fatalf("expected no trades, got %+v", res)
+	}
+	if res.Skipped != 1 || len(res.Equity) != len(bars)-1 {
+		t.Fatalf("expected one skipped bar, got skipped %d, equity %d", res.Skipped, len(res.Equity))
+	}
+}
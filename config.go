@@ -13,3 +13,9 @@ const (
 func DefaultConfig() IndicatorConfig {
 	return config.DefaultConfig()
 }
+
+// LoadConfigPreset reads a JSON preset written by IndicatorConfig.MarshalPreset;
+// see config.LoadConfigPreset for the validation applied.
+func LoadConfigPreset(data []byte) (IndicatorConfig, error) {
+	return config.LoadConfigPreset(data)
+}
@@ -1,6 +1,12 @@
 package config
 
-import "fmt"
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
 
 // -----------------------------------------------------------------------------
 // Exported constants (magic numbers made visible)
@@ -48,7 +54,9 @@ func DefaultConfig() IndicatorConfig {
 	}
 }
 
-// Validate checks that the configuration values are sensible.
+// Validate checks that the configuration values are sensible: ATSEMAperiod
+// must be positive and each overbought level (RSI, MFI, AMDO) must sit above
+// its oversold level.
 func (c IndicatorConfig) Validate() error {
 	// 0 or negative values are not allowed.
 	if c.ATSEMAperiod <= 0 {
@@ -66,5 +74,51 @@ func (c IndicatorConfig) Validate() error {
 			maxReasonablePeriod,
 		)
 	}
+	return c.validateThresholds()
+}
+
+// validateThresholds checks that each overbought level sits above its
+// oversold level.
+func (c IndicatorConfig) validateThresholds() error {
+	thresholds := []struct {
+		name                 string
+		overbought, oversold float64
+	}{
+		{"RSI", c.RSIOverbought, c.RSIOversold},
+		{"MFI", c.MFIOverbought, c.MFIOversold},
+		{"AMDO", c.AMDOOverbought, c.AMDOOversold},
+	}
+	for _, th := range thresholds {
+		if !(th.overbought > th.oversold) {
+			return fmt.Errorf("%sOverbought (%v) must be greater than %sOversold (%v)",
+				th.name, th.overbought, th.name, th.oversold)
+		}
+	}
 	return nil
 }
+
+// MarshalPreset encodes every field of the configuration as indented JSON,
+// keyed by the Go field names, ready to be stored as a named preset file.
+func (c IndicatorConfig) MarshalPreset() ([]byte, error) {
+	return json.MarshalIndent(c, "", "  ")
+}
+
+// LoadConfigPreset decodes a preset written by MarshalPreset and validates
+// it. Fields missing from the preset keep their DefaultConfig values; unknown
+// fields and anything after the JSON object are rejected so a misspelt key or
+// a pasted-together file does not load silently.
+func LoadConfigPreset(data []byte) (IndicatorConfig, error) {
+	cfg := DefaultConfig()
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return IndicatorConfig{}, fmt.Errorf("decode preset: %w", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return IndicatorConfig{}, errors.New("decode preset: unexpected data after the JSON object")
+	}
+	if err := cfg.Validate(); err != nil {
+		return IndicatorConfig{}, fmt.Errorf("invalid preset: %w", err)
+	}
+	return cfg, nil
+}
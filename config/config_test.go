@@ -1,6 +1,7 @@
 package config
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -35,6 +36,27 @@ func TestConfigValidation(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "inverted RSI thresholds",
+			modify: func(c *IndicatorConfig) {
+				c.RSIOverbought, c.RSIOversold = 30, 70
+			},
+			wantErr: true,
+		},
+		{
+			name: "equal MFI thresholds",
+			modify: func(c *IndicatorConfig) {
+				c.MFIOverbought, c.MFIOversold = 50, 50
+			},
+			wantErr: true,
+		},
+		{
+			name: "inverted AMDO thresholds",
+			modify: func(c *IndicatorConfig) {
+				c.AMDOOverbought, c.AMDOOversold = -1, 1
+			},
+			wantErr: true,
+		},
 		{
 			name: "valid custom period",
 			modify: func(c *IndicatorConfig) {
@@ -57,3 +79,66 @@ func TestConfigValidation(t *testing.T) {
 		}
 	}
 }
+
+func TestConfigPresetRoundTrip(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.RSIOverbought, cfg.RSIOversold = 80, 20
+	cfg.MFIVolumeScale = 1
+	cfg.VWAOStrongTrend = 55
+	cfg.ATSEMAperiod = 8
+
+	data, err := cfg.MarshalPreset()
+	if err != nil {
+		t.Fatalf("MarshalPreset failed: %v", err)
+	}
+	got, err := LoadConfigPreset(data)
+	if err != nil {
+		t.Fatalf("LoadConfigPreset failed: %v", err)
+	}
+	if got != cfg {
+		t.Fatalf("round trip mismatch: got %+v, want %+v", got, cfg)
+	}
+	// IndicatorConfig has no JSON tags, so presets use the Go field names.
+	if !strings.Contains(string(data), `"RSIOverbought": 80`) || !strings.Contains(string(data), `"ATSEMAperiod": 8`) {
+		t.Fatalf("expected Go field names as preset keys, got %s", data)
+	}
+}
+
+func TestLoadConfigPresetRejectsInvalid(t *testing.T) {
+	cases := map[string]string{
+		"inverted RSI":  `{"RSIOverbought": 30, "RSIOversold": 70}`,
+		"equal MFI":     `{"MFIOverbought": 50, "MFIOversold": 50}`,
+		"zero period":   `{"ATSEMAperiod": 0}`,
+		"unknown field": `{"RSIOverbougt": 75}`,
+		"malformed":     `{"RSIOverbought": }`,
+		"trailing JSON": `{"RSIOverbought": 75} {"RSIOversold": 25}`,
+		"trailing text": `{"RSIOverbought": 75} x`,
+	}
+	for name, preset := range cases {
+		if _, err := LoadConfigPreset([]byte(preset)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	_, err := LoadConfigPreset([]byte(`{"RSIOverbought": 30, "RSIOversold": 70}`))
+	if err == nil || !strings.Contains(err.Error(), "RSIOverbought") {
+		t.Fatalf("expected a field-specific error, got %v", err)
+	}
+
+	// Omitted fields fall back to the defaults.
+	got, err := LoadConfigPreset([]byte(`{"VWAOStrongTrend": 60}`))
+	if err != nil {
+		t.Fatalf("partial preset failed: %v", err)
+	}
+	want := DefaultConfig()
+	want.VWAOStrongTrend = 60
+	if got != want {
+		t.Fatalf("partial preset: got %+v, want %+v", got, want)
+	}
+}
+
+func TestLoadConfigPresetTrailingWhitespace(t *testing.T) {
+	if _, err := LoadConfigPreset([]byte("{\"RSIOverbought\": 75}\n")); err != nil {
+		t.Fatalf("trailing whitespace should load: %v", err)
+	}
+}
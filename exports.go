@@ -1,6 +1,9 @@
 package goti
 
 import (
+	"io"
+
+	"github.com/evdnx/goti/backtest"
 	"github.com/evdnx/goti/config"
 	"github.com/evdnx/goti/indicator"
 	"github.com/evdnx/goti/suite"
@@ -8,32 +11,349 @@ import (
 
 // ---- Shared data helpers ----
 type PlotData = indicator.PlotData
+type OHLCV = indicator.OHLCV
+type SignalEvent = indicator.SignalEvent
+type IndicatorInfo = indicator.IndicatorInfo
+
+func StreamBars(r io.Reader, fn func(indicator.OHLCV) error) error {
+	return indicator.StreamBars(r, fn)
+}
+
+func OverlayPlotData(name string, values []float64, lastBar int, startTime, interval int64) indicator.PlotData {
+	return indicator.OverlayPlotData(name, values, lastBar, startTime, interval)
+}
+
+const (
+	EventBullishCrossover = indicator.EventBullishCrossover
+	EventBearishCrossover = indicator.EventBearishCrossover
+	EventOverbought       = indicator.EventOverbought
+	EventOversold         = indicator.EventOversold
+	EventNeutral          = indicator.EventNeutral
+)
+
+type ZoneEvent = indicator.ZoneEvent
+
+const (
+	ZoneNone          = indicator.ZoneNone
+	EnteredOverbought = indicator.EnteredOverbought
+	ExitedOverbought  = indicator.ExitedOverbought
+	EnteredOversold   = indicator.EnteredOversold
+	ExitedOversold    = indicator.ExitedOversold
+)
+
+func ClassifyZoneTransition(prev, cur, overbought, oversold float64) indicator.ZoneEvent {
+	return indicator.ClassifyZoneTransition(prev, cur, overbought, oversold)
+}
 
 func GenerateTimestamps(startTime int64, count int, interval int64) []int64 {
 	return indicator.GenerateTimestamps(startTime, count, interval)
 }
 
+func SlicePlotData(data []indicator.PlotData, from, to int) []indicator.PlotData {
+	return indicator.SlicePlotData(data, from, to)
+}
+
+type Marker = indicator.Marker
+type PlotDataWithMarkers = indicator.PlotDataWithMarkers
+
+const (
+	MarkerBullishCross    = indicator.MarkerBullishCross
+	MarkerBearishCross    = indicator.MarkerBearishCross
+	MarkerOverbought      = indicator.MarkerOverbought
+	MarkerOversold        = indicator.MarkerOversold
+	MarkerStrongUptrend   = indicator.MarkerStrongUptrend
+	MarkerStrongDowntrend = indicator.MarkerStrongDowntrend
+)
+
+var (
+	ZoneMarkerKinds  = indicator.ZoneMarkerKinds
+	TrendMarkerKinds = indicator.TrendMarkerKinds
+)
+
+func ExtractMarkers(data []indicator.PlotData, kinds map[float64]string) indicator.PlotDataWithMarkers {
+	return indicator.ExtractMarkers(data, kinds)
+}
+
+func CorrectCandle(high, low, close float64) (float64, float64, float64, bool) {
+	return indicator.CorrectCandle(high, low, close)
+}
+
+func Percentile(values []float64, pct float64) (float64, error) {
+	return indicator.Percentile(values, pct)
+}
+
+func Histogram(values []float64, bins int) (edges []float64, counts []int) {
+	return indicator.Histogram(values, bins)
+}
+
+func Returns(prices []float64) []float64 {
+	return indicator.Returns(prices)
+}
+
+func CrossSeries(a, b []float64) (int, int) {
+	return indicator.CrossSeries(a, b)
+}
+
+func DivergenceStrength(pricePrev, priceCurr, oscPrev, oscCurr, priceRange, oscRange float64) float64 {
+	return indicator.DivergenceStrength(pricePrev, priceCurr, oscPrev, oscCurr, priceRange, oscRange)
+}
+
+func OutputSlope(values []float64, n int) (float64, error) {
+	return indicator.OutputSlope(values, n)
+}
+
+func Correlation(a, b []float64) (float64, error) {
+	return indicator.Correlation(a, b)
+}
+
+func LaggedCorrelation(a, b []float64, maxLag int) (bestLag int, bestCorr float64) {
+	return indicator.LaggedCorrelation(a, b, maxLag)
+}
+
+func TrackMFE(signals []indicator.SignalEvent, closes []float64, horizon int) []float64 {
+	return indicator.TrackMFE(signals, closes, horizon)
+}
+
+func EstimateLag(ma *indicator.MovingAverage, testSeries []float64) float64 {
+	return indicator.EstimateLag(ma, testSeries)
+}
+
+func EstimateSeriesLag(input, output []float64) float64 {
+	return indicator.EstimateSeriesLag(input, output)
+}
+
+func WarmupConfidence(emitted, period int) float64 {
+	return indicator.WarmupConfidence(emitted, period)
+}
+
+func InterpolateLast(values []float64, frac float64) (float64, error) {
+	return indicator.InterpolateLast(values, frac)
+}
+
+func ApplyTransform(fn func(float64) float64, v float64) float64 {
+	return indicator.ApplyTransform(fn, v)
+}
+
+var ErrNonFinite = indicator.ErrNonFinite
+
+func CheckFinite(name string, v float64) error {
+	return indicator.CheckFinite(name, v)
+}
+
+func ValidateFinite(name string, values []float64) error {
+	return indicator.ValidateFinite(name, values)
+}
+
+func LogReturns(prices []float64) []float64 {
+	return indicator.LogReturns(prices)
+}
+
 func FormatPlotDataJSON(data []indicator.PlotData) (string, error) {
 	return indicator.FormatPlotDataJSON(data)
 }
 
+type PlotDataEnvelope = indicator.PlotDataEnvelope
+
+const PlotDataSchemaVersion = indicator.PlotDataSchemaVersion
+
+func FormatPlotDataJSONV2(data []indicator.PlotData) (string, error) {
+	return indicator.FormatPlotDataJSONV2(data)
+}
+
+func FormatPlotDataEnvelopeJSON(env indicator.PlotDataEnvelope) (string, error) {
+	return indicator.FormatPlotDataEnvelopeJSON(env)
+}
+
 func FormatPlotDataCSV(data []indicator.PlotData) (string, error) {
 	return indicator.FormatPlotDataCSV(data)
 }
 
+type PlotWriter = indicator.PlotWriter
+type PlotFormat = indicator.PlotFormat
+
+const (
+	PlotFormatCSV    = indicator.PlotFormatCSV
+	PlotFormatNDJSON = indicator.PlotFormatNDJSON
+)
+
+func NewPlotWriter(w io.Writer, format PlotFormat) (*indicator.PlotWriter, error) {
+	return indicator.NewPlotWriter(w, format)
+}
+
+func StreamPlotDataCSV(w io.Writer, data []indicator.PlotData) error {
+	return indicator.StreamPlotDataCSV(w, data)
+}
+
 // ---- Moving averages ----
 type MovingAverageType = indicator.MovingAverageType
 
 const (
-	EMAMovingAverage MovingAverageType = indicator.EMAMovingAverage
-	SMAMovingAverage MovingAverageType = indicator.SMAMovingAverage
-	WMAMovingAverage MovingAverageType = indicator.WMAMovingAverage
+	EMAMovingAverage  MovingAverageType = indicator.EMAMovingAverage
+	SMAMovingAverage  MovingAverageType = indicator.SMAMovingAverage
+	WMAMovingAverage  MovingAverageType = indicator.WMAMovingAverage
+	RMAMovingAverage  MovingAverageType = indicator.RMAMovingAverage
+	DEMAMovingAverage MovingAverageType = indicator.DEMAMovingAverage
 )
 
 type MovingAverage = indicator.MovingAverage
 
-func NewMovingAverage(maType indicator.MovingAverageType, period int) (*indicator.MovingAverage, error) {
-	return indicator.NewMovingAverage(maType, period)
+func NewMovingAverage(maType indicator.MovingAverageType, period int, opts ...indicator.MAOption) (*indicator.MovingAverage, error) {
+	return indicator.NewMovingAverage(maType, period, opts...)
+}
+
+type MAOption = indicator.MAOption
+
+func WithMAEarlyValues(enabled bool) indicator.MAOption {
+	return indicator.WithMAEarlyValues(enabled)
+}
+
+type EMASeedMode = indicator.EMASeedMode
+
+const (
+	SeedSMA        = indicator.SeedSMA
+	SeedFirstValue = indicator.SeedFirstValue
+)
+
+func WithEMASeed(seed indicator.EMASeedMode) indicator.MAOption {
+	return indicator.WithEMASeed(seed)
+}
+
+func WithTALibCompat(enabled bool) indicator.MAOption {
+	return indicator.WithTALibCompat(enabled)
+}
+
+func WithMAInvertedPrice(enabled bool) indicator.MAOption {
+	return indicator.WithMAInvertedPrice(enabled)
+}
+
+type RollingMedian = indicator.RollingMedian
+type RollingWindow = indicator.RollingWindow
+type Extremes = indicator.Extremes
+type DivergenceWindow = indicator.DivergenceWindow
+
+type SupportResistance = indicator.SupportResistance
+type Level = indicator.Level
+type ToleranceMode = indicator.ToleranceMode
+
+const (
+	ToleranceAbsolute = indicator.ToleranceAbsolute
+	TolerancePercent  = indicator.TolerancePercent
+	LevelSupport      = indicator.LevelSupport
+	LevelResistance   = indicator.LevelResistance
+	LevelBoth         = indicator.LevelBoth
+)
+
+type Line = indicator.Line
+
+func FitTrendlines(prices []float64, leftBars, rightBars int) (support, resistance indicator.Line) {
+	return indicator.FitTrendlines(prices, leftBars, rightBars)
+}
+
+func Pivots(values []float64, leftBars, rightBars int) (lows, highs []int) {
+	return indicator.Pivots(values, leftBars, rightBars)
+}
+
+const (
+	DivergenceBullish = indicator.DivergenceBullish
+	DivergenceBearish = indicator.DivergenceBearish
+)
+
+func PivotDivergence(prices, osc []float64, leftBars, rightBars int) string {
+	return indicator.PivotDivergence(prices, osc, leftBars, rightBars)
+}
+
+func PivotDivergenceStrength(prices, osc []float64, leftBars, rightBars int) (dir string, strength float64) {
+	return indicator.PivotDivergenceStrength(prices, osc, leftBars, rightBars)
+}
+
+func ScanDivergence(data map[string][]float64, rsiPeriod, leftBars, rightBars int) map[string]string {
+	return indicator.ScanDivergence(data, rsiPeriod, leftBars, rightBars)
+}
+
+func NewSupportResistance(window int, tolerance float64, mode indicator.ToleranceMode) (*indicator.SupportResistance, error) {
+	return indicator.NewSupportResistance(window, tolerance, mode)
+}
+
+type GapFiller = indicator.GapFiller
+type GapFillMode = indicator.GapFillMode
+
+const (
+	GapFillFlat   = indicator.GapFillFlat
+	GapSkipMarked = indicator.GapSkipMarked
+)
+
+func NewGapFiller(mode indicator.GapFillMode) *indicator.GapFiller {
+	return indicator.NewGapFiller(mode)
+}
+
+type CrossoverInclusivity = indicator.CrossoverInclusivity
+
+const (
+	CrossoverDefault   = indicator.CrossoverDefault
+	CrossoverInclusive = indicator.CrossoverInclusive
+	CrossoverStrict    = indicator.CrossoverStrict
+)
+
+func CrossedAbove(prev, cur, level float64, allowEqual bool) bool {
+	return indicator.CrossedAbove(prev, cur, level, allowEqual)
+}
+
+func CrossedBelow(prev, cur, level float64, allowEqual bool) bool {
+	return indicator.CrossedBelow(prev, cur, level, allowEqual)
+}
+
+func NewRollingMedianWithParams(period int) (*indicator.RollingMedian, error) {
+	return indicator.NewRollingMedianWithParams(period)
+}
+
+func NewRollingWindow(period int) (*indicator.RollingWindow, error) {
+	return indicator.NewRollingWindow(period)
+}
+
+type MedianPrice = indicator.MedianPrice
+type WeightedClose = indicator.WeightedClose
+
+func NewMedianPrice() *indicator.MedianPrice { return indicator.NewMedianPrice() }
+
+func NewWeightedClose() *indicator.WeightedClose { return indicator.NewWeightedClose() }
+
+type SessionRange = indicator.SessionRange
+type SessionBoundaryFunc = indicator.SessionBoundaryFunc
+
+func NewSessionRange(boundary indicator.SessionBoundaryFunc) *indicator.SessionRange {
+	return indicator.NewSessionRange(boundary)
+}
+
+const (
+	MillisPerSecond = indicator.MillisPerSecond
+	MillisPerMinute = indicator.MillisPerMinute
+	MillisPerDay    = indicator.MillisPerDay
+)
+
+func DailySessionBoundary(offsetMillis int64) indicator.SessionBoundaryFunc {
+	return indicator.DailySessionBoundary(offsetMillis)
+}
+
+type TickAggregator = indicator.TickAggregator
+type BarBoundary = indicator.BarBoundary
+
+const (
+	TimeBoundary   = indicator.TimeBoundary
+	VolumeBoundary = indicator.VolumeBoundary
+)
+
+type Resampler = indicator.Resampler
+
+func NewResampler(baseInterval, interval int64) (*indicator.Resampler, error) {
+	return indicator.NewResampler(baseInterval, interval)
+}
+
+func NewTimeTickAggregator(interval int64) (*indicator.TickAggregator, error) {
+	return indicator.NewTimeTickAggregator(interval)
+}
+
+func NewVolumeTickAggregator(volume float64) (*indicator.TickAggregator, error) {
+	return indicator.NewVolumeTickAggregator(volume)
 }
 
 // ---- RSI ----
@@ -43,13 +363,46 @@ func NewRelativeStrengthIndex() (*indicator.RelativeStrengthIndex, error) {
 	return indicator.NewRelativeStrengthIndex()
 }
 
-func NewRelativeStrengthIndexWithParams(period int, cfg config.IndicatorConfig) (*indicator.RelativeStrengthIndex, error) {
-	return indicator.NewRelativeStrengthIndexWithParams(period, cfg)
+func NewRelativeStrengthIndexWithParams(period int, cfg config.IndicatorConfig, opts ...indicator.RSIOption) (*indicator.RelativeStrengthIndex, error) {
+	return indicator.NewRelativeStrengthIndexWithParams(period, cfg, opts...)
+}
+
+type RSIOption = indicator.RSIOption
+
+func WithRSIDynamicThresholds(window int, hiPct, loPct float64) indicator.RSIOption {
+	return indicator.WithRSIDynamicThresholds(window, hiPct, loPct)
+}
+
+func WithRSIStrictOutputCheck(enabled bool) indicator.RSIOption {
+	return indicator.WithRSIStrictOutputCheck(enabled)
+}
+
+func WithRSICutlerMethod(enabled bool) indicator.RSIOption {
+	return indicator.WithRSICutlerMethod(enabled)
+}
+
+func WithRSIHistory(n int) indicator.RSIOption {
+	return indicator.WithRSIHistory(n)
+}
+
+func WithRSICrossoverInclusivity(mode indicator.CrossoverInclusivity) indicator.RSIOption {
+	return indicator.WithRSICrossoverInclusivity(mode)
+}
+
+func WithRSIInvertedPrice(enabled bool) indicator.RSIOption {
+	return indicator.WithRSIInvertedPrice(enabled)
 }
 
 // ---- MACD ----
 type MACD = indicator.MACD
 
+const (
+	MACDRisingPositive  = indicator.MACDRisingPositive
+	MACDFallingPositive = indicator.MACDFallingPositive
+	MACDRisingNegative  = indicator.MACDRisingNegative
+	MACDFallingNegative = indicator.MACDFallingNegative
+)
+
 func NewMACD() (*indicator.MACD, error) {
 	return indicator.NewMACD()
 }
@@ -60,6 +413,14 @@ func NewMACDWithParams(fastPeriod, slowPeriod, signalPeriod int) (*indicator.MAC
 
 // ---- Stochastic Oscillator ----
 type StochasticOscillator = indicator.StochasticOscillator
+type StochasticMode = indicator.StochasticMode
+
+const (
+	DefaultStochasticSlowing = indicator.DefaultStochasticSlowing
+	StochFast                = indicator.StochFast
+	StochSlow                = indicator.StochSlow
+	StochFull                = indicator.StochFull
+)
 
 func NewStochasticOscillator() (*indicator.StochasticOscillator, error) {
 	return indicator.NewStochasticOscillator()
@@ -80,6 +441,85 @@ func NewCommodityChannelIndexWithParams(period int) (*indicator.CommodityChannel
 	return indicator.NewCommodityChannelIndexWithParams(period)
 }
 
+// ---- Elder Ray ----
+type ElderRay = indicator.ElderRay
+
+func NewElderRay() (*indicator.ElderRay, error) {
+	return indicator.NewElderRay()
+}
+
+func NewElderRayWithParams(emaPeriod int) (*indicator.ElderRay, error) {
+	return indicator.NewElderRayWithParams(emaPeriod)
+}
+
+// ---- QQE ----
+type QQE = indicator.QQE
+
+func NewQQE() (*indicator.QQE, error) {
+	return indicator.NewQQE()
+}
+
+func NewQQEWithParams(rsiPeriod, smoothing int, factor float64) (*indicator.QQE, error) {
+	return indicator.NewQQEWithParams(rsiPeriod, smoothing, factor)
+}
+
+// ---- Awesome & Accelerator Oscillators ----
+type AwesomeOscillator = indicator.AwesomeOscillator
+type AcceleratorOscillator = indicator.AcceleratorOscillator
+
+const (
+	DefaultAOFastPeriod = indicator.DefaultAOFastPeriod
+	DefaultAOSlowPeriod = indicator.DefaultAOSlowPeriod
+	DefaultACPeriod     = indicator.DefaultACPeriod
+	HistogramUp         = indicator.HistogramUp
+	HistogramDown       = indicator.HistogramDown
+)
+
+func NewAwesomeOscillator() (*indicator.AwesomeOscillator, error) {
+	return indicator.NewAwesomeOscillator()
+}
+
+func NewAwesomeOscillatorWithParams(fastPeriod, slowPeriod int) (*indicator.AwesomeOscillator, error) {
+	return indicator.NewAwesomeOscillatorWithParams(fastPeriod, slowPeriod)
+}
+
+func NewAcceleratorOscillator() (*indicator.AcceleratorOscillator, error) {
+	return indicator.NewAcceleratorOscillator()
+}
+
+func NewAcceleratorOscillatorWithParams(fastPeriod, slowPeriod, period int) (*indicator.AcceleratorOscillator, error) {
+	return indicator.NewAcceleratorOscillatorWithParams(fastPeriod, slowPeriod, period)
+}
+
+// ---- Relative Vigor Index ----
+type RelativeVigorIndex = indicator.RelativeVigorIndex
+
+const DefaultRVIPeriod = indicator.DefaultRVIPeriod
+
+func NewRelativeVigorIndex() (*indicator.RelativeVigorIndex, error) {
+	return indicator.NewRelativeVigorIndex()
+}
+
+func NewRelativeVigorIndexWithParams(period int) (*indicator.RelativeVigorIndex, error) {
+	return indicator.NewRelativeVigorIndexWithParams(period)
+}
+
+type AdaptiveRSI = indicator.AdaptiveRSI
+
+const (
+	DefaultAdaptiveRSIMinPeriod        = indicator.DefaultAdaptiveRSIMinPeriod
+	DefaultAdaptiveRSIMaxPeriod        = indicator.DefaultAdaptiveRSIMaxPeriod
+	DefaultAdaptiveRSIVolatilityPeriod = indicator.DefaultAdaptiveRSIVolatilityPeriod
+)
+
+func NewAdaptiveRSI() (*indicator.AdaptiveRSI, error) {
+	return indicator.NewAdaptiveRSI()
+}
+
+func NewAdaptiveRSIWithParams(minPeriod, maxPeriod, volatilityPeriod int) (*indicator.AdaptiveRSI, error) {
+	return indicator.NewAdaptiveRSIWithParams(minPeriod, maxPeriod, volatilityPeriod)
+}
+
 // ---- Money Flow Index ----
 type MoneyFlowIndex = indicator.MoneyFlowIndex
 
@@ -92,8 +532,30 @@ func NewMoneyFlowIndex() (*indicator.MoneyFlowIndex, error) {
 	return indicator.NewMoneyFlowIndex()
 }
 
-func NewMoneyFlowIndexWithParams(period int, cfg config.IndicatorConfig) (*indicator.MoneyFlowIndex, error) {
-	return indicator.NewMoneyFlowIndexWithParams(period, cfg)
+func NewMoneyFlowIndexWithParams(period int, cfg config.IndicatorConfig, opts ...indicator.MFIOption) (*indicator.MoneyFlowIndex, error) {
+	return indicator.NewMoneyFlowIndexWithParams(period, cfg, opts...)
+}
+
+type MFIOption = indicator.MFIOption
+
+func WithMFIAutoCorrect(enabled bool) indicator.MFIOption {
+	return indicator.WithMFIAutoCorrect(enabled)
+}
+
+func WithMFIStrictOutputCheck(enabled bool) indicator.MFIOption {
+	return indicator.WithMFIStrictOutputCheck(enabled)
+}
+
+func WithMFIHistory(n int) indicator.MFIOption {
+	return indicator.WithMFIHistory(n)
+}
+
+func WithMFICrossoverInclusivity(mode indicator.CrossoverInclusivity) indicator.MFIOption {
+	return indicator.WithMFICrossoverInclusivity(mode)
+}
+
+func WithVolumeAutoScale(enabled bool) indicator.MFIOption {
+	return indicator.WithVolumeAutoScale(enabled)
 }
 
 // ---- VWAP ----
@@ -103,6 +565,43 @@ func NewVWAP() *indicator.VWAP {
 	return indicator.NewVWAP()
 }
 
+func NewDecayVWAP(halfLifeBars float64) (*indicator.VWAP, error) {
+	return indicator.NewDecayVWAP(halfLifeBars)
+}
+
+// ---- Accumulation/Distribution & Chaikin Oscillator ----
+type AccumulationDistribution = indicator.AccumulationDistribution
+type ChaikinOscillator = indicator.ChaikinOscillator
+
+func NewAccumulationDistribution() *indicator.AccumulationDistribution {
+	return indicator.NewAccumulationDistribution()
+}
+
+func NewChaikinOscillator() (*indicator.ChaikinOscillator, error) {
+	return indicator.NewChaikinOscillator()
+}
+
+func NewChaikinOscillatorWithParams(fast, slow int) (*indicator.ChaikinOscillator, error) {
+	return indicator.NewChaikinOscillatorWithParams(fast, slow)
+}
+
+// ---- Volume RSI ----
+type VolumeRSI = indicator.VolumeRSI
+
+const (
+	DefaultVolumeRSIPeriod     = indicator.DefaultVolumeRSIPeriod
+	DefaultVolumeRSIOverbought = indicator.DefaultVolumeRSIOverbought
+	DefaultVolumeRSIOversold   = indicator.DefaultVolumeRSIOversold
+)
+
+func NewVolumeRSI() (*indicator.VolumeRSI, error) {
+	return indicator.NewVolumeRSI()
+}
+
+func NewVolumeRSIWithParams(period int) (*indicator.VolumeRSI, error) {
+	return indicator.NewVolumeRSIWithParams(period)
+}
+
 // ---- Volume Weighted Aroon Oscillator ----
 type VolumeWeightedAroonOscillator = indicator.VolumeWeightedAroonOscillator
 
@@ -136,15 +635,71 @@ func NewParabolicSARWithParams(step, maxStep float64) (*indicator.ParabolicSAR,
 	return indicator.NewParabolicSARWithParams(step, maxStep)
 }
 
+// ---- Moving-average ribbon ----
+type MARibbon = indicator.MARibbon
+
+func NewMARibbon(maType indicator.MovingAverageType, periods []int) (*indicator.MARibbon, error) {
+	return indicator.NewMARibbon(maType, periods)
+}
+
+// ---- Gann HiLo Activator ----
+type GannHiLo = indicator.GannHiLo
+
+func NewGannHiLo() (*indicator.GannHiLo, error) {
+	return indicator.NewGannHiLo()
+}
+
+func NewGannHiLoWithParams(period int) (*indicator.GannHiLo, error) {
+	return indicator.NewGannHiLoWithParams(period)
+}
+
+// ---- McGinley Dynamic ----
+type McGinleyDynamic = indicator.McGinleyDynamic
+
+func NewMcGinley() (*indicator.McGinleyDynamic, error) {
+	return indicator.NewMcGinley()
+}
+
+func NewMcGinleyWithParams(period int) (*indicator.McGinleyDynamic, error) {
+	return indicator.NewMcGinleyWithParams(period)
+}
+
 // ---- Average True Range ----
 type AverageTrueRange = indicator.AverageTrueRange
 type ATROption = indicator.ATROption
+type ATRGapMode = indicator.ATRGapMode
 type BollingerBands = indicator.BollingerBands
+type BollingerOption = indicator.BollingerOption
+type VolatilityRegime = indicator.VolatilityRegime
+
+const (
+	VolatilityLow           = indicator.VolatilityLow
+	VolatilityNormal        = indicator.VolatilityNormal
+	VolatilityHigh          = indicator.VolatilityHigh
+	VolatilityExtreme       = indicator.VolatilityExtreme
+	DefaultRegimeLowPct     = indicator.DefaultRegimeLowPct
+	DefaultRegimeHighPct    = indicator.DefaultRegimeHighPct
+	DefaultRegimeExtremePct = indicator.DefaultRegimeExtremePct
+	IncludeGaps             = indicator.IncludeGaps
+	ExcludeGaps             = indicator.ExcludeGaps
+)
 
 func WithCloseValidation(enabled bool) indicator.ATROption {
 	return indicator.WithCloseValidation(enabled)
 }
 
+func WithATRAutoCorrect(enabled bool) indicator.ATROption {
+	return indicator.WithATRAutoCorrect(enabled)
+}
+
+func WithATRStrictOutputCheck(enabled bool) indicator.ATROption {
+	return indicator.WithATRStrictOutputCheck(enabled)
+}
+
+func WithATREarlyValues(enabled bool) indicator.ATROption {
+	return indicator.WithATREarlyValues(enabled)
+}
+
 func NewAverageTrueRange() (*indicator.AverageTrueRange, error) {
 	return indicator.NewAverageTrueRange()
 }
@@ -157,8 +712,12 @@ func NewBollingerBands() (*indicator.BollingerBands, error) {
 	return indicator.NewBollingerBands()
 }
 
-func NewBollingerBandsWithParams(period int, multiplier float64) (*indicator.BollingerBands, error) {
-	return indicator.NewBollingerBandsWithParams(period, multiplier)
+func NewBollingerBandsWithParams(period int, multiplier float64, opts ...indicator.BollingerOption) (*indicator.BollingerBands, error) {
+	return indicator.NewBollingerBandsWithParams(period, multiplier, opts...)
+}
+
+func WithBollingerAdaptiveMultiplier(targetOutsideFrac float64) indicator.BollingerOption {
+	return indicator.WithBollingerAdaptiveMultiplier(targetOutsideFrac)
 }
 
 // ---- Adaptive DEMA Momentum Oscillator ----
@@ -170,6 +729,14 @@ const (
 	DefaultStdWeight   = indicator.DefaultStdWeight
 )
 
+type NormMode = indicator.NormMode
+
+const (
+	NormZScore     = indicator.NormZScore
+	NormATR        = indicator.NormATR
+	NormPercentile = indicator.NormPercentile
+)
+
 var (
 	ErrInsufficientData = indicator.ErrInsufficientData
 	ErrInvalidParams    = indicator.ErrInvalidParams
@@ -196,17 +763,71 @@ func NewAdaptiveTrendStrengthOscillatorWithParams(shortPeriod, longPeriod, volat
 	return indicator.NewAdaptiveTrendStrengthOscillatorWithParams(shortPeriod, longPeriod, volatilityPeriod, cfg)
 }
 
+// ---- Dominant cycle ----
+type DominantCycle = indicator.DominantCycle
+
+func NewDominantCycle() (*indicator.DominantCycle, error) {
+	return indicator.NewDominantCycle()
+}
+
+func NewDominantCycleWithParams(window, minLag, maxLag int) (*indicator.DominantCycle, error) {
+	return indicator.NewDominantCycleWithParams(window, minLag, maxLag)
+}
+
+// ---- Rolling Sharpe / Sortino ----
+type RollingSharpe = indicator.RollingSharpe
+
+var ErrZeroVolatility = indicator.ErrZeroVolatility
+
+func NewRollingSharpeWithParams(period int, riskFree float64) (*indicator.RollingSharpe, error) {
+	return indicator.NewRollingSharpeWithParams(period, riskFree)
+}
+
 // ---- Indicator suite ----
 type ScalpingIndicatorSuite = suite.ScalpingIndicatorSuite
 type IndicatorSuite = suite.ScalpingIndicatorSuite
 type OptimizedScalpingIndicatorSuite = suite.OptimizedScalpingIndicatorSuite
+type SuiteSnapshot = suite.SuiteSnapshot
+type SuiteDiff = suite.SuiteDiff
+type SuiteState = suite.SuiteState
+type SuiteOption = suite.SuiteOption
+type Signal = suite.Signal
+
+const (
+	StrongSell = suite.StrongSell
+	Sell       = suite.Sell
+	Neutral    = suite.Neutral
+	Buy        = suite.Buy
+	StrongBuy  = suite.StrongBuy
+)
+
+type Regime = suite.Regime
+
+const (
+	RegimeRangeBound     = suite.RegimeRangeBound
+	RegimeTrendingUp     = suite.RegimeTrendingUp
+	RegimeTrendingDown   = suite.RegimeTrendingDown
+	RegimeVolatileChoppy = suite.RegimeVolatileChoppy
+)
+
+func WithSuiteAutoCorrect(enabled bool) suite.SuiteOption {
+	return suite.WithAutoCorrect(enabled)
+}
+
+func WithMomentumConfirmation(bars int, boost float64) suite.SuiteOption {
+	return suite.WithMomentumConfirmation(bars, boost)
+}
+
+func WithSignalHysteresis(bars int) suite.SuiteOption {
+	return suite.WithSignalHysteresis(bars)
+}
 
 func NewScalpingIndicatorSuite() (*suite.ScalpingIndicatorSuite, error) {
 	return suite.NewScalpingIndicatorSuite()
 }
 
-func NewScalpingIndicatorSuiteWithConfig(cfg config.IndicatorConfig) (*suite.ScalpingIndicatorSuite, error) {
-	return suite.NewScalpingIndicatorSuiteWithConfig(cfg)
+func NewScalpingIndicatorSuiteWithConfig(cfg config.IndicatorConfig, opts ...suite.SuiteOption) (*suite.ScalpingIndicatorSuite, error) {
+	return suite.NewScalpingIndicatorSuiteWithConfig(cfg, opts...)
 }
 
 func NewOptimizedScalpingIndicatorSuite() (*suite.OptimizedScalpingIndicatorSuite, error) {
@@ -217,6 +838,44 @@ func NewOptimizedScalpingIndicatorSuiteWithConfig(cfg config.IndicatorConfig) (*
 	return suite.NewOptimizedScalpingIndicatorSuiteWithConfig(cfg)
 }
 
+type MultiTimeframeSuite = suite.MultiTimeframeSuite
+type Timeframe = suite.Timeframe
+
+func NewMultiTimeframeSuite(cfg config.IndicatorConfig, baseInterval int64, timeframes ...suite.Timeframe) (*suite.MultiTimeframeSuite, error) {
+	return suite.NewMultiTimeframeSuite(cfg, baseInterval, timeframes...)
+}
+
+type EnsembleSuite = suite.EnsembleSuite
+type EnsembleMember = suite.EnsembleMember
+
+func NewEnsembleSuite(cfgs ...config.IndicatorConfig) (*suite.EnsembleSuite, error) {
+	return suite.NewEnsembleSuite(cfgs...)
+}
+
+func NewEnsembleSuiteWithMembers(members ...suite.EnsembleMember) (*suite.EnsembleSuite, error) {
+	return suite.NewEnsembleSuiteWithMembers(members...)
+}
+
+func RunMultiSymbol(cfg config.IndicatorConfig, data map[string][]indicator.OHLCV) (map[string]suite.SuiteSnapshot, error) {
+	return suite.RunMultiSymbol(cfg, data)
+}
+
+// ---- Backtesting ----
+type BacktestAction = backtest.Action
+type BacktestRules = backtest.Rules
+type BacktestTrade = backtest.Trade
+type BacktestResult = backtest.Result
+
+const (
+	BacktestHold  = backtest.Hold
+	BacktestEnter = backtest.Enter
+	BacktestExit  = backtest.Exit
+)
+
+func RunBacktest(s *suite.ScalpingIndicatorSuite, bars []indicator.OHLCV, rules backtest.Rules) backtest.Result {
+	return backtest.Run(s, bars, rules)
+}
+
 // Backwards-compatible aliases for callers expecting the old names.
 func NewIndicatorSuite() (*suite.ScalpingIndicatorSuite, error) {
 	return NewScalpingIndicatorSuite()
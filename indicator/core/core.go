@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 )
 
@@ -37,6 +38,13 @@ const (
 	EMAMovingAverage MovingAverageType = "EMA"
 	SMAMovingAverage MovingAverageType = "SMA"
 	WMAMovingAverage MovingAverageType = "WMA"
+	// RMAMovingAverage is Wilder's running average: an EMA with alpha = 1/period,
+	// as used by the original RSI and ATR definitions.
+	RMAMovingAverage MovingAverageType = "RMA"
+	// DEMAMovingAverage is Mulloy's double EMA, 2·EMA − EMA(EMA), which
+	// cancels most of the EMA's lag. With SMA seeding its first value needs
+	// 2·period − 1 samples.
+	DEMAMovingAverage MovingAverageType = "DEMA"
 )
 
 // MovingAverage calculates Simple or Exponential Moving Average
@@ -51,14 +59,76 @@ type MovingAverage struct {
 	sampleCount    int
 	emaSeedSum     float64
 	emaInitialized bool
+
+	earlyValues bool        // return best-effort values before the period is filled
+	emaSeed     EMASeedMode // how the EMA recursion is started
+	taLibCompat bool        // reproduce TA-Lib's TA_EMA bit for bit
+	invertPrice bool        // Add feeds 1/price (see WithInvertedPrice)
+
+	demaInner *MovingAverage // EMA of the EMA (DEMA only)
+}
+
+// EMASeedMode selects how an EMA obtains its first value.
+type EMASeedMode int
+
+const (
+	// SeedSMA seeds the EMA with the simple average of the first `period`
+	// samples; no value is available before then. This is the default.
+	SeedSMA EMASeedMode = iota
+	// SeedFirstValue seeds the EMA with the very first sample and applies the
+	// smoothing recursion from the second sample on, as pandas'
+	// ewm(adjust=False) and several charting platforms do.
+	SeedFirstValue
+)
+
+// MAOption configures a MovingAverage instance.
+type MAOption func(*MovingAverage)
+
+// WithEarlyValues lets Calculate return an approximation before `period`
+// samples exist instead of an error: the SMA/WMA/EMA-seed of whatever data is
+// available. CalculateWithWarmup reports whether the value is fully warm.
+func WithEarlyValues(enabled bool) MAOption {
+	return func(ma *MovingAverage) { ma.earlyValues = enabled }
+}
+
+// WithEMASeed selects the EMA/RMA seeding strategy; it has no effect on
+// SMA/WMA.
+//
+// The two modes disagree during warm-up: SeedFirstValue is pulled toward the
+// first sample, and the gap to SeedSMA shrinks by a factor of (1-alpha) per
+// bar after the SMA seed, so the series only agree to within rounding after
+// several periods.
+// With SeedFirstValue, Calculate succeeds from the first sample and
+// CalculateWithWarmup reports warm once `period` samples have been seen.
+func WithEMASeed(seed EMASeedMode) MAOption {
+	return func(ma *MovingAverage) { ma.emaSeed = seed }
+}
+
+// WithTALibCompat makes an EMA reproduce TA-Lib's TA_EMA: the SMA of the
+// first `period` samples is the first output, at index period-1, and later
+// values use TA-Lib's ((x - prev) * k) + prev form of the recursion, so the
+// series matches to the last bit rather than to within rounding. It overrides
+// WithEMASeed and WithEarlyValues on an EMA and has no effect on other types.
+func WithTALibCompat(enabled bool) MAOption {
+	return func(ma *MovingAverage) { ma.taLibCompat = enabled }
+}
+
+// WithInvertedPrice makes Add average 1/price instead of the price (see
+// InvertPrice), so the MA tracks the inverted series used for short-side
+// research. AddValue, which takes arbitrary values, is not transformed.
+func WithInvertedPrice(enabled bool) MAOption {
+	return func(ma *MovingAverage) { ma.invertPrice = enabled }
 }
 
-// NewMovingAverage initializes a MovingAverage with the specified type and period
-func NewMovingAverage(maType MovingAverageType, period int) (*MovingAverage, error) {
+// NewMovingAverage initializes a MovingAverage with the specified type and
+// period. Functional options are applied last.
+func NewMovingAverage(maType MovingAverageType, period int, opts ...MAOption) (*MovingAverage, error) {
 	if period < 1 {
 		return nil, errors.New("period must be at least 1")
 	}
-	if maType != SMAMovingAverage && maType != EMAMovingAverage && maType != WMAMovingAverage {
+	switch maType {
+	case SMAMovingAverage, EMAMovingAverage, WMAMovingAverage, RMAMovingAverage, DEMAMovingAverage:
+	default:
 		return nil, errors.New("invalid moving average type")
 	}
 	ma := &MovingAverage{
@@ -66,6 +136,16 @@ func NewMovingAverage(maType MovingAverageType, period int) (*MovingAverage, err
 		period: period,
 		values: make([]float64, 0, period),
 	}
+	for _, opt := range opts {
+		opt(ma)
+	}
+	if ma.taLibCompat && maType == EMAMovingAverage {
+		ma.emaSeed = SeedSMA
+		ma.earlyValues = false
+	}
+	if maType == DEMAMovingAverage {
+		ma.demaInner = &MovingAverage{maType: EMAMovingAverage, period: period, emaSeed: ma.emaSeed}
+	}
 	return ma, nil
 }
 
@@ -80,6 +160,13 @@ func (ma *MovingAverage) Add(value float64) error {
 	if !isNonNegativePrice(value) {
 		return fmt.Errorf("cannot add negative or NaN price %f", value)
 	}
+	if ma.invertPrice {
+		inv, err := InvertPrice(value)
+		if err != nil {
+			return err
+		}
+		value = inv
+	}
 	ma.pushSample(value)
 	return nil
 }
@@ -97,8 +184,11 @@ func (ma *MovingAverage) AddValue(value float64) error {
 func (ma *MovingAverage) pushSample(value float64) {
 	ma.values = append(ma.values, value)
 	ma.sampleCount++
-	if ma.maType == EMAMovingAverage {
+	if ma.isExponential() {
 		ma.updateEMA(value)
+		if ma.demaInner != nil && ma.emaInitialized {
+			ma.demaInner.pushSample(ma.lastValue)
+		}
 	}
 	ma.trimSlices()
 }
@@ -113,8 +203,14 @@ func (ma *MovingAverage) updateEMA(latest float64) {
 		return
 	}
 
+	if ma.emaSeed == SeedFirstValue && !ma.emaInitialized {
+		ma.lastValue = latest
+		ma.emaInitialized = true
+		return
+	}
+
 	// Accumulate the first `period` values to seed the EMA with an SMA.
-	if ma.sampleCount <= ma.period {
+	if ma.emaSeed == SeedSMA && ma.sampleCount <= ma.period {
 		ma.emaSeedSum += latest
 		if ma.sampleCount < ma.period {
 			return
@@ -129,9 +225,29 @@ func (ma *MovingAverage) updateEMA(latest float64) {
 		ma.emaInitialized = true
 	}
 	alpha := 2.0 / float64(ma.period+1)
+	if ma.maType == RMAMovingAverage {
+		alpha = 1.0 / float64(ma.period)
+	} else if ma.taLibCompat {
+		ma.lastValue = (latest-ma.lastValue)*alpha + ma.lastValue
+		return
+	}
 	ma.lastValue = alpha*latest + (1-alpha)*ma.lastValue
 }
 
+// isExponential reports whether the type uses the recursive EMA machinery.
+func (ma *MovingAverage) isExponential() bool {
+	return ma.maType == EMAMovingAverage || ma.maType == RMAMovingAverage || ma.maType == DEMAMovingAverage
+}
+
+// demaValue combines the EMA with its inner EMA into 2·EMA − EMA(EMA).
+func (ma *MovingAverage) demaValue() (float64, error) {
+	inner, err := ma.demaInner.Calculate()
+	if err != nil {
+		return 0, err
+	}
+	return 2*ma.lastValue - inner, nil
+}
+
 /* -------------------------------------------------------------------------
    Core calculation
 --------------------------------------------------------------------------*/
@@ -143,9 +259,50 @@ func (ma *MovingAverage) trimSlices() {
 // Calculate returns the current moving‑average value.
 // The slice has already been trimmed by Add, so we can operate directly on it.
 func (ma *MovingAverage) Calculate() (float64, error) {
+	v, _, err := ma.CalculateWithWarmup()
+	return v, err
+}
+
+// CalculateWithWarmup behaves like Calculate but also reports whether the
+// value is based on a full period. With WithEarlyValues enabled it returns an
+// approximate value (warm == false) as soon as one sample exists.
+func (ma *MovingAverage) CalculateWithWarmup() (float64, bool, error) {
+	if ma.isExponential() && ma.emaSeed == SeedFirstValue && ma.emaInitialized {
+		if ma.maType == DEMAMovingAverage {
+			v, err := ma.demaValue()
+			return v, err == nil && ma.sampleCount >= ma.period, err
+		}
+		return ma.lastValue, ma.sampleCount >= ma.period, nil
+	}
 	if len(ma.values) < ma.period {
-		return 0, fmt.Errorf("insufficient data: need %d, have %d", ma.period, len(ma.values))
+		if ma.earlyValues && len(ma.values) > 0 {
+			return ma.earlyValue(), false, nil
+		}
+		return 0, false, fmt.Errorf("insufficient data: need %d, have %d", ma.period, len(ma.values))
 	}
+	v, err := ma.calculateFull()
+	return v, err == nil, err
+}
+
+// earlyValue approximates the average from the samples seen so far.
+func (ma *MovingAverage) earlyValue() float64 {
+	n := len(ma.values)
+	switch ma.maType {
+	case WMAMovingAverage:
+		v, _ := calculateWMA(ma.values, n)
+		return v
+	case EMAMovingAverage, RMAMovingAverage, DEMAMovingAverage:
+		return ma.emaSeedSum / float64(ma.sampleCount)
+	default:
+		sum := 0.0
+		for _, v := range ma.values {
+			sum += v
+		}
+		return sum / float64(n)
+	}
+}
+
+func (ma *MovingAverage) calculateFull() (float64, error) {
 
 	switch ma.maType {
 	case SMAMovingAverage:
@@ -156,12 +313,19 @@ func (ma *MovingAverage) Calculate() (float64, error) {
 		}
 		return sum / float64(ma.period), nil
 
-	case EMAMovingAverage:
+	case EMAMovingAverage, RMAMovingAverage:
 		if !ma.emaInitialized {
 			return 0, fmt.Errorf("insufficient data: need %d, have %d", ma.period, len(ma.values))
 		}
 		return ma.lastValue, nil
 
+	case DEMAMovingAverage:
+		v, err := ma.demaValue()
+		if err != nil {
+			return 0, fmt.Errorf("insufficient data: need %d, have %d", 2*ma.period-1, ma.sampleCount)
+		}
+		return v, nil
+
 	case WMAMovingAverage:
 		// Weighted Moving Average.
 		return calculateWMA(ma.values, ma.period)
@@ -171,6 +335,91 @@ func (ma *MovingAverage) Calculate() (float64, error) {
 	}
 }
 
+// ConfidenceBands returns mean ± z·stderr for an SMA, where stderr is the
+// sample standard deviation of the window divided by √period – a confidence
+// interval for the mean itself, much narrower than Bollinger's ±k·stddev.
+// It requires SMA mode, a period of at least 2 and a full window.
+func (ma *MovingAverage) ConfidenceBands(z float64) (lower, upper float64, err error) {
+	if ma.maType != SMAMovingAverage {
+		return 0, 0, fmt.Errorf("confidence bands require an SMA, have %s", ma.maType)
+	}
+	if ma.period < 2 {
+		return 0, 0, errors.New("confidence bands require a period of at least 2")
+	}
+	if len(ma.values) < ma.period {
+		return 0, 0, fmt.Errorf("insufficient data: need %d, have %d", ma.period, len(ma.values))
+	}
+	n := float64(ma.period)
+	mean, _ := ma.calculateFull()
+	ss := 0.0
+	for _, v := range ma.values {
+		ss += (v - mean) * (v - mean)
+	}
+	stderr := math.Sqrt(ss/(n-1)) / math.Sqrt(n)
+	return mean - z*stderr, mean + z*stderr, nil
+}
+
+// EstimateLag measures how many bars a moving average of ma's type, period
+// and EMA seeding needs to cover half of a step in testSeries, using
+// EstimateSeriesLag on the average's output. ma itself is not modified. NaN
+// is returned when the series has no step, the average is not warm on the
+// bar before it, or the halfway level is never reached.
+func EstimateLag(ma *MovingAverage, testSeries []float64) float64 {
+	probe, err := NewMovingAverage(ma.maType, ma.period, WithEMASeed(ma.emaSeed))
+	if err != nil {
+		return math.NaN()
+	}
+	output := make([]float64, len(testSeries))
+	for i, v := range testSeries {
+		if probe.AddValue(v) != nil {
+			return math.NaN()
+		}
+		out, err := probe.Calculate()
+		if err != nil {
+			out = math.NaN()
+		}
+		output[i] = out
+	}
+	return EstimateSeriesLag(testSeries, output)
+}
+
+// EstimateSeriesLag measures how many bars output, the response of any
+// indicator to input, needs to cover half of the step in input. The step is
+// the largest jump between consecutive inputs; output must be aligned with
+// input and finite from the bar before the step (use NaN for bars without a
+// value). The crossing is interpolated linearly between bars and counted from
+// the step bar, so an SMA of period n reports n/2 - 1 and a response that
+// jumps the full step at once reports a negative lag. NaN is returned when the
+// lengths differ, the input has no step, output is missing on or after the
+// bar before it, or the halfway level is never reached.
+func EstimateSeriesLag(input, output []float64) float64 {
+	if len(input) != len(output) {
+		return math.NaN()
+	}
+	step, size := 0, 0.0
+	for i := 1; i < len(input); i++ {
+		if d := math.Abs(input[i] - input[i-1]); d > size {
+			step, size = i, d
+		}
+	}
+	if step == 0 {
+		return math.NaN()
+	}
+	before, jump := input[step-1], input[step]-input[step-1]
+	prevFrac := math.NaN()
+	for i := step - 1; i < len(output); i++ {
+		if math.IsNaN(output[i]) || math.IsInf(output[i], 0) {
+			return math.NaN()
+		}
+		frac := (output[i] - before) / jump
+		if i >= step && frac >= 0.5 {
+			return float64(i-step-1) + (0.5-prevFrac)/(frac-prevFrac)
+		}
+		prevFrac = frac
+	}
+	return math.NaN()
+}
+
 /* -------------------------------------------------------------------------
    Miscellaneous helpers
 --------------------------------------------------------------------------*/
@@ -181,6 +430,10 @@ func (ma *MovingAverage) Reset() {
 	ma.sampleCount = 0
 	ma.emaSeedSum = 0
 	ma.emaInitialized = false
+	if ma.demaInner != nil {
+		ma.demaInner.period = ma.period
+		ma.demaInner.Reset()
+	}
 }
 
 func (ma *MovingAverage) SetPeriod(period int) error {
@@ -207,6 +460,9 @@ type PlotData struct {
 	Type      string    `json:"type,omitempty"`
 	Signal    string    `json:"signal,omitempty"`
 	Timestamp []int64   `json:"timestamp,omitempty"`
+	// Pane groups series that belong on the same chart panel, e.g. the MACD
+	// line, signal and histogram. Empty leaves placement to the consumer.
+	Pane string `json:"pane,omitempty"`
 }
 
 func copySlice(src []float64) []float64 {
@@ -344,6 +600,19 @@ func IsValidPrice(price float64) bool { return isValidPrice(price) }
 // IsNonNegativePrice exposes the non-negative price validator.
 func IsNonNegativePrice(price float64) bool { return isNonNegativePrice(price) }
 
+// InvertPrice returns 1/price, the price-inversion transform behind the
+// WithInvertedPrice options. A reciprocal rather than a negation keeps the
+// inverted series a valid positive price, so log returns and divergence
+// checks still work: every move changes direction and log returns flip sign
+// exactly, though percentage moves are only approximately mirrored (a +10%
+// bar becomes -9.09%). Zero has no reciprocal and is rejected.
+func InvertPrice(price float64) (float64, error) {
+	if !isValidPrice(price) {
+		return 0, fmt.Errorf("cannot invert price %f: must be positive and finite", price)
+	}
+	return 1 / price, nil
+}
+
 // IsValidVolume exposes the volume validator.
 func IsValidVolume(volume float64) bool { return isValidVolume(volume) }
 
@@ -362,6 +631,68 @@ func GenerateTimestamps(startTime int64, count int, interval int64) []int64 {
 	return ts
 }
 
+// OverlayPlotData builds a price-chart overlay series keyed to absolute bar
+// indices rather than re-based to 0. values is the retained tail of a series
+// whose latest value belongs to bar lastBar (counted from 0), so value i is
+// plotted at X = lastBar-len(values)+1+i with timestamp startTime+X·interval.
+// Overlays built this way line up with each other and with the candles
+// however much history each indicator has trimmed.
+func OverlayPlotData(name string, values []float64, lastBar int, startTime, interval int64) PlotData {
+	first := lastBar - len(values) + 1
+	x := make([]float64, len(values))
+	ts := make([]int64, len(values))
+	for i := range values {
+		x[i] = float64(first + i)
+		ts[i] = startTime + int64(first+i)*interval
+	}
+	return PlotData{Name: name, X: x, Y: copySlice(values), Type: "line", Timestamp: ts}
+}
+
+// SlicePlotData restricts every series to the points whose X index lies in
+// [from, to). X values are bar indices, so timestamps stay aligned with the
+// original export. Out-of-range bounds are clamped; series left without any
+// points are dropped. Every indicator's GetPlotDataRange is its GetPlotData
+// passed through SlicePlotData.
+func SlicePlotData(data []PlotData, from, to int) []PlotData {
+	if from < 0 {
+		from = 0
+	}
+	if to <= from {
+		return nil
+	}
+	out := make([]PlotData, 0, len(data))
+	for _, d := range data {
+		start, end := -1, -1
+		for i, x := range d.X {
+			if x >= float64(from) && start < 0 {
+				start = i
+			}
+			if x < float64(to) {
+				end = i + 1
+			}
+		}
+		if start < 0 || end <= start {
+			continue
+		}
+		sliced := d
+		sliced.X = d.X[start:end]
+		sliced.Y = sliceClamped(d.Y, start, end)
+		sliced.Timestamp = sliceClamped(d.Timestamp, start, end)
+		out = append(out, sliced)
+	}
+	return out
+}
+
+// sliceClamped returns s[start:end] with both bounds clamped to len(s), so a
+// field shorter than X is cut at the same positions instead of kept whole.
+func sliceClamped[T any](s []T, start, end int) []T {
+	if s == nil {
+		return nil
+	}
+	start, end = min(start, len(s)), min(end, len(s))
+	return s[start:end]
+}
+
 func FormatPlotDataJSON(data []PlotData) (string, error) {
 	if len(data) == 0 {
 		return "[]", nil
@@ -378,24 +709,47 @@ func FormatPlotDataJSON(data []PlotData) (string, error) {
 	return string(b), nil
 }
 
-func FormatPlotDataCSV(data []PlotData) (string, error) {
-	if len(data) == 0 {
-		return "", nil
-	}
-	var sb strings.Builder
-	sb.WriteString("Name,X,Y,Type,Signal,Timestamp\n")
-	for _, d := range data {
+// PlotDataSchemaVersion is the schema version written by FormatPlotDataJSONV2.
+const PlotDataSchemaVersion = 2
+
+// PlotDataEnvelope is the versioned JSON export format. Meta carries free-form
+// annotations such as the symbol or timeframe the series were computed on.
+type PlotDataEnvelope struct {
+	Version int               `json:"version"`
+	Series  []PlotData        `json:"series"`
+	Meta    map[string]string `json:"meta,omitempty"`
+}
+
+// FormatPlotDataJSONV2 marshals data inside a versioned envelope:
+// {"version": 2, "series": [...]}. FormatPlotDataJSON keeps emitting the flat
+// v1 array for existing consumers.
+func FormatPlotDataJSONV2(data []PlotData) (string, error) {
+	return FormatPlotDataEnvelopeJSON(PlotDataEnvelope{Series: data})
+}
+
+// FormatPlotDataEnvelopeJSON marshals env, stamping the current schema version.
+// Use it when the export should carry Meta annotations.
+func FormatPlotDataEnvelopeJSON(env PlotDataEnvelope) (string, error) {
+	for _, d := range env.Series {
 		if len(d.X) != len(d.Y) {
 			return "", fmt.Errorf("mismatched X and Y lengths for %s: %d vs %d", d.Name, len(d.X), len(d.Y))
 		}
-		for i := 0; i < len(d.X); i++ {
-			ts := ""
-			if i < len(d.Timestamp) {
-				ts = fmt.Sprintf("%d", d.Timestamp[i])
-			}
-			fmt.Fprintf(&sb, "%s,%f,%f,%s,%s,%s\n",
-				d.Name, d.X[i], d.Y[i], d.Type, d.Signal, ts)
-		}
+	}
+	env.Version = PlotDataSchemaVersion
+	if env.Series == nil {
+		env.Series = []PlotData{}
+	}
+	b, err := json.Marshal(env)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal plot data: %w", err)
+	}
+	return string(b), nil
+}
+
+func FormatPlotDataCSV(data []PlotData) (string, error) {
+	var sb strings.Builder
+	if err := StreamPlotDataCSV(&sb, data); err != nil {
+		return "", err
 	}
 	return sb.String(), nil
 }
@@ -432,3 +786,407 @@ func CalculateEMA(data []float64, period int, prevEMA float64) (float64, error)
 func CalculateWMA(data []float64, period int) (float64, error) {
 	return calculateWMA(data, period)
 }
+
+// CorrectCandle repairs an inconsistent bar instead of rejecting it: high and
+// low are swapped when inverted and close is clamped into [low, high]. The
+// boolean reports whether anything was changed.
+func CorrectCandle(high, low, close float64) (float64, float64, float64, bool) {
+	corrected := false
+	if high < low {
+		high, low = low, high
+		corrected = true
+	}
+	if close < low || close > high {
+		close = clamp(close, low, high)
+		corrected = true
+	}
+	return high, low, close, corrected
+}
+
+// Percentile returns the pct-th percentile (0–100) of values using linear
+// interpolation between the closest ranks. The input is not modified.
+func Percentile(values []float64, pct float64) (float64, error) {
+	if len(values) == 0 {
+		return 0, errors.New("no values")
+	}
+	if math.IsNaN(pct) || pct < 0 || pct > 100 {
+		return 0, fmt.Errorf("percentile must be within [0, 100], got %v", pct)
+	}
+	sorted := copySlice(values)
+	sort.Float64s(sorted)
+	rank := pct / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	frac := rank - float64(lo)
+	return sorted[lo] + (sorted[hi]-sorted[lo])*frac, nil
+}
+
+// Histogram splits the range from the minimum to the maximum of values into
+// the given number of equal-width bins and counts the values in each. edges
+// holds the bins+1 boundaries; bin i covers
+// [edges[i], edges[i+1]), except the last, which also includes the maximum.
+// NaN and ±Inf are skipped. When every value is equal the bins span
+// [v−0.5, v+0.5] so they keep a positive width. bins < 1 or no finite values
+// yield nil slices.
+func Histogram(values []float64, bins int) (edges []float64, counts []int) {
+	if bins < 1 {
+		return nil, nil
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	if lo > hi {
+		return nil, nil
+	}
+	if lo == hi {
+		lo, hi = lo-0.5, hi+0.5
+	}
+	width := (hi - lo) / float64(bins)
+	edges = make([]float64, bins+1)
+	for i := range edges {
+		edges[i] = lo + float64(i)*width
+	}
+	edges[bins] = hi
+	counts = make([]int, bins)
+	for _, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
+		counts[min(int((v-lo)/width), bins-1)]++
+	}
+	return edges, counts
+}
+
+// Returns computes bar-to-bar simple returns (p[i]/p[i-1] - 1). A zero
+// previous price yields a zero return rather than ±Inf.
+func Returns(prices []float64) []float64 {
+	if len(prices) < 2 {
+		return nil
+	}
+	out := make([]float64, len(prices)-1)
+	for i := 1; i < len(prices); i++ {
+		if prices[i-1] != 0 {
+			out[i-1] = prices[i]/prices[i-1] - 1
+		}
+	}
+	return out
+}
+
+// LogReturns computes bar-to-bar log returns (ln(p[i]/p[i-1])). Pairs with a
+// zero price yield a zero return rather than ±Inf.
+func LogReturns(prices []float64) []float64 {
+	if len(prices) < 2 {
+		return nil
+	}
+	out := make([]float64, len(prices)-1)
+	for i := 1; i < len(prices); i++ {
+		if prices[i-1] != 0 && prices[i] != 0 {
+			out[i-1] = math.Log(prices[i] / prices[i-1])
+		}
+	}
+	return out
+}
+
+// LogReturnVolatility returns the population standard deviation of the log
+// returns over the last `period` bars of closes, or over every available bar
+// when fewer exist. Closes must be positive; at least two are required.
+func LogReturnVolatility(closes []float64, period int) (float64, error) {
+	if len(closes) < 2 {
+		return 0, errors.New("insufficient data for volatility")
+	}
+	n := min(period, len(closes)-1)
+	if n <= 0 {
+		return 0, errors.New("volatility period resolved to zero")
+	}
+	start := len(closes) - n - 1 // n+1 closes give n returns
+	ret := make([]float64, n)
+	for i := range ret {
+		ret[i] = math.Log(closes[start+i+1] / closes[start+i])
+	}
+	mean := 0.0
+	for _, r := range ret {
+		mean += r
+	}
+	mean /= float64(n)
+
+	var variance float64
+	for _, r := range ret {
+		diff := r - mean
+		variance += diff * diff
+	}
+	variance /= float64(n)
+	return math.Sqrt(variance), nil
+}
+
+// VolatilityToPeriod maps a log-return volatility linearly onto
+// [minPeriod, maxPeriod]: vol/(sensitivity*0.05) is clamped to [0, 1], so
+// zero volatility gives minPeriod and anything at or above sensitivity*5%
+// gives maxPeriod. The result is rounded to the nearest integer.
+func VolatilityToPeriod(vol, sensitivity float64, minPeriod, maxPeriod int) int {
+	// 0.05 is a "typical" maximum per-bar volatility for equity data;
+	// sensitivity rescales it for other markets.
+	normalized := clamp(vol/(sensitivity*0.05), 0, 1)
+	adapt := float64(minPeriod) + normalized*float64(maxPeriod-minPeriod)
+	return max(minPeriod, min(maxPeriod, int(math.Round(adapt))))
+}
+
+// CrossSeries compares two series aligned on their most recent value and
+// reports the latest crossover: lastCross is +1 when a moved above b, -1 when
+// it moved below, and 0 when the series never crossed. index is the bar of
+// that crossover within the overlapping tail (0 = oldest shared bar), or -1.
+// Bars where a == b do not count as a cross by themselves; the direction is
+// judged against the last bar on which the series differed.
+func CrossSeries(a, b []float64) (lastCross int, index int) {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	a, b = a[len(a)-n:], b[len(b)-n:]
+
+	lastCross, index = 0, -1
+	prevSign := 0
+	for i := 0; i < n; i++ {
+		sign := 0
+		switch d := a[i] - b[i]; {
+		case d > 0:
+			sign = 1
+		case d < 0:
+			sign = -1
+		}
+		if sign == 0 {
+			continue
+		}
+		if prevSign != 0 && sign != prevSign {
+			lastCross, index = sign, i
+		}
+		prevSign = sign
+	}
+	return lastCross, index
+}
+
+// DivergenceStrength measures how far a price move and an oscillator move
+// between the same two bars – typically the pivots of a divergence – disagree.
+// Each change is divided by the range its own series covers (priceRange and
+// oscRange, e.g. the high–low span of the bars searched), so both legs are
+// fractions of their scale and the result ranks setups across instruments and
+// oscillators: 0 when both move by the same share of their range, up to 2 for
+// full-range moves in opposite directions. A leg whose range is not positive
+// counts as flat. PivotDivergenceStrength scales it per bar.
+func DivergenceStrength(pricePrev, priceCurr, oscPrev, oscCurr, priceRange, oscRange float64) float64 {
+	return math.Abs(normalizedMove(pricePrev, priceCurr, priceRange) - normalizedMove(oscPrev, oscCurr, oscRange))
+}
+
+// normalizedMove is the change from prev to curr as a fraction of rng.
+func normalizedMove(prev, curr, rng float64) float64 {
+	if !(rng > 0) {
+		return 0
+	}
+	return (curr - prev) / rng
+}
+
+// GainLoss splits a change into a non-negative gain and loss, the inputs of
+// RSI-style averages.
+func GainLoss(diff float64) (gain, loss float64) {
+	if diff > 0 {
+		return diff, 0
+	}
+	return 0, -diff
+}
+
+// RSIFromAverages turns average gain and loss into an RSI with the classic
+// edge cases: 50 with no movement, 100 with no losses, 0 with no gains.
+func RSIFromAverages(avgGain, avgLoss float64) float64 {
+	switch {
+	case avgLoss == 0 && avgGain == 0:
+		return 50
+	case avgLoss == 0:
+		return 100
+	case avgGain == 0:
+		return 0
+	}
+	return Clamp(100-100/(1+avgGain/avgLoss), 0, 100)
+}
+
+// OutputSlope returns the change in an indicator's output over the last n
+// bars, i.e. values[last] - values[last-n]. It errors when n is not positive
+// or the series holds fewer than n+1 values.
+func OutputSlope(values []float64, n int) (float64, error) {
+	if n < 1 {
+		return 0, errors.New("n must be at least 1")
+	}
+	if len(values) < n+1 {
+		return 0, fmt.Errorf("need %d values for a %d-bar slope, have %d", n+1, n, len(values))
+	}
+	last := len(values) - 1
+	return values[last] - values[last-n], nil
+}
+
+// Correlation returns the Pearson correlation of two equally long series. It
+// errors when the lengths differ, fewer than two points exist, or either
+// series is constant.
+func Correlation(a, b []float64) (float64, error) {
+	if len(a) != len(b) {
+		return 0, fmt.Errorf("series lengths differ: %d vs %d", len(a), len(b))
+	}
+	if len(a) < 2 {
+		return 0, errors.New("need at least 2 points for a correlation")
+	}
+	n := float64(len(a))
+	var meanA, meanB float64
+	for i := range a {
+		meanA += a[i]
+		meanB += b[i]
+	}
+	meanA /= n
+	meanB /= n
+	var cov, varA, varB float64
+	for i := range a {
+		da, db := a[i]-meanA, b[i]-meanB
+		cov += da * db
+		varA += da * da
+		varB += db * db
+	}
+	if varA == 0 || varB == 0 {
+		return 0, errors.New("correlation undefined for a constant series")
+	}
+	return Clamp(cov/math.Sqrt(varA*varB), -1, 1), nil
+}
+
+// LaggedCorrelation correlates a against b delayed by each lag from 0 to
+// maxLag – a[i] paired with b[i+lag] – and returns the lag with the highest
+// Pearson correlation. A positive bestLag means a leads b by that many bars;
+// swap the arguments to test the other direction. Lags that leave fewer than
+// two overlapping points or a constant overlap are skipped, ties go to the
+// shorter lag, and bestCorr is NaN when no lag yields a correlation.
+func LaggedCorrelation(a, b []float64, maxLag int) (bestLag int, bestCorr float64) {
+	bestCorr = math.NaN()
+	n := min(len(a), len(b))
+	for lag := 0; lag <= maxLag && lag < n; lag++ {
+		c, err := Correlation(a[:n-lag], b[lag:n])
+		if err != nil {
+			continue
+		}
+		if math.IsNaN(bestCorr) || c > bestCorr {
+			bestLag, bestCorr = lag, c
+		}
+	}
+	return bestLag, bestCorr
+}
+
+// InterpolateLast returns prev + frac*(cur-prev) for the last two values of a
+// series, for drawing a point between bars without touching indicator state.
+// frac must lie in [0, 1] and the series must hold at least two values.
+func InterpolateLast(values []float64, frac float64) (float64, error) {
+	if !(frac >= 0 && frac <= 1) {
+		return 0, fmt.Errorf("fraction %v outside [0, 1]", frac)
+	}
+	if len(values) < 2 {
+		return 0, fmt.Errorf("need 2 values to interpolate, have %d", len(values))
+	}
+	prev, cur := values[len(values)-2], values[len(values)-1]
+	return prev + frac*(cur-prev), nil
+}
+
+// ApplyTransform returns fn(v), or v unchanged when fn is nil. Indicators use
+// it to run their computed outputs through a SetOutputTransform hook.
+func ApplyTransform(fn func(float64) float64, v float64) float64 {
+	if fn == nil {
+		return v
+	}
+	return fn(v)
+}
+
+// ErrNonFinite is wrapped by the errors Validate and strict output checks
+// return when an indicator holds a NaN or ±Inf.
+var ErrNonFinite = errors.New("non-finite value")
+
+// CheckFinite returns an error wrapping ErrNonFinite when v is NaN or ±Inf.
+func CheckFinite(name string, v float64) error {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return fmt.Errorf("%s = %v: %w", name, v, ErrNonFinite)
+	}
+	return nil
+}
+
+// ValidateFinite returns an error naming the first NaN or ±Inf in values and
+// its index, or nil when every value is finite. The error wraps ErrNonFinite.
+func ValidateFinite(name string, values []float64) error {
+	for i, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("%s[%d] = %v: %w", name, i, v, ErrNonFinite)
+		}
+	}
+	return nil
+}
+
+// warmupConfidencePeriods is how many periods of values must follow the first
+// one before WarmupConfidence reports 1. After three periods a Wilder average
+// keeps (1-1/n)^(3n) ≈ e^-3, about 5%, of its seed.
+const warmupConfidencePeriods = 3
+
+// WarmupConfidence maps the number of values an indicator has emitted onto
+// [0, 1]: 0 for the first value, rising linearly to 1 once a further
+// 3*period values have been produced. It is 0 before any value and for a
+// non-positive period.
+func WarmupConfidence(emitted, period int) float64 {
+	if emitted < 1 || period < 1 {
+		return 0
+	}
+	return math.Min(1, float64(emitted-1)/float64(warmupConfidencePeriods*period))
+}
+
+// CrossoverInclusivity selects whether a threshold crossover may start from a
+// previous value exactly on the level.
+type CrossoverInclusivity int
+
+const (
+	// CrossoverDefault keeps each indicator's documented rule: RSI and VWAO
+	// accept a previous value on the level, MFI does for bearish crosses but
+	// not bullish ones.
+	CrossoverDefault CrossoverInclusivity = iota
+	// CrossoverInclusive lets the previous value equal the level.
+	CrossoverInclusive
+	// CrossoverStrict requires the previous value to lie strictly beyond the
+	// level, so a series resting on the level (e.g. a 0 oversold line after
+	// a Reset) does not fire when it moves off it.
+	CrossoverStrict
+)
+
+// AllowsEqual reports whether a previous value on the level counts, using
+// def for CrossoverDefault.
+func (c CrossoverInclusivity) AllowsEqual(def bool) bool {
+	switch c {
+	case CrossoverInclusive:
+		return true
+	case CrossoverStrict:
+		return false
+	default:
+		return def
+	}
+}
+
+// CrossedAbove reports whether the move from prev to cur crossed above level.
+// cur must be strictly above; prev must be below, or on the level when
+// allowEqual is set.
+func CrossedAbove(prev, cur, level float64, allowEqual bool) bool {
+	return (prev < level || allowEqual && prev == level) && cur > level
+}
+
+// CrossedBelow reports whether the move from prev to cur crossed below level,
+// mirroring CrossedAbove.
+func CrossedBelow(prev, cur, level float64, allowEqual bool) bool {
+	return (prev > level || allowEqual && prev == level) && cur < level
+}
+
+// ValidateCrossoverThreshold checks a minimum crossover margin as accepted by
+// the SetCrossoverThreshold setters: it must be finite and non-negative.
+func ValidateCrossoverThreshold(delta float64) error {
+	if !(delta >= 0) || math.IsInf(delta, 1) {
+		return fmt.Errorf("crossover threshold must be finite and non-negative, got %v", delta)
+	}
+	return nil
+}
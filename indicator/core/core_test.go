@@ -1,8 +1,12 @@
 package core // same package as the library code
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
+	"strings"
 	"testing"
 )
 
@@ -224,6 +228,33 @@ func TestWeightedMovingAverage(t *testing.T) {
 	}
 }
 
+func TestMovingAverageWithEarlyValues(t *testing.T) {
+	for _, maType := range []MovingAverageType{SMAMovingAverage, EMAMovingAverage, WMAMovingAverage} {
+		strict, _ := NewMovingAverage(maType, 5)
+		early, err := NewMovingAverage(maType, 5, WithEarlyValues(true))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", maType, err)
+		}
+		_ = strict.Add(10)
+		_ = early.Add(10)
+
+		if _, err := strict.Calculate(); err == nil {
+			t.Fatalf("%s: default mode must error before warm-up", maType)
+		}
+		v, warm, err := early.CalculateWithWarmup()
+		if err != nil || warm || v != 10 {
+			t.Fatalf("%s: expected early value 10 (warm=false), got %v warm=%v err=%v", maType, v, warm, err)
+		}
+
+		for _, x := range []float64{11, 12, 13, 14} {
+			_ = early.Add(x)
+		}
+		if _, warm, err := early.CalculateWithWarmup(); err != nil || !warm {
+			t.Fatalf("%s: expected warm value after a full period, warm=%v err=%v", maType, warm, err)
+		}
+	}
+}
+
 /*
 --------------------------------------------------------------
 
@@ -241,3 +272,1041 @@ func TestValidatePositiveInt(t *testing.T) {
 		t.Fatalf("expected error for negative value")
 	}
 }
+
+/*
+--------------------------------------------------------------
+
+	Plot data helpers
+	--------------------------------------------------------------
+*/
+func TestSlicePlotData(t *testing.T) {
+	x := []float64{0, 1, 2, 3, 4, 5}
+	y := []float64{10, 11, 12, 13, 14, 15}
+	data := []PlotData{
+		{Name: "line", X: x, Y: y, Timestamp: GenerateTimestamps(1000, len(x), 60)},
+		{Name: "signals", X: []float64{1, 5}, Y: []float64{1, -1}, Type: "scatter"},
+	}
+
+	got := SlicePlotData(data, 2, 5)
+	if len(got) != 1 {
+		t.Fatalf("expected only the line series in [2,5), got %d series", len(got))
+	}
+	if len(got[0].X) != 3 || len(got[0].Y) != 3 || len(got[0].Timestamp) != 3 {
+		t.Fatalf("unexpected lengths: x=%d y=%d ts=%d", len(got[0].X), len(got[0].Y), len(got[0].Timestamp))
+	}
+	if got[0].Y[0] != 12 || got[0].Timestamp[0] != 1120 {
+		t.Fatalf("slice misaligned: y=%v ts=%v", got[0].Y, got[0].Timestamp)
+	}
+
+	// Bounds outside the data are clamped.
+	got = SlicePlotData(data, -3, 100)
+	if len(got) != 2 || len(got[0].X) != len(x) {
+		t.Fatalf("clamped range should keep everything, got %+v", got)
+	}
+	if SlicePlotData(data, 4, 4) != nil {
+		t.Fatalf("empty range should yield nil")
+	}
+
+	// Fields shorter than X are cut at the same positions, not kept whole.
+	short := []PlotData{{Name: "short", X: x, Y: y[:4], Timestamp: GenerateTimestamps(1000, 3, 60)}}
+	got = SlicePlotData(short, 2, 6)
+	if len(got) != 1 || len(got[0].X) != 4 {
+		t.Fatalf("expected X[2:6], got %+v", got)
+	}
+	if len(got[0].Y) != 2 || got[0].Y[0] != 12 || len(got[0].Timestamp) != 1 || got[0].Timestamp[0] != 1120 {
+		t.Fatalf("short fields misaligned: y=%v ts=%v", got[0].Y, got[0].Timestamp)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	vals := []float64{5, 1, 4, 2, 3}
+	cases := map[float64]float64{0: 1, 50: 3, 100: 5, 25: 2, 90: 4.6}
+	for pct, want := range cases {
+		got, err := Percentile(vals, pct)
+		if err != nil || math.Abs(got-want) > 1e-9 {
+			t.Fatalf("Percentile(%v) = %v (err %v), want %v", pct, got, err, want)
+		}
+	}
+	if vals[0] != 5 {
+		t.Fatal("Percentile must not reorder its input")
+	}
+	if _, err := Percentile(nil, 50); err == nil {
+		t.Fatal("expected error for empty input")
+	}
+	if _, err := Percentile(vals, 101); err == nil {
+		t.Fatal("expected error for pct > 100")
+	}
+}
+
+func TestFormatPlotDataJSONV2RoundTrip(t *testing.T) {
+	data := []PlotData{
+		{Name: "RSI", X: []float64{0, 1}, Y: []float64{40, 60}, Type: "line", Timestamp: []int64{100, 160}},
+	}
+	out, err := FormatPlotDataEnvelopeJSON(PlotDataEnvelope{
+		Series: data,
+		Meta:   map[string]string{"symbol": "BTCUSD", "timeframe": "1m"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var env PlotDataEnvelope
+	if err := json.Unmarshal([]byte(out), &env); err != nil {
+		t.Fatalf("envelope did not parse: %v", err)
+	}
+	if env.Version != PlotDataSchemaVersion {
+		t.Fatalf("expected version %d, got %d", PlotDataSchemaVersion, env.Version)
+	}
+	if env.Meta["symbol"] != "BTCUSD" || env.Meta["timeframe"] != "1m" {
+		t.Fatalf("meta lost in round trip: %+v", env.Meta)
+	}
+	if len(env.Series) != 1 || env.Series[0].Name != "RSI" || env.Series[0].Y[1] != 60 || env.Series[0].Timestamp[1] != 160 {
+		t.Fatalf("series lost in round trip: %+v", env.Series)
+	}
+
+	empty, err := FormatPlotDataJSONV2(nil)
+	if err != nil || empty != `{"version":2,"series":[]}` {
+		t.Fatalf("unexpected empty envelope %q (err %v)", empty, err)
+	}
+	if _, err := FormatPlotDataJSONV2([]PlotData{{Name: "bad", X: []float64{0}}}); err == nil {
+		t.Fatal("expected error for mismatched X/Y lengths")
+	}
+}
+
+func TestRollingMedianIgnoresOutlier(t *testing.T) {
+	rm, err := NewRollingMedianWithParams(5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sma, _ := NewMovingAverage(SMAMovingAverage, 5)
+	if _, err := rm.Median(); err == nil {
+		t.Fatal("expected error before the window fills")
+	}
+
+	series := []float64{100, 101, 102, 10000, 103, 104, 105}
+	for _, v := range series {
+		_ = rm.Add(v)
+		_ = sma.Add(v)
+	}
+	// Window is {102, 10000, 103, 104, 105}: the spike sits at the top of
+	// the sorted window and never becomes the middle element.
+	med, err := rm.Median()
+	if err != nil || med != 104 {
+		t.Fatalf("expected median 104, got %v (err %v)", med, err)
+	}
+	avg, _ := sma.Calculate()
+	if avg < 2000 {
+		t.Fatalf("SMA should be skewed by the spike, got %v", avg)
+	}
+
+	want := []float64{102, 103, 104}
+	got := rm.GetValues()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d medians, got %v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("median[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	plot := rm.GetPlotData(0, 60)
+	if len(plot) != 1 || len(plot[0].Y) != 3 {
+		t.Fatalf("unexpected plot data: %+v", plot)
+	}
+	if _, err := NewRollingMedianWithParams(0); err == nil {
+		t.Fatal("expected error for period 0")
+	}
+}
+
+func TestCrossSeries(t *testing.T) {
+	a := []float64{1, 2, 3, 4, 5, 6}
+	b := []float64{3, 3, 3, 3, 7, 2}
+	// a touches b at index 2 (no cross), moves above at 3, below at 4 and
+	// back above at 5.
+	dir, idx := CrossSeries(a, b)
+	if dir != 1 || idx != 5 {
+		t.Fatalf("expected bullish cross at 5, got %d at %d", dir, idx)
+	}
+	dir, idx = CrossSeries(a[:5], b[:5])
+	if dir != -1 || idx != 4 {
+		t.Fatalf("expected bearish cross at 4, got %d at %d", dir, idx)
+	}
+
+	// Series of different lengths are aligned on their last element.
+	dir, idx = CrossSeries([]float64{0, 0, 1, 2, 3}, []float64{2.5, 2.5, 2.5})
+	if dir != 1 || idx != 2 {
+		t.Fatalf("expected bullish cross at tail index 2, got %d at %d", dir, idx)
+	}
+
+	if dir, idx := CrossSeries([]float64{1, 2, 3}, []float64{0, 0, 0}); dir != 0 || idx != -1 {
+		t.Fatalf("expected no cross, got %d at %d", dir, idx)
+	}
+}
+
+func TestEMASeedModes(t *testing.T) {
+	series := []float64{10, 20, 12, 13, 15, 14, 16, 18, 17, 19, 21, 20, 22, 24, 23, 25, 27, 26, 28, 30}
+	smaSeed, _ := NewMovingAverage(EMAMovingAverage, 3)
+	firstSeed, _ := NewMovingAverage(EMAMovingAverage, 3, WithEMASeed(SeedFirstValue))
+
+	alpha := 2.0 / 4.0
+	want := series[0]
+	for i, v := range series {
+		_ = smaSeed.Add(v)
+		_ = firstSeed.Add(v)
+		if i > 0 {
+			want = alpha*v + (1-alpha)*want
+		}
+
+		got, warm, err := firstSeed.CalculateWithWarmup()
+		if err != nil {
+			t.Fatalf("first-value seed should be available from bar %d: %v", i, err)
+		}
+		if math.Abs(got-want) > 1e-9 {
+			t.Fatalf("bar %d: first-value EMA %v, want %v", i, got, want)
+		}
+		if warm != (i >= 2) {
+			t.Fatalf("bar %d: unexpected warm flag %v", i, warm)
+		}
+		if i < 2 {
+			if _, err := smaSeed.Calculate(); err == nil {
+				t.Fatalf("bar %d: SMA seed must not produce a value yet", i)
+			}
+		}
+		if i == 2 {
+			// SMA seed = mean(10, 20, 12) = 14; first-value seed = 13.5.
+			if v, _ := smaSeed.Calculate(); v != 14 || got != 13.5 {
+				t.Fatalf("expected 14 vs 13.5 at the seed bar, got %v vs %v", v, got)
+			}
+		}
+	}
+
+	// At the seed bar the two modes differ; by the end they have converged.
+	a, _ := smaSeed.Calculate()
+	b, _ := firstSeed.Calculate()
+	if math.Abs(a-b) > 1e-3 {
+		t.Fatalf("seeding modes should converge, got %v vs %v", a, b)
+	}
+}
+
+func TestEMATALibCompat(t *testing.T) {
+	input := []float64{44.34, 44.09, 44.15, 43.61, 44.33, 44.83, 45.10, 45.42, 45.84, 46.28, 46.00, 46.03, 46.41, 46.22, 45.64}
+	// TA_EMA(input, 5): lookback 4, seeded with the SMA of the first five.
+	want := []float64{
+		44.104000000000, 44.346000000000, 44.597333333333, 44.871555555556,
+		45.194370370370, 45.556246913580, 45.704164609053, 45.812776406036,
+		46.011850937357, 46.081233958238, 45.934155972159,
+	}
+	// The compat option must win over options that would emit earlier values.
+	ma, err := NewMovingAverage(EMAMovingAverage, 5, WithTALibCompat(true), WithEarlyValues(true), WithEMASeed(SeedFirstValue))
+	if err != nil {
+		t.Fatalf("constructor failed: %v", err)
+	}
+	var got []float64
+	for i, v := range input {
+		_ = ma.Add(v)
+		val, err := ma.Calculate()
+		if i < 4 {
+			if err == nil {
+				t.Fatalf("index %d: TA-Lib emits nothing before index 4, got %v", i, val)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("index %d: unexpected error %v", i, err)
+		}
+		got = append(got, val)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d outputs, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			t.Fatalf("output %d: got %.12f, want %.12f", i, got[i], want[i])
+		}
+	}
+}
+
+func TestStreamPlotDataCSVMatchesBatch(t *testing.T) {
+	data := []PlotData{
+		{Name: "RSI", X: []float64{0, 1, 2}, Y: []float64{45.5, 50, 61.25}, Type: "line", Timestamp: []int64{0, 60, 120}},
+		{Name: "Signals", X: []float64{0, 1}, Y: []float64{0, 1}, Type: "scatter", Signal: "buy"},
+	}
+	batch, err := FormatPlotDataCSV(data)
+	if err != nil {
+		t.Fatalf("batch format failed: %v", err)
+	}
+	if !strings.HasPrefix(batch, "Name,X,Y,Type,Signal,Timestamp\nRSI,0.000000,45.500000,line,,0\n") {
+		t.Fatalf("unexpected CSV layout: %q", batch)
+	}
+
+	var buf bytes.Buffer
+	if err := StreamPlotDataCSV(&buf, data); err != nil {
+		t.Fatalf("stream failed: %v", err)
+	}
+	if buf.String() != batch {
+		t.Fatalf("streamed CSV differs from batch:\n%q\n%q", buf.String(), batch)
+	}
+
+	// Feeding the writer one bar at a time yields the same bytes.
+	buf.Reset()
+	pw, _ := NewPlotWriter(&buf, PlotFormatCSV)
+	for _, d := range data {
+		for i := range d.X {
+			bar := PlotData{Name: d.Name, X: d.X[i : i+1], Y: d.Y[i : i+1], Type: d.Type, Signal: d.Signal}
+			if i < len(d.Timestamp) {
+				bar.Timestamp = d.Timestamp[i : i+1]
+			}
+			if err := pw.Write(bar); err != nil {
+				t.Fatalf("write failed: %v", err)
+			}
+		}
+	}
+	if buf.String() != batch {
+		t.Fatalf("bar-by-bar CSV differs from batch:\n%q\n%q", buf.String(), batch)
+	}
+
+	buf.Reset()
+	pw, _ = NewPlotWriter(&buf, PlotFormatNDJSON)
+	for _, d := range data {
+		_ = pw.Write(d)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("expected 5 NDJSON lines, got %d: %q", len(lines), buf.String())
+	}
+	var first map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("invalid NDJSON line %q: %v", lines[0], err)
+	}
+	if first["name"] != "RSI" || first["y"] != 45.5 || first["timestamp"] != 0.0 {
+		t.Fatalf("unexpected NDJSON point %v", first)
+	}
+	if strings.Contains(lines[4], "timestamp") {
+		t.Fatalf("points without timestamps must omit the field: %q", lines[4])
+	}
+
+	if err := StreamPlotDataCSV(&buf, []PlotData{{Name: "bad", X: []float64{1}}}); err == nil {
+		t.Fatal("expected error for mismatched X/Y lengths")
+	}
+}
+
+func TestWilderMovingAverage(t *testing.T) {
+	rma, err := NewMovingAverage(RMAMovingAverage, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, v := range []float64{4, 6, 8, 16} {
+		_ = rma.Add(v)
+	}
+	// Seed = mean(4, 6, 8) = 6, then (6*2 + 16) / 3.
+	got, err := rma.Calculate()
+	if err != nil || math.Abs(got-28.0/3) > 1e-9 {
+		t.Fatalf("expected RMA %v, got %v (err %v)", 28.0/3, got, err)
+	}
+}
+
+func TestOutputSlope(t *testing.T) {
+	values := []float64{10, 12, 14, 16}
+	if got, err := OutputSlope(values, 1); err != nil || got != 2 {
+		t.Fatalf("1-bar slope: got %v, %v", got, err)
+	}
+	if got, err := OutputSlope(values, 3); err != nil || got != 6 {
+		t.Fatalf("3-bar slope: got %v, %v", got, err)
+	}
+	if _, err := OutputSlope(values, 4); err == nil {
+		t.Fatal("expected error when n exceeds history")
+	}
+	if _, err := OutputSlope(values, 0); err == nil {
+		t.Fatal("expected error for n = 0")
+	}
+}
+
+func TestSessionRangeResetsAtBoundary(t *testing.T) {
+	sr := NewSessionRange(DailySessionBoundary(0))
+	day1 := int64(1_700_006_400_000) // 2023-11-15 00:00 UTC
+	for i, hl := range [][2]float64{{101, 99}, {103, 100}, {110, 95}} {
+		if err := sr.AddWithTime(hl[0], hl[1], day1+int64(i)*15*MillisPerMinute); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if sr.High() != 110 || sr.Low() != 95 {
+		t.Fatalf("day 1 range: got %v/%v", sr.High(), sr.Low())
+	}
+	if h, l := sr.OpeningRange(30); h != 103 || l != 99 {
+		t.Fatalf("day 1 opening range: got %v/%v", h, l)
+	}
+
+	day2 := day1 + MillisPerDay + 9*60*MillisPerMinute
+	bars := [][2]float64{{50, 48}, {52, 49}, {60, 40}}
+	for i, hl := range bars {
+		if err := sr.AddWithTime(hl[0], hl[1], day2+int64(i)*20*MillisPerMinute); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if sr.SessionStart() != day2 {
+		t.Fatalf("expected session start %d, got %d", day2, sr.SessionStart())
+	}
+	if sr.High() != 60 || sr.Low() != 40 {
+		t.Fatalf("day 2 range: got %v/%v", sr.High(), sr.Low())
+	}
+	if h, l := sr.OpeningRange(30); h != 52 || l != 48 {
+		t.Fatalf("day 2 opening range: got %v/%v", h, l)
+	}
+	if err := sr.AddWithTime(55, 50, day2); err == nil {
+		t.Fatal("expected error for out-of-order timestamp")
+	}
+}
+
+func TestTickAggregatorTimeBars(t *testing.T) {
+	ta, err := NewTimeTickAggregator(MillisPerMinute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ticks := []struct {
+		price, size float64
+		ts          int64
+	}{
+		{100, 1, 1_200_000}, {102, 2, 1_210_000}, {99, 1.5, 1_230_000}, {101, 0.5, 1_259_999},
+	}
+	for _, tk := range ticks {
+		if _, complete := ta.AddTick(tk.price, tk.size, tk.ts); complete {
+			t.Fatalf("bar completed early at ts %d", tk.ts)
+		}
+	}
+	if _, complete := ta.AddTick(-1, 1, 1_259_999); complete || ta.Rejected() != 1 {
+		t.Fatal("invalid tick should be rejected")
+	}
+
+	bar, complete := ta.AddTick(103, 1, 1_260_000)
+	if !complete {
+		t.Fatal("expected the first bar to complete at the interval boundary")
+	}
+	want := OHLCV{Timestamp: 1_200_000, Open: 100, High: 102, Low: 99, Close: 101, Volume: 5}
+	if bar != want {
+		t.Fatalf("got %+v, want %+v", bar, want)
+	}
+	if cur, ok := ta.Current(); !ok || cur.Open != 103 || cur.Timestamp != 1_260_000 {
+		t.Fatalf("unexpected bar in progress %+v", cur)
+	}
+}
+
+func TestTickAggregatorVolumeBars(t *testing.T) {
+	ta, _ := NewVolumeTickAggregator(10)
+	ta.AddTick(50, 4, 1)
+	ta.AddTick(51, 4, 2)
+	bar, complete := ta.AddTick(49, 3, 3)
+	if !complete || bar.Volume != 11 || bar.High != 51 || bar.Low != 49 || bar.Close != 49 {
+		t.Fatalf("unexpected volume bar %+v (complete=%v)", bar, complete)
+	}
+	if _, ok := ta.Flush(); ok {
+		t.Fatal("nothing should be left after a completed volume bar")
+	}
+}
+
+func TestExtremes(t *testing.T) {
+	var e Extremes
+	if e.Min() != 0 || e.Max() != 0 {
+		t.Fatal("expected zero extremes before any observation")
+	}
+	for _, v := range []float64{-3, 5, 2, -7, 4} {
+		e.Observe(v)
+	}
+	if e.Min() != -7 || e.Max() != 5 {
+		t.Fatalf("got min %v max %v", e.Min(), e.Max())
+	}
+	e.Reset()
+	e.Observe(1)
+	if e.Min() != 1 || e.Max() != 1 {
+		t.Fatal("Reset should forget earlier observations")
+	}
+}
+
+func TestDerivedPriceSeries(t *testing.T) {
+	mp, wc := NewMedianPrice(), NewWeightedClose()
+	bars := [][3]float64{{12, 8, 11}, {15, 9, 10}, {20, 16, 19}}
+	wantMedian := []float64{10, 12, 18}
+	wantWeighted := []float64{10.5, 11, 18.5}
+	for _, b := range bars {
+		if err := mp.Add(b[0], b[1]); err != nil {
+			t.Fatal(err)
+		}
+		if err := wc.Add(b[0], b[1], b[2]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := range bars {
+		if mp.GetValues()[i] != wantMedian[i] || wc.GetValues()[i] != wantWeighted[i] {
+			t.Fatalf("bar %d: median %v weighted %v", i, mp.GetValues()[i], wc.GetValues()[i])
+		}
+	}
+	if plot := wc.GetPlotData(0, 60); len(plot) != 1 || plot[0].Name != "Weighted Close" || len(plot[0].Y) != 3 {
+		t.Fatalf("unexpected plot data %+v", plot)
+	}
+	if err := mp.Add(5, 6); err == nil {
+		t.Fatal("expected error for high < low")
+	}
+}
+
+func TestMovingAverage_ConfidenceBands(t *testing.T) {
+	ma, _ := NewMovingAverage(SMAMovingAverage, 8)
+	if _, _, err := ma.ConfidenceBands(1.96); err == nil {
+		t.Fatal("expected error before the window is full")
+	}
+	for _, v := range []float64{2, 4, 4, 4, 5, 5, 7, 9} {
+		_ = ma.Add(v)
+	}
+	// mean 5, sample stddev sqrt(32/7), stderr = stddev/sqrt(8) ≈ 0.755929
+	lower, upper, err := ma.ConfidenceBands(1.96)
+	if err != nil {
+		t.Fatalf("ConfidenceBands error: %v", err)
+	}
+	if math.Abs(lower-3.518379) > 1e-6 || math.Abs(upper-6.481621) > 1e-6 {
+		t.Fatalf("expected bands [3.518379, 6.481621], got [%v, %v]", lower, upper)
+	}
+
+	short, _ := NewMovingAverage(SMAMovingAverage, 1)
+	_ = short.Add(5)
+	if _, _, err := short.ConfidenceBands(1.96); err == nil {
+		t.Fatal("expected error for period < 2")
+	}
+	ema, _ := NewMovingAverage(EMAMovingAverage, 3)
+	if _, _, err := ema.ConfidenceBands(1.96); err == nil {
+		t.Fatal("expected error for a non-SMA average")
+	}
+}
+
+func TestExtractMarkers(t *testing.T) {
+	data := []PlotData{
+		{Name: "Line", X: []float64{0, 1, 2, 3}, Y: []float64{25, 35, 75, 60}, Type: "line"},
+		{Name: "Signals", X: []float64{0, 1, 2, 3}, Y: []float64{-2, 1, 2, 7}, Type: "scatter"},
+	}
+	got := ExtractMarkers(data, ZoneMarkerKinds)
+	if len(got.Series) != 1 || got.Series[0].Name != "Line" {
+		t.Fatalf("expected the signal scatter to be removed, got %+v", got.Series)
+	}
+	want := []Marker{
+		{Index: 0, Kind: MarkerOversold, Value: 25},
+		{Index: 1, Kind: MarkerBullishCross, Value: 35},
+		{Index: 2, Kind: MarkerOverbought, Value: 75},
+	}
+	if len(got.Markers) != len(want) {
+		t.Fatalf("expected %d markers (unknown codes skipped), got %+v", len(want), got.Markers)
+	}
+	for i := range want {
+		if got.Markers[i] != want[i] {
+			t.Fatalf("marker %d: expected %+v, got %+v", i, want[i], got.Markers[i])
+		}
+	}
+}
+
+func TestSupportResistance_RepeatedResistance(t *testing.T) {
+	sr, err := NewSupportResistance(100, 0.5, TolerancePercent)
+	if err != nil {
+		t.Fatalf("constructor error: %v", err)
+	}
+	// Three rallies stall near 110 and pull back to progressively higher lows.
+	peaks := []float64{110, 110.3, 109.8}
+	troughs := []float64{100, 103, 106}
+	ts := int64(0)
+	add := func(high, low float64) {
+		ts += 60
+		if err := sr.Add(high, low, ts); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	for k := range peaks {
+		span := peaks[k] - troughs[k]
+		for _, f := range []float64{0.2, 0.5, 0.8, 1, 0.8, 0.5, 0.2, 0} {
+			mid := troughs[k] + f*span
+			high, low := mid+0.1, mid-0.1
+			if f == 1 {
+				high = peaks[k]
+			}
+			add(high, low)
+		}
+	}
+	add(107, 106.8)
+	add(107.5, 107)
+
+	var resistance *Level
+	for _, lv := range sr.Levels() {
+		if lv.Kind == LevelResistance {
+			l := lv
+			resistance = &l
+		}
+	}
+	if resistance == nil {
+		t.Fatalf("expected a resistance level, got %+v", sr.Levels())
+	}
+	if resistance.Touches != 3 || math.Abs(resistance.Price-110.033333) > 1e-3 {
+		t.Fatalf("expected 3 touches near 110.03, got %+v", *resistance)
+	}
+
+	if _, err := NewSupportResistance(3, 1, ToleranceAbsolute); err == nil {
+		t.Fatal("expected error for a window below 5")
+	}
+}
+
+func TestCorrelation(t *testing.T) {
+	a := []float64{1, 2, 3, 4, 5}
+	if c, err := Correlation(a, []float64{2, 4, 6, 8, 10}); err != nil || math.Abs(c-1) > 1e-12 {
+		t.Fatalf("expected 1, got %v (%v)", c, err)
+	}
+	if c, err := Correlation(a, []float64{5, 4, 3, 2, 1}); err != nil || math.Abs(c+1) > 1e-12 {
+		t.Fatalf("expected -1, got %v (%v)", c, err)
+	}
+	if _, err := Correlation(a, []float64{3, 3, 3, 3, 3}); err == nil {
+		t.Fatal("expected error for a constant series")
+	}
+	if _, err := Correlation(a, a[:3]); err == nil {
+		t.Fatal("expected error for mismatched lengths")
+	}
+}
+
+func TestGapFiller_FillsMissingBars(t *testing.T) {
+	gf := NewGapFiller(GapFillFlat)
+	var out []OHLCV
+	for _, b := range []OHLCV{
+		{Timestamp: 0, Open: 10, High: 11, Low: 9, Close: 10.5, Volume: 100},
+		{Timestamp: 60, Open: 10.5, High: 12, Low: 10, Close: 11, Volume: 120},
+		{Timestamp: 240, Open: 11.2, High: 12, Low: 11, Close: 11.8, Volume: 90}, // 120 and 180 missing
+	} {
+		out = append(out, gf.AddWithTime(b, 60)...)
+	}
+	if len(out) != 5 {
+		t.Fatalf("expected 5 bars after filling, got %d", len(out))
+	}
+	for i, b := range out {
+		if b.Timestamp != int64(i)*60 {
+			t.Fatalf("bar %d: expected timestamp %d, got %d", i, i*60, b.Timestamp)
+		}
+	}
+	for _, b := range out[2:4] {
+		if b.Open != 11 || b.High != 11 || b.Low != 11 || b.Close != 11 || b.Volume != 0 {
+			t.Fatalf("expected a flat zero-volume bar at the previous close, got %+v", b)
+		}
+	}
+	if gf.LastGap() != 2 || gf.MissingBars() != 2 {
+		t.Fatalf("expected a 2-bar gap, got last %d total %d", gf.LastGap(), gf.MissingBars())
+	}
+
+	skip := NewGapFiller(GapSkipMarked)
+	skip.AddWithTime(OHLCV{Timestamp: 0, Close: 10}, 60)
+	if got := skip.AddWithTime(OHLCV{Timestamp: 180, Close: 11}, 60); len(got) != 1 || skip.LastGap() != 2 {
+		t.Fatalf("skip mode should only mark the gap, got %d bars and gap %d", len(got), skip.LastGap())
+	}
+}
+
+func TestEstimateLag(t *testing.T) {
+	series := make([]float64, 60)
+	for i := range series {
+		if i >= 30 {
+			series[i] = 10
+		}
+	}
+	lag := func(maType MovingAverageType) float64 {
+		ma, err := NewMovingAverage(maType, 10)
+		if err != nil {
+			t.Fatalf("NewMovingAverage(%s): %v", maType, err)
+		}
+		return EstimateLag(ma, series)
+	}
+	sma, ema, wma := lag(SMAMovingAverage), lag(EMAMovingAverage), lag(WMAMovingAverage)
+	if math.Abs(sma-4) > 1e-9 {
+		t.Fatalf("expected SMA(10) lag of 4 bars, got %v", sma)
+	}
+	if !(wma < sma) || !(ema < sma) {
+		t.Fatalf("expected WMA and EMA to lag less than SMA, got SMA %v EMA %v WMA %v", sma, ema, wma)
+	}
+
+	ma, _ := NewMovingAverage(SMAMovingAverage, 10)
+	if !math.IsNaN(EstimateLag(ma, series[25:])) {
+		t.Fatal("expected NaN when the average is not warm before the step")
+	}
+	if !math.IsNaN(EstimateLag(ma, make([]float64, 20))) {
+		t.Fatal("expected NaN for a series without a step")
+	}
+	if !math.IsNaN(EstimateSeriesLag(series, series[1:])) {
+		t.Fatal("expected NaN for misaligned series")
+	}
+	// An output equal to its input jumps the whole step on the step bar.
+	if got := EstimateSeriesLag(series, series); math.Abs(got+0.5) > 1e-9 {
+		t.Fatalf("expected a lag of -0.5 for the input itself, got %v", got)
+	}
+}
+
+func TestFitTrendlines(t *testing.T) {
+	// Two swings with higher lows (bars 3 and 9) and higher highs (6 and 12).
+	prices := []float64{10, 9, 8, 7, 8, 9, 10, 9, 8.5, 8, 9, 10, 11, 10.8, 10.9}
+	support, resistance := FitTrendlines(prices, 2, 2)
+	if !support.Valid() || support.P1 != 3 || support.P2 != 9 {
+		t.Fatalf("expected support through bars 3 and 9, got %+v", support)
+	}
+	if support.Slope <= 0 {
+		t.Fatalf("expected an ascending support line, got slope %v", support.Slope)
+	}
+	for _, p := range []int{support.P1, support.P2} {
+		if math.Abs(support.ValueAt(p)-prices[p]) > 1e-12 {
+			t.Fatalf("support misses pivot %d: %v vs %v", p, support.ValueAt(p), prices[p])
+		}
+	}
+	if resistance.P1 != 6 || resistance.P2 != 12 || resistance.ValueAt(12) != 11 {
+		t.Fatalf("expected resistance through bars 6 and 12, got %+v", resistance)
+	}
+	if s, _ := FitTrendlines(prices[:8], 2, 2); s.Valid() {
+		t.Fatal("expected no support line with a single pivot low")
+	}
+	if s, _ := FitTrendlines(prices, 0, 2); s.Valid() {
+		t.Fatal("expected zero lines for invalid bar counts")
+	}
+}
+
+func TestResampler(t *testing.T) {
+	r, err := NewResampler(MillisPerMinute, 5*MillisPerMinute)
+	if err != nil {
+		t.Fatalf("NewResampler failed: %v", err)
+	}
+	if _, err := NewResampler(MillisPerMinute, 90*MillisPerSecond); err == nil {
+		t.Fatal("expected error for a non-multiple interval")
+	}
+	// A full bucket (minutes 0–4), then a bucket cut short at minute 6 by a
+	// gap to minute 10, and one cut short at minute 11 by a gap to minute 19,
+	// the last minute of its own bucket.
+	var done []OHLCV
+	for _, m := range []int64{0, 1, 2, 3, 4, 5, 6, 10, 11, 19} {
+		ts := m * MillisPerMinute
+		p := 10 + float64(m)
+		done = append(done, r.Add(OHLCV{Timestamp: ts, Open: p, High: p + 1, Low: p - 1, Close: p, Volume: 1})...)
+		if m == 11 {
+			if cur, ok := r.Current(); !ok || cur.Timestamp != 10*MillisPerMinute {
+				t.Fatalf("expected a bar in progress at minute 10, got %+v %v", cur, ok)
+			}
+		}
+	}
+	if len(done) != 4 {
+		t.Fatalf("expected 4 completed bars, got %d: %+v", len(done), done)
+	}
+	first := done[0]
+	if first.Timestamp != 0 || first.Open != 10 || first.High != 15 || first.Low != 9 || first.Close != 14 || first.Volume != 5 {
+		t.Fatalf("unexpected first bar %+v", first)
+	}
+	if done[1].Timestamp != 5*MillisPerMinute || done[1].Close != 16 || done[1].Volume != 2 {
+		t.Fatalf("expected the gapped bar to close on the next bucket, got %+v", done[1])
+	}
+	if done[2].Timestamp != 10*MillisPerMinute || done[2].Close != 21 || done[2].Volume != 2 {
+		t.Fatalf("expected the second gapped bar to close on the next bucket, got %+v", done[2])
+	}
+	if done[3].Timestamp != 15*MillisPerMinute || done[3].Open != 29 || done[3].Volume != 1 {
+		t.Fatalf("expected the gap bar to complete its own bucket, got %+v", done[3])
+	}
+	if _, ok := r.Current(); ok {
+		t.Fatal("expected no bar in progress after the last bar of a bucket")
+	}
+	if out := r.Add(OHLCV{Timestamp: 18 * MillisPerMinute, Close: 1}); out != nil {
+		t.Fatalf("expected a bar of a completed bucket to be ignored, got %+v", out)
+	}
+}
+
+func TestTrackMFE(t *testing.T) {
+	closes := []float64{100, 99, 100, 102, 105, 103, 108, 101, 96, 97}
+	signals := []SignalEvent{
+		{Index: 2, Kind: EventBullishCrossover, Direction: 1},  // rally to 108 within 4 bars
+		{Index: 6, Kind: EventBearishCrossover, Direction: -1}, // drop to 96 within 3 bars
+		{Index: 1, Kind: EventBearishCrossover, Direction: -1}, // price only rises afterwards
+		{Index: 8, Kind: EventBullishCrossover, Direction: 1},  // horizon truncated at the end
+		{Index: 3, Kind: EventNeutral},
+		{Index: 42, Direction: 1},
+	}
+	got := TrackMFE(signals, closes, 4)
+	want := []float64{8, 12, 0, 1, 0}
+	for i, w := range want {
+		if math.Abs(got[i]-w) > 1e-12 {
+			t.Fatalf("signal %d: expected MFE %v, got %v", i, w, got[i])
+		}
+	}
+	if !math.IsNaN(got[5]) {
+		t.Fatalf("expected NaN for an out-of-range signal, got %v", got[5])
+	}
+}
+
+func TestStreamBars(t *testing.T) {
+	feed := `{"t":1,"o":10,"h":11,"l":9,"c":10.5,"v":100}
+{"t":2,"o":10.5,"h":12,"l":10,"c":11.5,"v":150}
+
+{"t":3,"o":11.5,"h":12.5,"l":11,"c":12,"v":120}
+`
+	var count int
+	var volume float64
+	err := StreamBars(strings.NewReader(feed), func(b OHLCV) error {
+		count++
+		volume += b.Volume
+		if b.Timestamp != int64(count) {
+			t.Fatalf("bar %d: unexpected timestamp %d", count, b.Timestamp)
+		}
+		return nil
+	})
+	if err != nil || count != 3 || volume != 370 {
+		t.Fatalf("expected 3 bars with volume 370, got %d / %v (%v)", count, volume, err)
+	}
+
+	count = 0
+	err = StreamBars(strings.NewReader(feed+"{\"t\":4,\"c\":oops}\n"), func(OHLCV) error {
+		count++
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "line 5") || count != 3 {
+		t.Fatalf("expected a line 5 error after 3 bars, got %v after %d", err, count)
+	}
+
+	for _, bad := range []string{
+		`{}`,
+		`null`,
+		`{"foo":1}`,
+		`{"t":4,"o":1,"h":1,"l":1,"c":1,"v":1,"x":2}`,
+		`{"t":4,"o":1,"h":1,"l":1,"v":1}`,
+		`{"t":4,"o":1,"h":1,"l":1,"c":1} {"t":5}`,
+		`[]`,
+	} {
+		count = 0
+		err = StreamBars(strings.NewReader(feed+bad+"\n"), func(OHLCV) error {
+			count++
+			return nil
+		})
+		if err == nil || !strings.Contains(err.Error(), "line 5") || count != 3 {
+			t.Fatalf("%s: expected a line 5 error after 3 bars, got %v after %d", bad, err, count)
+		}
+	}
+	if err := StreamBars(strings.NewReader(`{"o":1,"h":2,"l":0.5,"c":1.5}`), func(b OHLCV) error {
+		if b.Timestamp != 0 || b.Volume != 0 || b.Close != 1.5 {
+			t.Fatalf("unexpected bar %+v", b)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("expected t and v to be optional, got %v", err)
+	}
+
+	stop := errors.New("stop")
+	err = StreamBars(strings.NewReader(feed), func(OHLCV) error { return stop })
+	if !errors.Is(err, stop) || !strings.Contains(err.Error(), "line 1") {
+		t.Fatalf("expected the callback error on line 1, got %v", err)
+	}
+}
+
+func TestClassifyZoneTransition(t *testing.T) {
+	cases := []struct {
+		prev, cur float64
+		want      ZoneEvent
+	}{
+		{60, 75, EnteredOverbought},
+		{75, 70, ExitedOverbought},
+		{40, 25, EnteredOversold},
+		{25, 30, ExitedOversold},
+		{75, 80, ZoneNone},
+		{40, 60, ZoneNone},
+		{80, 20, EnteredOversold}, // the new zone wins on a jump
+	}
+	for _, c := range cases {
+		if got := ClassifyZoneTransition(c.prev, c.cur, 70, 30); got != c.want {
+			t.Fatalf("%v -> %v: got %s, want %s", c.prev, c.cur, got, c.want)
+		}
+	}
+}
+
+func TestPivotDivergence(t *testing.T) {
+	prices := []float64{10, 12, 15, 12, 10, 13, 16, 13, 11}
+	lows, highs := Pivots(prices, 2, 2)
+	if len(lows) != 1 || lows[0] != 4 || len(highs) != 2 || highs[0] != 2 || highs[1] != 6 {
+		t.Fatalf("unexpected pivots lows=%v highs=%v", lows, highs)
+	}
+	// Higher price high, lower oscillator high.
+	osc := []float64{50, 60, 80, 55, 40, 60, 70, 50, 45}
+	if got := PivotDivergence(prices, osc, 2, 2); got != DivergenceBearish {
+		t.Fatalf("expected bearish divergence, got %q", got)
+	}
+	// Pivot highs at bars 2 and 6: price +1 of its 6-point range (+1/6),
+	// oscillator -10 of its 40-point range (-1/4), a gap of 5/12 spread over
+	// 4 bars.
+	if dir, strength := PivotDivergenceStrength(prices, osc, 2, 2); dir != DivergenceBearish || math.Abs(strength-5.0/48) > 1e-9 {
+		t.Fatalf("expected bearish strength 5/48, got %q %v", dir, strength)
+	}
+	var w DivergenceWindow
+	for i := range prices {
+		w.Observe(prices[i], osc[i])
+	}
+	if _, _, ok := w.Strength(); ok {
+		t.Fatal("nine bars should not be enough for the window")
+	}
+	w.Observe(12, 48)
+	if dir, strength, ok := w.Strength(); !ok || dir != DivergenceBearish || math.Abs(strength-5.0/48) > 1e-9 {
+		t.Fatalf("window should report the same divergence, got %q %v %v", dir, strength, ok)
+	}
+	// The oscillator confirms the new high: no divergence.
+	osc[6] = 85
+	if got := PivotDivergence(prices, osc, 2, 2); got != "" {
+		t.Fatalf("expected no divergence, got %q", got)
+	}
+	// A pivot inside the oscillator's warm-up is ignored.
+	osc[2], osc[6] = math.NaN(), 70
+	if got := PivotDivergence(prices, osc, 2, 2); got != "" {
+		t.Fatalf("expected warm-up pivots to be skipped, got %q", got)
+	}
+}
+
+func TestRollingWindowCustomRangeIndicator(t *testing.T) {
+	if _, err := NewRollingWindow(0); err == nil {
+		t.Fatal("expected error for period 0")
+	}
+	win, err := NewRollingWindow(3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rangeFn := func(w []float64) float64 {
+		hi, lo := w[0], w[0]
+		for _, v := range w[1:] {
+			hi, lo = max(hi, v), min(lo, v)
+		}
+		return hi - lo
+	}
+
+	var got []float64
+	for i, v := range []float64{5, 7, 4, 6, 10, 9} {
+		w, full := win.Add(v)
+		if full != (i >= 2) || len(w) != min(i+1, 3) {
+			t.Fatalf("bar %d: window %v full=%v", i, w, full)
+		}
+		r, ok := win.Apply(rangeFn)
+		if ok != full {
+			t.Fatalf("bar %d: Apply ok=%v, want %v", i, ok, full)
+		}
+		if ok {
+			got = append(got, r)
+		}
+	}
+
+	want := []float64{3, 3, 6, 4} // {5,7,4} {7,4,6} {4,6,10} {6,10,9}
+	values := win.GetValues()
+	for i := range want {
+		if got[i] != want[i] || values[i] != want[i] {
+			t.Fatalf("range[%d] = %v (recorded %v), want %v", i, got[i], values[i], want[i])
+		}
+	}
+	if plots := win.GetPlotData("Range", 0, 60); len(plots) != 1 || len(plots[0].Y) != len(want) || plots[0].Name != "Range" {
+		t.Fatalf("unexpected plot data: %+v", plots)
+	}
+
+	win.Reset()
+	if _, err := win.Calculate(); err == nil {
+		t.Fatal("expected error after Reset")
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	edges, counts := Histogram([]float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 10, math.NaN()}, 5)
+	wantEdges := []float64{0, 2, 4, 6, 8, 10}
+	wantCounts := []int{2, 2, 2, 2, 2} // 10 (the maximum) falls into the last bin
+	if len(edges) != len(wantEdges) || len(counts) != len(wantCounts) {
+		t.Fatalf("got %d edges / %d counts", len(edges), len(counts))
+	}
+	for i := range wantEdges {
+		if math.Abs(edges[i]-wantEdges[i]) > 1e-12 {
+			t.Fatalf("edge %d = %v, want %v", i, edges[i], wantEdges[i])
+		}
+	}
+	for i := range wantCounts {
+		if counts[i] != wantCounts[i] {
+			t.Fatalf("counts = %v, want %v", counts, wantCounts)
+		}
+	}
+
+	edges, counts = Histogram([]float64{3, 3, 3}, 2)
+	if edges[0] != 2.5 || edges[2] != 3.5 || counts[0]+counts[1] != 3 {
+		t.Fatalf("constant input: edges %v counts %v", edges, counts)
+	}
+	if e, c := Histogram(nil, 3); e != nil || c != nil {
+		t.Fatal("expected nil for no values")
+	}
+	if e, c := Histogram([]float64{1, 2}, 0); e != nil || c != nil {
+		t.Fatal("expected nil for zero bins")
+	}
+}
+
+func TestMovingAverageWithInvertedPrice(t *testing.T) {
+	ma, err := NewMovingAverage(SMAMovingAverage, 2, WithInvertedPrice(true))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = ma.Add(2)
+	_ = ma.Add(4)
+	if v, err := ma.Calculate(); err != nil || v != 0.375 {
+		t.Fatalf("expected the mean of 1/2 and 1/4 (0.375), got %v err=%v", v, err)
+	}
+	if err := ma.Add(0); err == nil {
+		t.Fatal("expected an error for a zero price while inverted")
+	}
+	if _, err := InvertPrice(math.Inf(1)); err == nil {
+		t.Fatal("expected an error inverting +Inf")
+	}
+}
+
+func TestLaggedCorrelation(t *testing.T) {
+	a := make([]float64, 60)
+	for i := range a {
+		a[i] = math.Sin(float64(i)*0.7) + 0.3*math.Cos(float64(i)*1.9)
+	}
+	// b repeats a three bars later, so a leads b by 3.
+	b := make([]float64, len(a))
+	for i := range b {
+		if i >= 3 {
+			b[i] = a[i-3]
+		}
+	}
+	lag, corr := LaggedCorrelation(a, b, 6)
+	if lag != 3 || math.Abs(corr-1) > 1e-9 {
+		t.Fatalf("expected lag 3 with correlation 1, got lag %d corr %v", lag, corr)
+	}
+	// Reversed, b leads nothing: the best non-negative lag is no longer 3.
+	if lag, _ := LaggedCorrelation(b, a, 6); lag == 3 {
+		t.Fatal("reversed arguments should not recover the lead")
+	}
+	if _, corr := LaggedCorrelation([]float64{1, 1, 1}, []float64{1, 2, 3}, 1); !math.IsNaN(corr) {
+		t.Fatalf("expected NaN for a constant series, got %v", corr)
+	}
+}
+
+func TestMovingAverage_DEMA(t *testing.T) {
+	ma, err := NewMovingAverage(DEMAMovingAverage, 2)
+	if err != nil {
+		t.Fatalf("NewMovingAverage(DEMA) failed: %v", err)
+	}
+	// EMA(2): 1.5, 2.5, 3.5, 4.5, 49/6; its EMA: 2, 3, 4, 61/9. On the ramp
+	// the DEMA has no lag; the jump to 10 lands at 2·49/6 − 61/9 = 86/9.
+	want := []float64{math.NaN(), math.NaN(), 3, 4, 5, 86.0 / 9}
+	for i, v := range []float64{1, 2, 3, 4, 5, 10} {
+		if err := ma.Add(v); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+		got, err := ma.Calculate()
+		if math.IsNaN(want[i]) {
+			if err == nil {
+				t.Fatalf("sample %d: expected insufficient data, got %v", i, got)
+			}
+			continue
+		}
+		if err != nil || math.Abs(got-want[i]) > 1e-9 {
+			t.Fatalf("sample %d: got %v (%v), want %v", i, got, err, want[i])
+		}
+	}
+
+	ma.Reset()
+	if _, err := ma.Calculate(); err == nil {
+		t.Fatal("expected Reset to clear the DEMA")
+	}
+	if err := ma.SetPeriod(1); err != nil {
+		t.Fatalf("SetPeriod failed: %v", err)
+	}
+	_ = ma.Add(7)
+	if got, err := ma.Calculate(); err != nil || got != 7 {
+		t.Fatalf("DEMA(1) should follow the input, got %v (%v)", got, err)
+	}
+}
@@ -0,0 +1,108 @@
+package core
+
+import "errors"
+
+// derivedPriceMaxValues bounds the retained history of the derived price
+// series.
+const derivedPriceMaxValues = 256
+
+// MedianPrice records each bar's median price, (high+low)/2, as a plottable
+// overlay series.
+type MedianPrice struct {
+	values []float64
+}
+
+// NewMedianPrice creates an empty median price series.
+func NewMedianPrice() *MedianPrice {
+	return &MedianPrice{values: make([]float64, 0, 16)}
+}
+
+// Add appends the median price of a bar.
+func (m *MedianPrice) Add(high, low float64) error {
+	if high < low {
+		return errors.New("invalid price: high < low")
+	}
+	if !IsValidPrice(high) || !IsValidPrice(low) {
+		return errors.New("invalid price: all prices must be positive")
+	}
+	m.values = KeepLast(append(m.values, (high+low)/2), derivedPriceMaxValues)
+	return nil
+}
+
+// Calculate returns the latest median price.
+func (m *MedianPrice) Calculate() (float64, error) {
+	if len(m.values) == 0 {
+		return 0, errors.New("no median price data")
+	}
+	return m.values[len(m.values)-1], nil
+}
+
+// Reset clears the series.
+func (m *MedianPrice) Reset() { m.values = m.values[:0] }
+
+// GetValues returns the median price series (defensive copy).
+func (m *MedianPrice) GetValues() []float64 { return CopySlice(m.values) }
+
+// GetPlotData returns the series as a single "Median Price" line.
+func (m *MedianPrice) GetPlotData(startTime, interval int64) []PlotData {
+	return derivedPricePlot("Median Price", m.values, startTime, interval)
+}
+
+// WeightedClose records each bar's weighted close, (high+low+2·close)/4,
+// which leans the bar's representative price towards its close.
+type WeightedClose struct {
+	values []float64
+}
+
+// NewWeightedClose creates an empty weighted close series.
+func NewWeightedClose() *WeightedClose {
+	return &WeightedClose{values: make([]float64, 0, 16)}
+}
+
+// Add appends the weighted close of a bar.
+func (w *WeightedClose) Add(high, low, close float64) error {
+	if high < low {
+		return errors.New("invalid price: high < low")
+	}
+	if !IsValidPrice(high) || !IsValidPrice(low) || !IsValidPrice(close) {
+		return errors.New("invalid price: all prices must be positive")
+	}
+	w.values = KeepLast(append(w.values, (high+low+2*close)/4), derivedPriceMaxValues)
+	return nil
+}
+
+// Calculate returns the latest weighted close.
+func (w *WeightedClose) Calculate() (float64, error) {
+	if len(w.values) == 0 {
+		return 0, errors.New("no weighted close data")
+	}
+	return w.values[len(w.values)-1], nil
+}
+
+// Reset clears the series.
+func (w *WeightedClose) Reset() { w.values = w.values[:0] }
+
+// GetValues returns the weighted close series (defensive copy).
+func (w *WeightedClose) GetValues() []float64 { return CopySlice(w.values) }
+
+// GetPlotData returns the series as a single "Weighted Close" line.
+func (w *WeightedClose) GetPlotData(startTime, interval int64) []PlotData {
+	return derivedPricePlot("Weighted Close", w.values, startTime, interval)
+}
+
+func derivedPricePlot(name string, values []float64, startTime, interval int64) []PlotData {
+	if len(values) == 0 {
+		return nil
+	}
+	x := make([]float64, len(values))
+	for i := range x {
+		x[i] = float64(i)
+	}
+	return []PlotData{{
+		Name:      name,
+		X:         x,
+		Y:         CopySlice(values),
+		Type:      "line",
+		Timestamp: GenerateTimestamps(startTime, len(values), interval),
+	}}
+}
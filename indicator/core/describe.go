@@ -0,0 +1,11 @@
+package core
+
+// IndicatorInfo is the self-description returned by an indicator's Describe
+// method. Params holds the live parameter values keyed by the names used in
+// the indicator's constructor, and SamplesNeeded is the number of bars that
+// must be added before the indicator produces its first complete value.
+type IndicatorInfo struct {
+	Name          string         `json:"name"`
+	Params        map[string]any `json:"params"`
+	SamplesNeeded int            `json:"samplesNeeded"`
+}
@@ -0,0 +1,98 @@
+package core
+
+import "math"
+
+// Signal event kinds recorded by the suite's event history.
+const (
+	EventBullishCrossover = "bullish_crossover"
+	EventBearishCrossover = "bearish_crossover"
+	EventOverbought       = "overbought" // entered the overbought zone
+	EventOversold         = "oversold"   // entered the oversold zone
+	EventNeutral          = "neutral"    // left an overbought/oversold zone
+)
+
+// SignalEvent describes a crossover or zone transition produced by an
+// indicator on a given bar.
+type SignalEvent struct {
+	Index     int     `json:"index"`     // absolute bar index (0-based) of the event
+	Source    string  `json:"source"`    // indicator name, e.g. "MACD"
+	Kind      string  `json:"kind"`      // one of the Event* constants
+	Direction int     `json:"direction"` // +1 bullish, -1 bearish, 0 neutral
+	Value     float64 `json:"value"`     // indicator reading on the event bar
+	Price     float64 `json:"price"`     // close of the event bar
+}
+
+// ZoneEvent reports how an oscillator moved relative to its overbought and
+// oversold zones on the latest bar.
+type ZoneEvent int
+
+const (
+	ZoneNone          ZoneEvent = iota // stayed inside or outside both zones
+	EnteredOverbought                  // rose above the overbought level
+	ExitedOverbought                   // fell back to or below the overbought level
+	EnteredOversold                    // fell below the oversold level
+	ExitedOversold                     // rose back to or above the oversold level
+)
+
+// String returns the event name, e.g. "entered_overbought".
+func (z ZoneEvent) String() string {
+	switch z {
+	case EnteredOverbought:
+		return "entered_overbought"
+	case ExitedOverbought:
+		return "exited_overbought"
+	case EnteredOversold:
+		return "entered_oversold"
+	case ExitedOversold:
+		return "exited_oversold"
+	default:
+		return "none"
+	}
+}
+
+// ClassifyZoneTransition compares two consecutive readings against the zone
+// levels. A value is in the overbought zone when strictly above overbought
+// and in the oversold zone when strictly below oversold, as in
+// GetOverboughtOversold. A jump straight from one zone into the other
+// reports the entry into the new zone.
+func ClassifyZoneTransition(prev, cur, overbought, oversold float64) ZoneEvent {
+	prevOB, curOB := prev > overbought, cur > overbought
+	prevOS, curOS := prev < oversold, cur < oversold
+	switch {
+	case curOB && !prevOB:
+		return EnteredOverbought
+	case curOS && !prevOS:
+		return EnteredOversold
+	case prevOB && !curOB:
+		return ExitedOverbought
+	case prevOS && !curOS:
+		return ExitedOversold
+	}
+	return ZoneNone
+}
+
+// TrackMFE returns, for each signal, its maximum favourable excursion: how far
+// the close moved in the signal's Direction within the horizon bars after the
+// event, measured in price units from the close at the event's Index. A
+// bullish signal scores max(close) − entry, a bearish one entry − min(close);
+// a move that only ever went against the signal scores 0. Signals without a
+// direction score 0, and horizons running past the end of closes are
+// truncated. Index must address closes; signals outside it yield NaN.
+func TrackMFE(signals []SignalEvent, closes []float64, horizon int) []float64 {
+	mfe := make([]float64, len(signals))
+	for i, s := range signals {
+		if s.Index < 0 || s.Index >= len(closes) {
+			mfe[i] = math.NaN()
+			continue
+		}
+		if s.Direction == 0 {
+			continue
+		}
+		entry := closes[s.Index]
+		end := min(s.Index+horizon, len(closes)-1)
+		for j := s.Index + 1; j <= end; j++ {
+			mfe[i] = math.Max(mfe[i], float64(s.Direction)*(closes[j]-entry))
+		}
+	}
+	return mfe
+}
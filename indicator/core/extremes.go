@@ -0,0 +1,27 @@
+package core
+
+// Extremes tracks the smallest and largest value observed in a stream without
+// retaining the stream itself. The zero value is ready to use.
+type Extremes struct {
+	min, max float64
+	seen     bool
+}
+
+// Observe folds v into the running minimum and maximum.
+func (e *Extremes) Observe(v float64) {
+	if !e.seen {
+		e.min, e.max, e.seen = v, v, true
+		return
+	}
+	e.min = min(e.min, v)
+	e.max = max(e.max, v)
+}
+
+// Min returns the smallest observed value, or 0 before the first one.
+func (e *Extremes) Min() float64 { return e.min }
+
+// Max returns the largest observed value, or 0 before the first one.
+func (e *Extremes) Max() float64 { return e.max }
+
+// Reset forgets all observations.
+func (e *Extremes) Reset() { *e = Extremes{} }
@@ -0,0 +1,89 @@
+package core
+
+// GapFillMode selects what a GapFiller does with missing bars.
+type GapFillMode int
+
+const (
+	// GapFillFlat emits a synthetic bar for every missing interval: open,
+	// high, low and close all equal the previous close and volume is zero.
+	GapFillFlat GapFillMode = iota
+	// GapSkipMarked emits no synthetic bars; the gap is only recorded and can
+	// be read back with LastGap and MissingBars.
+	GapSkipMarked
+)
+
+// gapFillMaxBars caps how many synthetic bars a single gap produces. Longer
+// gaps, such as a weekend on intraday bars, are recorded but not filled.
+const gapFillMaxBars = 1000
+
+// GapFiller keeps a timestamped bar feed continuous before it reaches the
+// indicators, so per-bar plots stay aligned with wall-clock time when the
+// source skips intervals (halts, outages).
+type GapFiller struct {
+	mode GapFillMode
+
+	last    OHLCV
+	hasLast bool
+	lastGap int
+	missing int
+}
+
+// NewGapFiller creates a filler using the given mode.
+func NewGapFiller(mode GapFillMode) *GapFiller {
+	return &GapFiller{mode: mode}
+}
+
+// AddWithTime returns the bars to feed downstream for bar: any synthetic
+// bars filling the intervals missing since the previous bar, oldest first,
+// followed by bar itself. A gap exists when bar.Timestamp is more than one
+// expectedInterval after the previous bar; timestamps that are not a whole
+// number of intervals apart are rounded down. With a non-positive interval,
+// a first bar, or a timestamp that does not advance, bar is returned as is.
+func (gf *GapFiller) AddWithTime(bar OHLCV, expectedInterval int64) []OHLCV {
+	gf.lastGap = 0
+	prev, hadPrev := gf.last, gf.hasLast
+	if !hadPrev || bar.Timestamp > prev.Timestamp {
+		gf.last, gf.hasLast = bar, true
+	}
+	if !hadPrev || expectedInterval <= 0 || bar.Timestamp <= prev.Timestamp {
+		return []OHLCV{bar}
+	}
+
+	gap := int((bar.Timestamp-prev.Timestamp)/expectedInterval) - 1
+	if gap <= 0 {
+		return []OHLCV{bar}
+	}
+	gf.lastGap = gap
+	gf.missing += gap
+	if gf.mode != GapFillFlat || gap > gapFillMaxBars {
+		return []OHLCV{bar}
+	}
+
+	out := make([]OHLCV, 0, gap+1)
+	for i := 1; i <= gap; i++ {
+		c := prev.Close
+		out = append(out, OHLCV{
+			Timestamp: prev.Timestamp + int64(i)*expectedInterval,
+			Open:      c,
+			High:      c,
+			Low:       c,
+			Close:     c,
+		})
+	}
+	return append(out, bar)
+}
+
+// LastGap returns how many intervals were missing before the latest bar.
+func (gf *GapFiller) LastGap() int { return gf.lastGap }
+
+// MissingBars returns the total number of missing intervals detected since
+// construction or the last Reset, whether filled or not.
+func (gf *GapFiller) MissingBars() int { return gf.missing }
+
+// Reset forgets the previous bar and the gap counters; the mode is kept.
+func (gf *GapFiller) Reset() {
+	gf.last = OHLCV{}
+	gf.hasLast = false
+	gf.lastGap = 0
+	gf.missing = 0
+}
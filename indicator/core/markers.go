@@ -0,0 +1,85 @@
+package core
+
+// Marker kinds emitted by ExtractMarkers for the standard signal encodings.
+const (
+	MarkerBullishCross    = "bullish_cross"
+	MarkerBearishCross    = "bearish_cross"
+	MarkerOverbought      = "overbought"
+	MarkerOversold        = "oversold"
+	MarkerStrongUptrend   = "strong_uptrend"
+	MarkerStrongDowntrend = "strong_downtrend"
+)
+
+// ZoneMarkerKinds decodes the "Signals" scatter of bounded oscillators such as
+// RSI, MFI and ADMO: ±1 for crossovers and ±2 for the overbought/oversold
+// zones.
+var ZoneMarkerKinds = map[float64]string{
+	1:  MarkerBullishCross,
+	-1: MarkerBearishCross,
+	2:  MarkerOverbought,
+	-2: MarkerOversold,
+}
+
+// TrendMarkerKinds decodes a "Signals" scatter whose ±2 codes mark
+// strong-trend zones rather than overbought/oversold, as VWAO emits.
+var TrendMarkerKinds = map[float64]string{
+	1:  MarkerBullishCross,
+	-1: MarkerBearishCross,
+	2:  MarkerStrongUptrend,
+	-2: MarkerStrongDowntrend,
+}
+
+// Marker is a typed signal annotation at a bar index. Value is the
+// indicator's reading at that bar, so a frontend can place the marker on the
+// line without looking it up.
+type Marker struct {
+	Index int     `json:"index"`
+	Kind  string  `json:"kind"`
+	Value float64 `json:"value"`
+}
+
+// PlotDataWithMarkers carries an indicator's line series together with its
+// signals as explicit markers instead of a numeric "Signals" scatter.
+type PlotDataWithMarkers struct {
+	Series  []PlotData `json:"series"`
+	Markers []Marker   `json:"markers"`
+}
+
+// ExtractMarkers converts the "Signals" scatter of a GetPlotData export into
+// markers using kinds to name each numeric code; codes missing from kinds
+// (including 0) produce no marker. The remaining series are returned
+// unchanged, and each marker's Value is taken from the first of them.
+func ExtractMarkers(data []PlotData, kinds map[float64]string) PlotDataWithMarkers {
+	out := PlotDataWithMarkers{Series: make([]PlotData, 0, len(data)), Markers: []Marker{}}
+	var signals *PlotData
+	for i := range data {
+		if data[i].Name == "Signals" && data[i].Type == "scatter" {
+			signals = &data[i]
+			continue
+		}
+		out.Series = append(out.Series, data[i])
+	}
+	if signals == nil {
+		return out
+	}
+	var line []float64
+	if len(out.Series) > 0 {
+		line = out.Series[0].Y
+	}
+	for i, code := range signals.Y {
+		kind, ok := kinds[code]
+		if !ok {
+			continue
+		}
+		idx := i
+		if i < len(signals.X) {
+			idx = int(signals.X[i])
+		}
+		m := Marker{Index: idx, Kind: kind}
+		if i < len(line) {
+			m.Value = line[i]
+		}
+		out.Markers = append(out.Markers, m)
+	}
+	return out
+}
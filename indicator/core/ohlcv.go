@@ -0,0 +1,102 @@
+package core
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Timestamps throughout the library are Unix milliseconds: OHLCV bars, plot
+// timestamps and every time-aware helper (SessionRange, TickAggregator,
+// Resampler) use this unit.
+const (
+	MillisPerSecond int64 = 1000
+	MillisPerMinute       = 60 * MillisPerSecond
+	MillisPerDay          = 24 * 60 * MillisPerMinute
+)
+
+// OHLCV is a single price bar. Timestamp is in Unix milliseconds; the
+// indicators themselves do not interpret it.
+type OHLCV struct {
+	Timestamp int64   `json:"timestamp,omitempty"`
+	Open      float64 `json:"open"`
+	High      float64 `json:"high"`
+	Low       float64 `json:"low"`
+	Close     float64 `json:"close"`
+	Volume    float64 `json:"volume"`
+}
+
+// streamBar is the compact wire form read by StreamBars. The prices are
+// pointers so a missing field can be told apart from a zero.
+type streamBar struct {
+	T int64    `json:"t"`
+	O *float64 `json:"o"`
+	H *float64 `json:"h"`
+	L *float64 `json:"l"`
+	C *float64 `json:"c"`
+	V float64  `json:"v"`
+}
+
+// decodeStreamBar strictly decodes one StreamBars record: a single JSON
+// object with only the known keys and all four prices present.
+func decodeStreamBar(text string) (OHLCV, error) {
+	dec := json.NewDecoder(strings.NewReader(text))
+	dec.DisallowUnknownFields()
+	var b *streamBar
+	if err := dec.Decode(&b); err != nil {
+		return OHLCV{}, err
+	}
+	if b == nil {
+		return OHLCV{}, errors.New("null bar")
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return OHLCV{}, errors.New("trailing data after bar")
+	}
+	for _, f := range []struct {
+		name string
+		v    *float64
+	}{{"o", b.O}, {"h", b.H}, {"l", b.L}, {"c", b.C}} {
+		if f.v == nil {
+			return OHLCV{}, fmt.Errorf("missing field %q", f.name)
+		}
+	}
+	return OHLCV{Timestamp: b.T, Open: *b.O, High: *b.H, Low: *b.L, Close: *b.C, Volume: b.V}, nil
+}
+
+// maxStreamLine caps the length of a single JSON-lines record.
+const maxStreamLine = 1 << 20
+
+// StreamBars reads newline-delimited JSON bars of the form
+// {"t":…,"o":…,"h":…,"l":…,"c":…,"v":…} from r and calls fn for each one as
+// it is decoded, so a file or socket can feed an indicator without being
+// loaded into memory. Blank lines are skipped. "o", "h", "l" and "c" are
+// required, "t" and "v" default to zero, and unknown keys are rejected, so a
+// line such as {} or null is an error rather than a zero bar. A malformed
+// line, or an error returned by fn, stops the stream with an error naming the
+// 1-based line number; fn errors are wrapped so errors.Is still matches them.
+func StreamBars(r io.Reader, fn func(OHLCV) error) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 4096), maxStreamLine)
+	line := 0
+	for sc.Scan() {
+		line++
+		text := strings.TrimSpace(sc.Text())
+		if text == "" {
+			continue
+		}
+		bar, err := decodeStreamBar(text)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		if err := fn(bar); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return fmt.Errorf("line %d: %w", line+1, err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// PlotFormat selects the row encoding used by PlotWriter.
+type PlotFormat int
+
+const (
+	// PlotFormatCSV writes the same rows as FormatPlotDataCSV, header first.
+	PlotFormatCSV PlotFormat = iota
+	// PlotFormatNDJSON writes one JSON object per point, newline-terminated.
+	PlotFormatNDJSON
+)
+
+const plotCSVHeader = "Name,X,Y,Type,Signal,Timestamp\n"
+
+// PlotWriter streams plot points to an io.Writer as they are produced, so
+// long runs can be exported without holding every series in memory. Call
+// Write with the points for each new bar (a PlotData holding a single point
+// is fine); rows are encoded immediately. Wrap w in a bufio.Writer for
+// throughput and flush it when done.
+type PlotWriter struct {
+	w             io.Writer
+	format        PlotFormat
+	headerWritten bool
+}
+
+// plotPoint is the NDJSON row shape.
+type plotPoint struct {
+	Name      string  `json:"name"`
+	X         float64 `json:"x"`
+	Y         float64 `json:"y"`
+	Type      string  `json:"type,omitempty"`
+	Signal    string  `json:"signal,omitempty"`
+	Timestamp *int64  `json:"timestamp,omitempty"`
+}
+
+// NewPlotWriter creates a streaming writer in the given format.
+func NewPlotWriter(w io.Writer, format PlotFormat) (*PlotWriter, error) {
+	if w == nil {
+		return nil, fmt.Errorf("writer must not be nil")
+	}
+	if format != PlotFormatCSV && format != PlotFormatNDJSON {
+		return nil, fmt.Errorf("unsupported plot format %d", format)
+	}
+	return &PlotWriter{w: w, format: format}, nil
+}
+
+// Write encodes every point of d. A series whose X and Y lengths differ is
+// rejected before any of its rows are written.
+func (pw *PlotWriter) Write(d PlotData) error {
+	if len(d.X) != len(d.Y) {
+		return fmt.Errorf("mismatched X and Y lengths for %s: %d vs %d", d.Name, len(d.X), len(d.Y))
+	}
+	if pw.format == PlotFormatCSV && !pw.headerWritten {
+		if _, err := io.WriteString(pw.w, plotCSVHeader); err != nil {
+			return err
+		}
+		pw.headerWritten = true
+	}
+	for i := range d.X {
+		var err error
+		if pw.format == PlotFormatCSV {
+			err = pw.writeCSVRow(d, i)
+		} else {
+			err = pw.writeNDJSONRow(d, i)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (pw *PlotWriter) writeCSVRow(d PlotData, i int) error {
+	ts := ""
+	if i < len(d.Timestamp) {
+		ts = strconv.FormatInt(d.Timestamp[i], 10)
+	}
+	_, err := fmt.Fprintf(pw.w, "%s,%f,%f,%s,%s,%s\n", d.Name, d.X[i], d.Y[i], d.Type, d.Signal, ts)
+	return err
+}
+
+func (pw *PlotWriter) writeNDJSONRow(d PlotData, i int) error {
+	p := plotPoint{Name: d.Name, X: d.X[i], Y: d.Y[i], Type: d.Type, Signal: d.Signal}
+	if i < len(d.Timestamp) {
+		ts := d.Timestamp[i]
+		p.Timestamp = &ts
+	}
+	b, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("failed to marshal plot point: %w", err)
+	}
+	b = append(b, '\n')
+	_, err = pw.w.Write(b)
+	return err
+}
+
+// StreamPlotDataCSV writes data to w in exactly the format produced by
+// FormatPlotDataCSV, without building the whole document in memory.
+func StreamPlotDataCSV(w io.Writer, data []PlotData) error {
+	if len(data) == 0 {
+		return nil
+	}
+	pw, err := NewPlotWriter(w, PlotFormatCSV)
+	if err != nil {
+		return err
+	}
+	for _, d := range data {
+		if err := pw.Write(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
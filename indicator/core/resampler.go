@@ -0,0 +1,91 @@
+package core
+
+import (
+	"errors"
+	"math"
+)
+
+// Resampler folds completed bars of one timeframe into bars of a longer one,
+// e.g. 1-minute bars into 5-minute bars. Output bars are aligned to multiples
+// of the interval since the Unix epoch and stamped with the start of their
+// interval, like TickAggregator's time bars.
+type Resampler struct {
+	base     int64 // milliseconds per input bar
+	interval int64 // milliseconds per output bar
+
+	bar  OHLCV
+	open bool // an output bar is in progress
+	seen bool // bar holds the latest output bar, open or completed
+}
+
+// NewResampler creates a resampler turning bars of baseInterval milliseconds
+// into bars of interval milliseconds (e.g. MillisPerMinute and
+// 5*MillisPerMinute); interval must be a positive multiple of baseInterval.
+// Bar timestamps are Unix milliseconds. Equal intervals pass every bar
+// straight through.
+func NewResampler(baseInterval, interval int64) (*Resampler, error) {
+	if baseInterval < 1 {
+		return nil, errors.New("base interval must be at least 1 millisecond")
+	}
+	if interval < baseInterval || interval%baseInterval != 0 {
+		return nil, errors.New("interval must be a multiple of the base interval")
+	}
+	return &Resampler{base: baseInterval, interval: interval}, nil
+}
+
+// Add folds a base bar, stamped with the start of its interval, into the
+// current output bar and returns the output bars it completes, oldest first.
+// An output bar completes as soon as the last base bar of its interval
+// arrives, so a 5-minute bar completes with its fifth 1-minute bar rather than
+// with the next one. When a gap skips the end of an interval, the bar from the
+// later interval closes the unfinished one and opens the next; if it is also
+// the last base bar of its own interval, both bars are returned. Bars
+// belonging to an earlier or already completed output bar are ignored.
+func (r *Resampler) Add(bar OHLCV) []OHLCV {
+	start := r.bucket(bar.Timestamp)
+	if r.seen && (start < r.bar.Timestamp || (!r.open && start == r.bar.Timestamp)) {
+		return nil
+	}
+	var out []OHLCV
+	if r.open && start != r.bar.Timestamp {
+		out = append(out, r.bar)
+		r.open = false
+	}
+
+	if !r.open {
+		r.bar = OHLCV{Timestamp: start, Open: bar.Open, High: bar.High, Low: bar.Low, Close: bar.Close, Volume: bar.Volume}
+		r.open, r.seen = true, true
+	} else {
+		r.bar.High = math.Max(r.bar.High, bar.High)
+		r.bar.Low = math.Min(r.bar.Low, bar.Low)
+		r.bar.Close = bar.Close
+		r.bar.Volume += bar.Volume
+	}
+
+	if bar.Timestamp+r.base >= start+r.interval {
+		out = append(out, r.bar)
+		r.open = false
+	}
+	return out
+}
+
+// Current returns the output bar in progress, if any.
+func (r *Resampler) Current() (OHLCV, bool) { return r.bar, r.open }
+
+// Interval returns the output bar length in milliseconds.
+func (r *Resampler) Interval() int64 { return r.interval }
+
+// Reset discards the output bar in progress.
+func (r *Resampler) Reset() {
+	r.bar = OHLCV{}
+	r.open, r.seen = false, false
+}
+
+// bucket returns the start of the output interval containing ts.
+func (r *Resampler) bucket(ts int64) int64 {
+	b := ts - ts%r.interval
+	if ts < 0 && ts%r.interval != 0 {
+		b -= r.interval
+	}
+	return b
+}
@@ -0,0 +1,121 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// rollingMedianMaxValues bounds the retained median history.
+const rollingMedianMaxValues = 256
+
+// RollingMedian is a moving median over the last `period` samples. Unlike an
+// SMA or EMA it ignores isolated spikes entirely as long as fewer than half of
+// the window's samples are outliers, which makes it a robust pre-filter for
+// noisy tick data.
+//
+// The window is kept twice: once in arrival order (to know which sample to
+// evict) and once sorted, so each update costs a binary search plus an
+// O(period) copy, and reading the median is O(1).
+type RollingMedian struct {
+	period int
+	window []float64 // arrival order
+	sorted []float64 // same samples, ascending
+
+	values    []float64
+	lastValue float64
+}
+
+// NewRollingMedianWithParams creates a rolling median over `period` samples.
+func NewRollingMedianWithParams(period int) (*RollingMedian, error) {
+	if period < 1 {
+		return nil, errors.New("period must be at least 1")
+	}
+	return &RollingMedian{
+		period: period,
+		window: make([]float64, 0, period+1),
+		sorted: make([]float64, 0, period+1),
+		values: make([]float64, 0, 16),
+	}, nil
+}
+
+// Add ingests a sample and records a new median once the window is full.
+func (rm *RollingMedian) Add(value float64) error {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return fmt.Errorf("cannot add invalid value %f", value)
+	}
+
+	rm.window = append(rm.window, value)
+	i := sort.SearchFloat64s(rm.sorted, value)
+	rm.sorted = append(rm.sorted, 0)
+	copy(rm.sorted[i+1:], rm.sorted[i:])
+	rm.sorted[i] = value
+
+	if len(rm.window) > rm.period {
+		oldest := rm.window[0]
+		rm.window = KeepLast(rm.window, rm.period)
+		j := sort.SearchFloat64s(rm.sorted, oldest)
+		rm.sorted = append(rm.sorted[:j], rm.sorted[j+1:]...)
+	}
+	if len(rm.window) < rm.period {
+		return nil
+	}
+
+	n := len(rm.sorted)
+	median := rm.sorted[n/2]
+	if n%2 == 0 {
+		median = (rm.sorted[n/2-1] + rm.sorted[n/2]) / 2
+	}
+	rm.lastValue = median
+	rm.values = append(rm.values, median)
+	rm.values = KeepLast(rm.values, rollingMedianMaxValues)
+	return nil
+}
+
+// Median returns the median of the current window.
+func (rm *RollingMedian) Median() (float64, error) {
+	if len(rm.values) == 0 {
+		return 0, errors.New("insufficient data for median")
+	}
+	return rm.lastValue, nil
+}
+
+// Reset clears all samples while preserving the period.
+func (rm *RollingMedian) Reset() {
+	rm.window = rm.window[:0]
+	rm.sorted = rm.sorted[:0]
+	rm.values = rm.values[:0]
+	rm.lastValue = 0
+}
+
+// SetPeriod updates the window length and resets the smoother.
+func (rm *RollingMedian) SetPeriod(period int) error {
+	if period < 1 {
+		return errors.New("period must be at least 1")
+	}
+	rm.period = period
+	rm.Reset()
+	return nil
+}
+
+// GetValues returns the recorded median series (defensive copy).
+func (rm *RollingMedian) GetValues() []float64 { return CopySlice(rm.values) }
+
+// GetPlotData returns the median series as a single line.
+func (rm *RollingMedian) GetPlotData(startTime, interval int64) []PlotData {
+	if len(rm.values) == 0 {
+		return nil
+	}
+	x := make([]float64, len(rm.values))
+	for i := range x {
+		x[i] = float64(i)
+	}
+	return []PlotData{{
+		Name:      "Rolling Median",
+		X:         x,
+		Y:         CopySlice(rm.values),
+		Type:      "line",
+		Timestamp: GenerateTimestamps(startTime, len(rm.values), interval),
+	}}
+}
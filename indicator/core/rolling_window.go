@@ -0,0 +1,95 @@
+package core
+
+import "errors"
+
+// rollingWindowMaxValues bounds the retained Apply history.
+const rollingWindowMaxValues = 256
+
+// RollingWindow keeps the last `period` samples so custom indicators can be
+// written as a function of the window without their own buffering:
+//
+//	win, _ := NewRollingWindow(10)
+//	win.Add(close)
+//	rng, ok := win.Apply(func(w []float64) float64 { ... })
+//
+// Every value Apply computes is recorded, so GetValues and GetPlotData export
+// the custom series like any built-in indicator.
+type RollingWindow struct {
+	period int
+	window []float64
+
+	values    []float64
+	lastValue float64
+}
+
+// NewRollingWindow creates a window over the last `period` samples.
+func NewRollingWindow(period int) (*RollingWindow, error) {
+	if period < 1 {
+		return nil, errors.New("period must be at least 1")
+	}
+	return &RollingWindow{
+		period: period,
+		window: make([]float64, 0, period+1),
+		values: make([]float64, 0, 16),
+	}, nil
+}
+
+// Add appends a sample, evicting the oldest once the window is full, and
+// returns the current window, oldest first, and whether it holds `period`
+// samples. The slice aliases internal storage: read it, but do not modify or
+// keep it past the next Add.
+func (rw *RollingWindow) Add(v float64) ([]float64, bool) {
+	rw.window = KeepLast(append(rw.window, v), rw.period)
+	return rw.window, len(rw.window) == rw.period
+}
+
+// Apply evaluates fn on the full window and records the result. It returns
+// false, without calling fn, while the window is still filling. fn receives
+// the same aliased slice as Add returns.
+func (rw *RollingWindow) Apply(fn func([]float64) float64) (float64, bool) {
+	if len(rw.window) < rw.period {
+		return 0, false
+	}
+	rw.lastValue = fn(rw.window)
+	rw.values = KeepLast(append(rw.values, rw.lastValue), rollingWindowMaxValues)
+	return rw.lastValue, true
+}
+
+// Calculate returns the latest value recorded by Apply.
+func (rw *RollingWindow) Calculate() (float64, error) {
+	if len(rw.values) == 0 {
+		return 0, errors.New("no RollingWindow values")
+	}
+	return rw.lastValue, nil
+}
+
+// Period returns the window length.
+func (rw *RollingWindow) Period() int { return rw.period }
+
+// Reset clears the samples and the recorded values while preserving the period.
+func (rw *RollingWindow) Reset() {
+	rw.window = rw.window[:0]
+	rw.values = rw.values[:0]
+	rw.lastValue = 0
+}
+
+// GetValues returns the values recorded by Apply (defensive copy).
+func (rw *RollingWindow) GetValues() []float64 { return CopySlice(rw.values) }
+
+// GetPlotData returns the recorded values as a single line named name.
+func (rw *RollingWindow) GetPlotData(name string, startTime, interval int64) []PlotData {
+	if len(rw.values) == 0 {
+		return nil
+	}
+	x := make([]float64, len(rw.values))
+	for i := range x {
+		x[i] = float64(i)
+	}
+	return []PlotData{{
+		Name:      name,
+		X:         x,
+		Y:         CopySlice(rw.values),
+		Type:      "line",
+		Timestamp: GenerateTimestamps(startTime, len(rw.values), interval),
+	}}
+}
@@ -0,0 +1,122 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SessionBoundaryFunc reports whether a bar stamped ts opens a new session
+// given the timestamp of the previous bar. Timestamps are Unix milliseconds,
+// like OHLCV.Timestamp.
+type SessionBoundaryFunc func(prev, ts int64) bool
+
+// DailySessionBoundary starts a new session whenever a bar falls on a later
+// day than its predecessor, with days beginning offsetMillis after midnight
+// UTC. An offset of (13*60+30)*MillisPerMinute, for example, rolls sessions at
+// 13:30 UTC.
+func DailySessionBoundary(offsetMillis int64) SessionBoundaryFunc {
+	day := func(ts int64) int64 {
+		d := ts - offsetMillis
+		if d < 0 {
+			return (d+1)/MillisPerDay - 1
+		}
+		return d / MillisPerDay
+	}
+	return func(prev, ts int64) bool { return day(ts) != day(prev) }
+}
+
+type sessionBar struct {
+	ts        int64
+	high, low float64
+}
+
+// SessionRange tracks the high and low of the current trading session and the
+// range of its opening minutes, the inputs to opening-range-breakout rules.
+// All state is discarded when the boundary function reports a new session.
+type SessionRange struct {
+	boundary SessionBoundaryFunc
+
+	bars      []sessionBar // bars of the current session, oldest first
+	high, low float64
+	lastTs    int64
+}
+
+// NewSessionRange creates a tracker using the given boundary function; nil
+// selects DailySessionBoundary(0), i.e. sessions roll at midnight UTC.
+func NewSessionRange(boundary SessionBoundaryFunc) *SessionRange {
+	if boundary == nil {
+		boundary = DailySessionBoundary(0)
+	}
+	return &SessionRange{boundary: boundary, bars: make([]sessionBar, 0, 64)}
+}
+
+// AddWithTime ingests a bar stamped with Unix milliseconds. Timestamps must not go
+// backwards; a bar that the boundary function places in a new session resets
+// the tracked range before being applied.
+func (sr *SessionRange) AddWithTime(high, low float64, ts int64) error {
+	if high < low {
+		return errors.New("invalid price: high < low")
+	}
+	if !IsValidPrice(high) || !IsValidPrice(low) {
+		return errors.New("invalid price: all prices must be positive")
+	}
+	if len(sr.bars) > 0 {
+		if ts < sr.lastTs {
+			return fmt.Errorf("timestamp %d precedes previous bar %d", ts, sr.lastTs)
+		}
+		if sr.boundary(sr.lastTs, ts) {
+			sr.Reset()
+		}
+	}
+
+	if len(sr.bars) == 0 || high > sr.high {
+		sr.high = high
+	}
+	if len(sr.bars) == 0 || low < sr.low {
+		sr.low = low
+	}
+	sr.bars = append(sr.bars, sessionBar{ts: ts, high: high, low: low})
+	sr.lastTs = ts
+	return nil
+}
+
+// High returns the current session's high, or 0 before the first bar.
+func (sr *SessionRange) High() float64 { return sr.high }
+
+// Low returns the current session's low, or 0 before the first bar.
+func (sr *SessionRange) Low() float64 { return sr.low }
+
+// SessionStart returns the timestamp of the current session's first bar, or
+// 0 before the first bar.
+func (sr *SessionRange) SessionStart() int64 {
+	if len(sr.bars) == 0 {
+		return 0
+	}
+	return sr.bars[0].ts
+}
+
+// OpeningRange returns the high and low of the bars stamped within the first
+// `minutes` minutes of the session, measured from its first bar. Both values
+// are 0 when no bars have been seen or minutes is not positive.
+func (sr *SessionRange) OpeningRange(minutes int) (high, low float64) {
+	if len(sr.bars) == 0 || minutes <= 0 {
+		return 0, 0
+	}
+	end := sr.bars[0].ts + int64(minutes)*MillisPerMinute
+	high, low = sr.bars[0].high, sr.bars[0].low
+	for _, b := range sr.bars[1:] {
+		if b.ts >= end {
+			break
+		}
+		high = max(high, b.high)
+		low = min(low, b.low)
+	}
+	return high, low
+}
+
+// Reset discards the current session; the boundary function is kept.
+func (sr *SessionRange) Reset() {
+	sr.bars = sr.bars[:0]
+	sr.high, sr.low = 0, 0
+	sr.lastTs = 0
+}
@@ -0,0 +1,183 @@
+package core
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// Level kinds reported by SupportResistance.
+const (
+	LevelSupport    = "support"
+	LevelResistance = "resistance"
+	// LevelBoth marks a level tested equally often from above and below,
+	// i.e. one that has flipped between support and resistance.
+	LevelBoth = "support_resistance"
+)
+
+// ToleranceMode selects how SupportResistance interprets its clustering
+// tolerance.
+type ToleranceMode int
+
+const (
+	// ToleranceAbsolute merges pivots within a fixed price distance.
+	ToleranceAbsolute ToleranceMode = iota
+	// TolerancePercent merges pivots within a percentage of the level price.
+	TolerancePercent
+)
+
+// Level is a price zone formed by one or more swing pivots.
+type Level struct {
+	Price   float64 `json:"price"`   // mean price of the clustered pivots
+	Touches int     `json:"touches"` // number of pivots in the cluster
+	Kind    string  `json:"kind"`    // LevelSupport, LevelResistance or LevelBoth
+	// LastTouch is the timestamp of the most recent pivot in the cluster.
+	LastTouch int64 `json:"lastTouch"`
+}
+
+type srBar struct {
+	high, low float64
+	ts        int64
+}
+
+type srPivot struct {
+	price float64
+	high  bool // swing high (resistance) rather than swing low
+	bar   int
+	ts    int64
+}
+
+// SupportResistance detects swing pivots with the five-bar fractal rule – a
+// bar whose high (low) is strictly above (below) the two bars on either side
+// – and clusters the pivots of the last `window` bars into price levels. A
+// pivot is confirmed two bars after it forms.
+type SupportResistance struct {
+	window    int
+	tolerance float64
+	mode      ToleranceMode
+
+	bars   []srBar // last five bars, oldest first
+	pivots []srPivot
+	count  int // bars seen
+}
+
+// NewSupportResistance creates a detector keeping pivots from the last window
+// bars and merging pivots whose prices lie within tolerance of a cluster's
+// mean (a price distance, or a percentage of the mean with TolerancePercent).
+func NewSupportResistance(window int, tolerance float64, mode ToleranceMode) (*SupportResistance, error) {
+	if window < 5 {
+		return nil, errors.New("window must be at least 5 bars")
+	}
+	if tolerance < 0 || math.IsNaN(tolerance) || math.IsInf(tolerance, 0) {
+		return nil, errors.New("tolerance must be non-negative")
+	}
+	if mode != ToleranceAbsolute && mode != TolerancePercent {
+		return nil, errors.New("unknown tolerance mode")
+	}
+	return &SupportResistance{
+		window:    window,
+		tolerance: tolerance,
+		mode:      mode,
+		bars:      make([]srBar, 0, 5),
+	}, nil
+}
+
+// Add ingests a bar's high and low stamped with ts; a level's LastTouch
+// reports the timestamp of its latest pivot bar.
+func (sr *SupportResistance) Add(high, low float64, ts int64) error {
+	if high < low {
+		return errors.New("invalid price: high < low")
+	}
+	if !IsValidPrice(high) || !IsValidPrice(low) {
+		return errors.New("invalid price: all prices must be positive")
+	}
+	sr.count++
+	sr.bars = append(sr.bars, srBar{high: high, low: low, ts: ts})
+	if len(sr.bars) > 5 {
+		sr.bars = sr.bars[1:]
+	}
+	if len(sr.bars) == 5 {
+		mid := sr.bars[2]
+		bar := sr.count - 3
+		isHigh, isLow := true, true
+		for i, b := range sr.bars {
+			if i == 2 {
+				continue
+			}
+			isHigh = isHigh && mid.high > b.high
+			isLow = isLow && mid.low < b.low
+		}
+		if isHigh {
+			sr.pivots = append(sr.pivots, srPivot{price: mid.high, high: true, bar: bar, ts: mid.ts})
+		}
+		if isLow {
+			sr.pivots = append(sr.pivots, srPivot{price: mid.low, bar: bar, ts: mid.ts})
+		}
+	}
+
+	oldest := sr.count - sr.window
+	drop := 0
+	for drop < len(sr.pivots) && sr.pivots[drop].bar < oldest {
+		drop++
+	}
+	sr.pivots = sr.pivots[drop:]
+	return nil
+}
+
+// Levels clusters the retained pivots and returns the levels ordered by
+// price, lowest first. A level's Kind follows the majority of its pivots:
+// swing lows make support, swing highs resistance.
+func (sr *SupportResistance) Levels() []Level {
+	if len(sr.pivots) == 0 {
+		return nil
+	}
+	sorted := make([]srPivot, len(sr.pivots))
+	copy(sorted, sr.pivots)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].price < sorted[j].price })
+
+	var levels []Level
+	var sum float64
+	var n, highs int
+	var last int64
+	flush := func() {
+		lv := Level{Price: sum / float64(n), Touches: n, Kind: LevelBoth, LastTouch: last}
+		switch lows := n - highs; {
+		case highs > lows:
+			lv.Kind = LevelResistance
+		case lows > highs:
+			lv.Kind = LevelSupport
+		}
+		levels = append(levels, lv)
+	}
+	for _, p := range sorted {
+		if n > 0 && !sr.within(p.price, sum/float64(n)) {
+			flush()
+			sum, n, highs = 0, 0, 0
+		}
+		sum += p.price
+		n++
+		if p.high {
+			highs++
+		}
+		if n == 1 || p.ts > last {
+			last = p.ts
+		}
+	}
+	flush()
+	return levels
+}
+
+// Reset discards all bars and pivots; the configuration is kept.
+func (sr *SupportResistance) Reset() {
+	sr.bars = sr.bars[:0]
+	sr.pivots = sr.pivots[:0]
+	sr.count = 0
+}
+
+func (sr *SupportResistance) within(price, mean float64) bool {
+	tol := sr.tolerance
+	if sr.mode == TolerancePercent {
+		tol = mean * sr.tolerance / 100
+	}
+	return math.Abs(price-mean) <= tol
+}
@@ -0,0 +1,124 @@
+package core
+
+import (
+	"errors"
+	"math"
+)
+
+// BarBoundary selects when a TickAggregator closes the bar being built.
+type BarBoundary int
+
+const (
+	// TimeBoundary closes bars on fixed, epoch-aligned time intervals.
+	TimeBoundary BarBoundary = iota
+	// VolumeBoundary closes a bar once its traded size reaches a threshold.
+	VolumeBoundary
+)
+
+// TickAggregator builds OHLCV bars from raw trade ticks so they can be fed to
+// any indicator without an external bar-building step.
+type TickAggregator struct {
+	boundary  BarBoundary
+	interval  int64   // milliseconds per bar (TimeBoundary)
+	barVolume float64 // size per bar (VolumeBoundary)
+
+	bar      OHLCV
+	open     bool // a bar is in progress
+	lastTs   int64
+	rejected int
+}
+
+// NewTimeTickAggregator creates an aggregator that emits one bar per interval
+// milliseconds, e.g. MillisPerMinute for 1-minute bars. Tick timestamps are
+// Unix milliseconds; bars are aligned to multiples of interval since the Unix
+// epoch and stamped with the start of their interval.
+func NewTimeTickAggregator(interval int64) (*TickAggregator, error) {
+	if interval < 1 {
+		return nil, errors.New("interval must be at least 1 millisecond")
+	}
+	return &TickAggregator{boundary: TimeBoundary, interval: interval}, nil
+}
+
+// NewVolumeTickAggregator creates an aggregator that emits a bar as soon as the
+// ticks it contains reach volume. Ticks are not split, so a bar can overshoot
+// the threshold by up to one tick. Bars are stamped with their first tick.
+func NewVolumeTickAggregator(volume float64) (*TickAggregator, error) {
+	if !(volume > 0) || math.IsInf(volume, 0) {
+		return nil, errors.New("bar volume must be positive")
+	}
+	return &TickAggregator{boundary: VolumeBoundary, barVolume: volume}, nil
+}
+
+// AddTick folds a trade into the current bar. When the tick completes a bar,
+// that bar is returned with complete == true:
+//
+//   - TimeBoundary: a tick from a later interval closes the previous bar (the
+//     returned bar does not include it) and opens the next one.
+//   - VolumeBoundary: the tick that brings the bar's volume to the threshold
+//     is included in the returned bar, and the next tick opens a new bar.
+//
+// Ticks with a non-positive or non-finite price, a negative size, or a
+// timestamp older than the previous tick are ignored and counted by Rejected.
+func (ta *TickAggregator) AddTick(price, size float64, ts int64) (bar OHLCV, complete bool) {
+	if !IsValidPrice(price) || !IsValidVolume(size) || (ta.lastTs != 0 && ts < ta.lastTs) {
+		ta.rejected++
+		return OHLCV{}, false
+	}
+	ta.lastTs = ts
+
+	if ta.boundary == TimeBoundary && ta.open && ta.bucket(ts) != ta.bar.Timestamp {
+		bar, complete = ta.bar, true
+		ta.open = false
+	}
+
+	if !ta.open {
+		start := ts
+		if ta.boundary == TimeBoundary {
+			start = ta.bucket(ts)
+		}
+		ta.bar = OHLCV{Timestamp: start, Open: price, High: price, Low: price, Close: price}
+		ta.open = true
+	}
+	ta.bar.High = math.Max(ta.bar.High, price)
+	ta.bar.Low = math.Min(ta.bar.Low, price)
+	ta.bar.Close = price
+	ta.bar.Volume += size
+
+	if ta.boundary == VolumeBoundary && ta.bar.Volume >= ta.barVolume {
+		bar, complete = ta.bar, true
+		ta.open = false
+	}
+	return bar, complete
+}
+
+// Current returns the bar in progress, if any.
+func (ta *TickAggregator) Current() (OHLCV, bool) { return ta.bar, ta.open }
+
+// Flush closes and returns the bar in progress, e.g. at the end of a feed.
+func (ta *TickAggregator) Flush() (OHLCV, bool) {
+	if !ta.open {
+		return OHLCV{}, false
+	}
+	ta.open = false
+	return ta.bar, true
+}
+
+// Rejected returns how many ticks AddTick has ignored as invalid.
+func (ta *TickAggregator) Rejected() int { return ta.rejected }
+
+// Reset discards the bar in progress and the tick history.
+func (ta *TickAggregator) Reset() {
+	ta.bar = OHLCV{}
+	ta.open = false
+	ta.lastTs = 0
+	ta.rejected = 0
+}
+
+// bucket returns the start of the interval containing ts.
+func (ta *TickAggregator) bucket(ts int64) int64 {
+	b := ts - ts%ta.interval
+	if ts < 0 && ts%ta.interval != 0 {
+		b -= ta.interval
+	}
+	return b
+}
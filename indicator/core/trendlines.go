@@ -0,0 +1,208 @@
+package core
+
+import "math"
+
+// Line is a straight trendline over bar indices: ValueAt(bar) equals
+// Intercept + Slope·bar. P1 and P2 are the indices of the two pivots it was
+// fitted through, oldest first.
+type Line struct {
+	Slope     float64 `json:"slope"`
+	Intercept float64 `json:"intercept"`
+	P1        int     `json:"p1"`
+	P2        int     `json:"p2"`
+}
+
+// ValueAt returns the line's price at the given bar index; bars past P2
+// extrapolate the trendline.
+func (l Line) ValueAt(bar int) float64 {
+	return l.Intercept + l.Slope*float64(bar)
+}
+
+// Valid reports whether the line was fitted through two pivots. FitTrendlines
+// returns the zero Line when fewer than two pivots exist.
+func (l Line) Valid() bool { return l.P2 > l.P1 }
+
+// FitTrendlines connects the two most recent pivot lows (support) and the two
+// most recent pivot highs (resistance) in prices, using the pivots found by
+// Pivots. Bar indices refer to positions in prices. Either line is the zero
+// Line when fewer than two pivots of its kind exist or a bar count is below 1.
+func FitTrendlines(prices []float64, leftBars, rightBars int) (support, resistance Line) {
+	lows, highs := Pivots(prices, leftBars, rightBars)
+	return lineThroughLast(prices, lows), lineThroughLast(prices, highs)
+}
+
+// Pivots returns the indices of the pivot lows and pivot highs in values,
+// oldest first. A pivot high is a bar strictly above the leftBars bars before
+// it and the rightBars bars after it; pivot lows mirror this, so the last
+// rightBars bars can never be pivots. Both are nil when a bar count is below
+// 1.
+func Pivots(values []float64, leftBars, rightBars int) (lows, highs []int) {
+	if leftBars < 1 || rightBars < 1 {
+		return nil, nil
+	}
+	for i := leftBars; i < len(values)-rightBars; i++ {
+		isHigh, isLow := true, true
+		for j := i - leftBars; j <= i+rightBars; j++ {
+			if j == i {
+				continue
+			}
+			isHigh = isHigh && values[i] > values[j]
+			isLow = isLow && values[i] < values[j]
+		}
+		if isHigh {
+			highs = append(highs, i)
+		}
+		if isLow {
+			lows = append(lows, i)
+		}
+	}
+	return lows, highs
+}
+
+// Divergence directions reported by PivotDivergence.
+const (
+	DivergenceBullish = "bullish"
+	DivergenceBearish = "bearish"
+)
+
+// PivotDivergence compares the last two price pivots (see Pivots) with the
+// oscillator readings on the same bars. Two pivot lows where price makes a
+// lower low but the oscillator a higher low are a bullish divergence; two
+// pivot highs with a higher price high and a lower oscillator high are
+// bearish. When both exist the one completed by the more recent pivot wins.
+// osc must be aligned with prices; pivots on bars where osc is NaN (e.g. the
+// oscillator's warm-up) are ignored. It returns "" when there is no
+// divergence.
+func PivotDivergence(prices, osc []float64, leftBars, rightBars int) string {
+	dir, _, _ := lastPivotDivergence(prices, osc, leftBars, rightBars)
+	return dir
+}
+
+// PivotDivergenceStrength reports the divergence PivotDivergence finds along
+// with its magnitude: DivergenceStrength between the two pivots that define
+// it, with each leg measured against the range of its whole input series
+// (NaN and ±Inf skipped), divided by the bars separating the pivots. A
+// divergence that develops quickly therefore outranks the same disagreement
+// spread over many bars. strength is 0 when there is no divergence.
+func PivotDivergenceStrength(prices, osc []float64, leftBars, rightBars int) (dir string, strength float64) {
+	dir, a, b := lastPivotDivergence(prices, osc, leftBars, rightBars)
+	if dir == "" {
+		return "", 0
+	}
+	strength = DivergenceStrength(prices[a], prices[b], osc[a], osc[b], finiteSpan(prices), finiteSpan(osc))
+	return dir, strength / float64(b-a)
+}
+
+// finiteSpan returns max − min over the finite values, or 0 when there are
+// none.
+func finiteSpan(values []float64) float64 {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	if hi < lo {
+		return 0
+	}
+	return hi - lo
+}
+
+// lastPivotDivergence implements PivotDivergence and also returns the two
+// pivot indices (a < b) that define the reported divergence.
+func lastPivotDivergence(prices, osc []float64, leftBars, rightBars int) (dir string, a, b int) {
+	if len(osc) != len(prices) {
+		return "", 0, 0
+	}
+	lows, highs := Pivots(prices, leftBars, rightBars)
+	lows, highs = withOscillator(lows, osc), withOscillator(highs, osc)
+
+	bull, bear := [2]int{-1, -1}, [2]int{-1, -1}
+	if n := len(lows); n >= 2 {
+		a, b := lows[n-2], lows[n-1]
+		if prices[b] < prices[a] && osc[b] > osc[a] {
+			bull = [2]int{a, b}
+		}
+	}
+	if n := len(highs); n >= 2 {
+		a, b := highs[n-2], highs[n-1]
+		if prices[b] > prices[a] && osc[b] < osc[a] {
+			bear = [2]int{a, b}
+		}
+	}
+	switch {
+	case bull[1] < 0 && bear[1] < 0:
+		return "", 0, 0
+	case bull[1] > bear[1]:
+		return DivergenceBullish, bull[0], bull[1]
+	default:
+		return DivergenceBearish, bear[0], bear[1]
+	}
+}
+
+// divergenceWindowSize bounds the history a DivergenceWindow keeps and
+// divergencePivotBars is the pivot width it passes to Pivots on each side.
+const (
+	divergenceWindowSize = 64
+	divergencePivotBars  = 2
+)
+
+// DivergenceWindow keeps the last 64 closes and oscillator values aligned bar
+// for bar, so a streaming oscillator can run PivotDivergenceStrength over
+// its own recent history with pivots two bars wide. The zero value is ready
+// to use.
+type DivergenceWindow struct {
+	prices []float64
+	osc    []float64
+}
+
+// Observe records one bar's close and oscillator value.
+func (w *DivergenceWindow) Observe(price, osc float64) {
+	w.prices = KeepLast(append(w.prices, price), divergenceWindowSize)
+	w.osc = KeepLast(append(w.osc, osc), divergenceWindowSize)
+}
+
+// Strength runs PivotDivergenceStrength over the window. ok is false until
+// enough bars exist for two pivots.
+func (w *DivergenceWindow) Strength() (dir string, strength float64, ok bool) {
+	if len(w.prices) < 4*divergencePivotBars+2 {
+		return "", 0, false
+	}
+	dir, strength = PivotDivergenceStrength(w.prices, w.osc, divergencePivotBars, divergencePivotBars)
+	return dir, strength, true
+}
+
+// Clone returns a copy that does not share storage with w.
+func (w *DivergenceWindow) Clone() DivergenceWindow {
+	return DivergenceWindow{prices: CopySlice(w.prices), osc: CopySlice(w.osc)}
+}
+
+// Reset forgets all bars.
+func (w *DivergenceWindow) Reset() { *w = DivergenceWindow{} }
+
+// withOscillator drops the pivots whose oscillator reading is NaN.
+func withOscillator(idx []int, osc []float64) []int {
+	out := idx[:0]
+	for _, i := range idx {
+		if !math.IsNaN(osc[i]) {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// lineThroughLast fits a Line through the last two pivots in idx.
+func lineThroughLast(prices []float64, idx []int) Line {
+	if len(idx) < 2 {
+		return Line{}
+	}
+	p1, p2 := idx[len(idx)-2], idx[len(idx)-1]
+	slope := (prices[p2] - prices[p1]) / float64(p2-p1)
+	return Line{
+		Slope:     slope,
+		Intercept: prices[p1] - slope*float64(p1),
+		P1:        p1,
+		P2:        p2,
+	}
+}
@@ -1,9 +1,12 @@
 package indicator
 
 import (
+	"io"
+
 	"github.com/evdnx/goti/config"
 	"github.com/evdnx/goti/indicator/core"
 	"github.com/evdnx/goti/indicator/momentum"
+	"github.com/evdnx/goti/indicator/stats"
 	"github.com/evdnx/goti/indicator/trend"
 	"github.com/evdnx/goti/indicator/volatility"
 	"github.com/evdnx/goti/indicator/volume"
@@ -11,32 +14,349 @@ import (
 
 // ---- Shared data helpers ----
 type PlotData = core.PlotData
+type OHLCV = core.OHLCV
+type SignalEvent = core.SignalEvent
+type IndicatorInfo = core.IndicatorInfo
+
+func StreamBars(r io.Reader, fn func(core.OHLCV) error) error {
+	return core.StreamBars(r, fn)
+}
+
+func OverlayPlotData(name string, values []float64, lastBar int, startTime, interval int64) core.PlotData {
+	return core.OverlayPlotData(name, values, lastBar, startTime, interval)
+}
+
+const (
+	EventBullishCrossover = core.EventBullishCrossover
+	EventBearishCrossover = core.EventBearishCrossover
+	EventOverbought       = core.EventOverbought
+	EventOversold         = core.EventOversold
+	EventNeutral          = core.EventNeutral
+)
+
+type ZoneEvent = core.ZoneEvent
+
+const (
+	ZoneNone          = core.ZoneNone
+	EnteredOverbought = core.EnteredOverbought
+	ExitedOverbought  = core.ExitedOverbought
+	EnteredOversold   = core.EnteredOversold
+	ExitedOversold    = core.ExitedOversold
+)
+
+func ClassifyZoneTransition(prev, cur, overbought, oversold float64) core.ZoneEvent {
+	return core.ClassifyZoneTransition(prev, cur, overbought, oversold)
+}
 
 func GenerateTimestamps(startTime int64, count int, interval int64) []int64 {
 	return core.GenerateTimestamps(startTime, count, interval)
 }
 
+func SlicePlotData(data []PlotData, from, to int) []PlotData {
+	return core.SlicePlotData(data, from, to)
+}
+
+type Marker = core.Marker
+type PlotDataWithMarkers = core.PlotDataWithMarkers
+
+const (
+	MarkerBullishCross    = core.MarkerBullishCross
+	MarkerBearishCross    = core.MarkerBearishCross
+	MarkerOverbought      = core.MarkerOverbought
+	MarkerOversold        = core.MarkerOversold
+	MarkerStrongUptrend   = core.MarkerStrongUptrend
+	MarkerStrongDowntrend = core.MarkerStrongDowntrend
+)
+
+var (
+	ZoneMarkerKinds  = core.ZoneMarkerKinds
+	TrendMarkerKinds = core.TrendMarkerKinds
+)
+
+func ExtractMarkers(data []PlotData, kinds map[float64]string) PlotDataWithMarkers {
+	return core.ExtractMarkers(data, kinds)
+}
+
+func CorrectCandle(high, low, close float64) (float64, float64, float64, bool) {
+	return core.CorrectCandle(high, low, close)
+}
+
+func Percentile(values []float64, pct float64) (float64, error) {
+	return core.Percentile(values, pct)
+}
+
+func Histogram(values []float64, bins int) (edges []float64, counts []int) {
+	return core.Histogram(values, bins)
+}
+
+func Returns(prices []float64) []float64 {
+	return core.Returns(prices)
+}
+
+func CrossSeries(a, b []float64) (int, int) {
+	return core.CrossSeries(a, b)
+}
+
+func DivergenceStrength(pricePrev, priceCurr, oscPrev, oscCurr, priceRange, oscRange float64) float64 {
+	return core.DivergenceStrength(pricePrev, priceCurr, oscPrev, oscCurr, priceRange, oscRange)
+}
+
+func OutputSlope(values []float64, n int) (float64, error) {
+	return core.OutputSlope(values, n)
+}
+
+func Correlation(a, b []float64) (float64, error) {
+	return core.Correlation(a, b)
+}
+
+func LaggedCorrelation(a, b []float64, maxLag int) (bestLag int, bestCorr float64) {
+	return core.LaggedCorrelation(a, b, maxLag)
+}
+
+func TrackMFE(signals []SignalEvent, closes []float64, horizon int) []float64 {
+	return core.TrackMFE(signals, closes, horizon)
+}
+
+func EstimateLag(ma *core.MovingAverage, testSeries []float64) float64 {
+	return core.EstimateLag(ma, testSeries)
+}
+
+func EstimateSeriesLag(input, output []float64) float64 {
+	return core.EstimateSeriesLag(input, output)
+}
+
+func WarmupConfidence(emitted, period int) float64 {
+	return core.WarmupConfidence(emitted, period)
+}
+
+func InterpolateLast(values []float64, frac float64) (float64, error) {
+	return core.InterpolateLast(values, frac)
+}
+
+func ApplyTransform(fn func(float64) float64, v float64) float64 {
+	return core.ApplyTransform(fn, v)
+}
+
+var ErrNonFinite = core.ErrNonFinite
+
+func CheckFinite(name string, v float64) error {
+	return core.CheckFinite(name, v)
+}
+
+func ValidateFinite(name string, values []float64) error {
+	return core.ValidateFinite(name, values)
+}
+
+func LogReturns(prices []float64) []float64 {
+	return core.LogReturns(prices)
+}
+
 func FormatPlotDataJSON(data []PlotData) (string, error) {
 	return core.FormatPlotDataJSON(data)
 }
 
+type PlotDataEnvelope = core.PlotDataEnvelope
+
+const PlotDataSchemaVersion = core.PlotDataSchemaVersion
+
+func FormatPlotDataJSONV2(data []PlotData) (string, error) {
+	return core.FormatPlotDataJSONV2(data)
+}
+
+func FormatPlotDataEnvelopeJSON(env PlotDataEnvelope) (string, error) {
+	return core.FormatPlotDataEnvelopeJSON(env)
+}
+
 func FormatPlotDataCSV(data []PlotData) (string, error) {
 	return core.FormatPlotDataCSV(data)
 }
 
+type PlotWriter = core.PlotWriter
+type PlotFormat = core.PlotFormat
+
+const (
+	PlotFormatCSV    = core.PlotFormatCSV
+	PlotFormatNDJSON = core.PlotFormatNDJSON
+)
+
+func NewPlotWriter(w io.Writer, format PlotFormat) (*core.PlotWriter, error) {
+	return core.NewPlotWriter(w, format)
+}
+
+func StreamPlotDataCSV(w io.Writer, data []PlotData) error {
+	return core.StreamPlotDataCSV(w, data)
+}
+
 // ---- Moving averages & utilities ----
 type MovingAverageType = core.MovingAverageType
 
 const (
-	EMAMovingAverage MovingAverageType = core.EMAMovingAverage
-	SMAMovingAverage MovingAverageType = core.SMAMovingAverage
-	WMAMovingAverage MovingAverageType = core.WMAMovingAverage
+	EMAMovingAverage  MovingAverageType = core.EMAMovingAverage
+	SMAMovingAverage  MovingAverageType = core.SMAMovingAverage
+	WMAMovingAverage  MovingAverageType = core.WMAMovingAverage
+	RMAMovingAverage  MovingAverageType = core.RMAMovingAverage
+	DEMAMovingAverage MovingAverageType = core.DEMAMovingAverage
 )
 
 type MovingAverage = core.MovingAverage
 
-func NewMovingAverage(maType MovingAverageType, period int) (*core.MovingAverage, error) {
-	return core.NewMovingAverage(maType, period)
+func NewMovingAverage(maType MovingAverageType, period int, opts ...core.MAOption) (*core.MovingAverage, error) {
+	return core.NewMovingAverage(maType, period, opts...)
+}
+
+type MAOption = core.MAOption
+
+func WithMAEarlyValues(enabled bool) core.MAOption {
+	return core.WithEarlyValues(enabled)
+}
+
+type EMASeedMode = core.EMASeedMode
+
+const (
+	SeedSMA        = core.SeedSMA
+	SeedFirstValue = core.SeedFirstValue
+)
+
+func WithEMASeed(seed EMASeedMode) core.MAOption {
+	return core.WithEMASeed(seed)
+}
+
+func WithTALibCompat(enabled bool) core.MAOption {
+	return core.WithTALibCompat(enabled)
+}
+
+func WithMAInvertedPrice(enabled bool) core.MAOption {
+	return core.WithInvertedPrice(enabled)
+}
+
+type RollingMedian = core.RollingMedian
+type RollingWindow = core.RollingWindow
+type Extremes = core.Extremes
+type DivergenceWindow = core.DivergenceWindow
+
+type SupportResistance = core.SupportResistance
+type Level = core.Level
+type ToleranceMode = core.ToleranceMode
+
+const (
+	ToleranceAbsolute = core.ToleranceAbsolute
+	TolerancePercent  = core.TolerancePercent
+	LevelSupport      = core.LevelSupport
+	LevelResistance   = core.LevelResistance
+	LevelBoth         = core.LevelBoth
+)
+
+type Line = core.Line
+
+func FitTrendlines(prices []float64, leftBars, rightBars int) (support, resistance Line) {
+	return core.FitTrendlines(prices, leftBars, rightBars)
+}
+
+func Pivots(values []float64, leftBars, rightBars int) (lows, highs []int) {
+	return core.Pivots(values, leftBars, rightBars)
+}
+
+const (
+	DivergenceBullish = core.DivergenceBullish
+	DivergenceBearish = core.DivergenceBearish
+)
+
+func PivotDivergence(prices, osc []float64, leftBars, rightBars int) string {
+	return core.PivotDivergence(prices, osc, leftBars, rightBars)
+}
+
+func PivotDivergenceStrength(prices, osc []float64, leftBars, rightBars int) (dir string, strength float64) {
+	return core.PivotDivergenceStrength(prices, osc, leftBars, rightBars)
+}
+
+func ScanDivergence(data map[string][]float64, rsiPeriod, leftBars, rightBars int) map[string]string {
+	return momentum.ScanDivergence(data, rsiPeriod, leftBars, rightBars)
+}
+
+func NewSupportResistance(window int, tolerance float64, mode ToleranceMode) (*SupportResistance, error) {
+	return core.NewSupportResistance(window, tolerance, mode)
+}
+
+type GapFiller = core.GapFiller
+type GapFillMode = core.GapFillMode
+
+const (
+	GapFillFlat   = core.GapFillFlat
+	GapSkipMarked = core.GapSkipMarked
+)
+
+func NewGapFiller(mode GapFillMode) *GapFiller {
+	return core.NewGapFiller(mode)
+}
+
+type CrossoverInclusivity = core.CrossoverInclusivity
+
+const (
+	CrossoverDefault   = core.CrossoverDefault
+	CrossoverInclusive = core.CrossoverInclusive
+	CrossoverStrict    = core.CrossoverStrict
+)
+
+func CrossedAbove(prev, cur, level float64, allowEqual bool) bool {
+	return core.CrossedAbove(prev, cur, level, allowEqual)
+}
+
+func CrossedBelow(prev, cur, level float64, allowEqual bool) bool {
+	return core.CrossedBelow(prev, cur, level, allowEqual)
+}
+
+func NewRollingMedianWithParams(period int) (*core.RollingMedian, error) {
+	return core.NewRollingMedianWithParams(period)
+}
+
+func NewRollingWindow(period int) (*core.RollingWindow, error) {
+	return core.NewRollingWindow(period)
+}
+
+type MedianPrice = core.MedianPrice
+type WeightedClose = core.WeightedClose
+
+func NewMedianPrice() *core.MedianPrice { return core.NewMedianPrice() }
+
+func NewWeightedClose() *core.WeightedClose { return core.NewWeightedClose() }
+
+type SessionRange = core.SessionRange
+type SessionBoundaryFunc = core.SessionBoundaryFunc
+
+func NewSessionRange(boundary core.SessionBoundaryFunc) *core.SessionRange {
+	return core.NewSessionRange(boundary)
+}
+
+const (
+	MillisPerSecond = core.MillisPerSecond
+	MillisPerMinute = core.MillisPerMinute
+	MillisPerDay    = core.MillisPerDay
+)
+
+func DailySessionBoundary(offsetMillis int64) core.SessionBoundaryFunc {
+	return core.DailySessionBoundary(offsetMillis)
+}
+
+type TickAggregator = core.TickAggregator
+type BarBoundary = core.BarBoundary
+
+const (
+	TimeBoundary   = core.TimeBoundary
+	VolumeBoundary = core.VolumeBoundary
+)
+
+type Resampler = core.Resampler
+
+func NewResampler(baseInterval, interval int64) (*core.Resampler, error) {
+	return core.NewResampler(baseInterval, interval)
+}
+
+func NewTimeTickAggregator(interval int64) (*core.TickAggregator, error) {
+	return core.NewTimeTickAggregator(interval)
+}
+
+func NewVolumeTickAggregator(volume float64) (*core.TickAggregator, error) {
+	return core.NewVolumeTickAggregator(volume)
 }
 
 func KeepLast[T any](s []T, n int) []T { return core.KeepLast(s, n) }
@@ -60,15 +380,58 @@ func IsValidVolume(volume float64) bool     { return core.IsValidVolume(volume)
 // ---- Momentum indicators ----
 type RelativeStrengthIndex = momentum.RelativeStrengthIndex
 type MACD = momentum.MACD
+
+const (
+	MACDRisingPositive  = momentum.MACDRisingPositive
+	MACDFallingPositive = momentum.MACDFallingPositive
+	MACDRisingNegative  = momentum.MACDRisingNegative
+	MACDFallingNegative = momentum.MACDFallingNegative
+)
+
 type StochasticOscillator = momentum.StochasticOscillator
+type StochasticMode = momentum.StochasticMode
+
+const (
+	DefaultStochasticSlowing = momentum.DefaultStochasticSlowing
+	StochFast                = momentum.StochFast
+	StochSlow                = momentum.StochSlow
+	StochFull                = momentum.StochFull
+)
+
 type CommodityChannelIndex = momentum.CommodityChannelIndex
 
 func NewRelativeStrengthIndex() (*momentum.RelativeStrengthIndex, error) {
 	return momentum.NewRelativeStrengthIndex()
 }
 
-func NewRelativeStrengthIndexWithParams(period int, cfg config.IndicatorConfig) (*momentum.RelativeStrengthIndex, error) {
-	return momentum.NewRelativeStrengthIndexWithParams(period, cfg)
+func NewRelativeStrengthIndexWithParams(period int, cfg config.IndicatorConfig, opts ...momentum.RSIOption) (*momentum.RelativeStrengthIndex, error) {
+	return momentum.NewRelativeStrengthIndexWithParams(period, cfg, opts...)
+}
+
+type RSIOption = momentum.RSIOption
+
+func WithRSIDynamicThresholds(window int, hiPct, loPct float64) momentum.RSIOption {
+	return momentum.WithDynamicThresholds(window, hiPct, loPct)
+}
+
+func WithRSIStrictOutputCheck(enabled bool) momentum.RSIOption {
+	return momentum.WithStrictOutputCheck(enabled)
+}
+
+func WithRSICutlerMethod(enabled bool) momentum.RSIOption {
+	return momentum.WithCutlerMethod(enabled)
+}
+
+func WithRSIHistory(n int) momentum.RSIOption {
+	return momentum.WithHistory(n)
+}
+
+func WithRSICrossoverInclusivity(mode core.CrossoverInclusivity) momentum.RSIOption {
+	return momentum.WithCrossoverInclusivity(mode)
+}
+
+func WithRSIInvertedPrice(enabled bool) momentum.RSIOption {
+	return momentum.WithInvertedPrice(enabled)
 }
 
 type AdaptiveDEMAMomentumOscillator = momentum.AdaptiveDEMAMomentumOscillator
@@ -79,6 +442,14 @@ const (
 	DefaultStdWeight   = momentum.DefaultStdWeight
 )
 
+type NormMode = momentum.NormMode
+
+const (
+	NormZScore     = momentum.NormZScore
+	NormATR        = momentum.NormATR
+	NormPercentile = momentum.NormPercentile
+)
+
 var (
 	ErrInsufficientData = momentum.ErrInsufficientData
 	ErrInvalidParams    = momentum.ErrInvalidParams
@@ -118,6 +489,81 @@ func NewCommodityChannelIndexWithParams(period int) (*momentum.CommodityChannelI
 	return momentum.NewCommodityChannelIndexWithParams(period)
 }
 
+type ElderRay = momentum.ElderRay
+
+func NewElderRay() (*momentum.ElderRay, error) {
+	return momentum.NewElderRay()
+}
+
+func NewElderRayWithParams(emaPeriod int) (*momentum.ElderRay, error) {
+	return momentum.NewElderRayWithParams(emaPeriod)
+}
+
+type AwesomeOscillator = momentum.AwesomeOscillator
+type AcceleratorOscillator = momentum.AcceleratorOscillator
+
+const (
+	DefaultAOFastPeriod = momentum.DefaultAOFastPeriod
+	DefaultAOSlowPeriod = momentum.DefaultAOSlowPeriod
+	DefaultACPeriod     = momentum.DefaultACPeriod
+	HistogramUp         = momentum.HistogramUp
+	HistogramDown       = momentum.HistogramDown
+)
+
+func NewAwesomeOscillator() (*momentum.AwesomeOscillator, error) {
+	return momentum.NewAwesomeOscillator()
+}
+
+func NewAwesomeOscillatorWithParams(fastPeriod, slowPeriod int) (*momentum.AwesomeOscillator, error) {
+	return momentum.NewAwesomeOscillatorWithParams(fastPeriod, slowPeriod)
+}
+
+func NewAcceleratorOscillator() (*momentum.AcceleratorOscillator, error) {
+	return momentum.NewAcceleratorOscillator()
+}
+
+func NewAcceleratorOscillatorWithParams(fastPeriod, slowPeriod, period int) (*momentum.AcceleratorOscillator, error) {
+	return momentum.NewAcceleratorOscillatorWithParams(fastPeriod, slowPeriod, period)
+}
+
+type RelativeVigorIndex = momentum.RelativeVigorIndex
+
+const DefaultRVIPeriod = momentum.DefaultRVIPeriod
+
+func NewRelativeVigorIndex() (*momentum.RelativeVigorIndex, error) {
+	return momentum.NewRelativeVigorIndex()
+}
+
+func NewRelativeVigorIndexWithParams(period int) (*momentum.RelativeVigorIndex, error) {
+	return momentum.NewRelativeVigorIndexWithParams(period)
+}
+
+type AdaptiveRSI = momentum.AdaptiveRSI
+
+const (
+	DefaultAdaptiveRSIMinPeriod        = momentum.DefaultAdaptiveRSIMinPeriod
+	DefaultAdaptiveRSIMaxPeriod        = momentum.DefaultAdaptiveRSIMaxPeriod
+	DefaultAdaptiveRSIVolatilityPeriod = momentum.DefaultAdaptiveRSIVolatilityPeriod
+)
+
+func NewAdaptiveRSI() (*momentum.AdaptiveRSI, error) {
+	return momentum.NewAdaptiveRSI()
+}
+
+func NewAdaptiveRSIWithParams(minPeriod, maxPeriod, volatilityPeriod int) (*momentum.AdaptiveRSI, error) {
+	return momentum.NewAdaptiveRSIWithParams(minPeriod, maxPeriod, volatilityPeriod)
+}
+
+type QQE = momentum.QQE
+
+func NewQQE() (*momentum.QQE, error) {
+	return momentum.NewQQE()
+}
+
+func NewQQEWithParams(rsiPeriod, smoothing int, factor float64) (*momentum.QQE, error) {
+	return momentum.NewQQEWithParams(rsiPeriod, smoothing, factor)
+}
+
 // ---- Trend indicators ----
 type HullMovingAverage = trend.HullMovingAverage
 type ParabolicSAR = trend.ParabolicSAR
@@ -164,6 +610,31 @@ func NewParabolicSARWithParams(step, maxStep float64) (*trend.ParabolicSAR, erro
 	return trend.NewParabolicSARWithParams(step, maxStep)
 }
 
+type GannHiLo = trend.GannHiLo
+type MARibbon = trend.MARibbon
+
+func NewMARibbon(maType core.MovingAverageType, periods []int) (*trend.MARibbon, error) {
+	return trend.NewMARibbon(maType, periods)
+}
+
+func NewGannHiLo() (*trend.GannHiLo, error) {
+	return trend.NewGannHiLo()
+}
+
+func NewGannHiLoWithParams(period int) (*trend.GannHiLo, error) {
+	return trend.NewGannHiLoWithParams(period)
+}
+
+type McGinleyDynamic = trend.McGinleyDynamic
+
+func NewMcGinley() (*trend.McGinleyDynamic, error) {
+	return trend.NewMcGinley()
+}
+
+func NewMcGinleyWithParams(period int) (*trend.McGinleyDynamic, error) {
+	return trend.NewMcGinleyWithParams(period)
+}
+
 // ---- Volume indicators ----
 type MoneyFlowIndex = volume.MoneyFlowIndex
 type VWAP = volume.VWAP
@@ -177,23 +648,107 @@ func NewMoneyFlowIndex() (*volume.MoneyFlowIndex, error) {
 	return volume.NewMoneyFlowIndex()
 }
 
-func NewMoneyFlowIndexWithParams(period int, cfg config.IndicatorConfig) (*volume.MoneyFlowIndex, error) {
-	return volume.NewMoneyFlowIndexWithParams(period, cfg)
+func NewMoneyFlowIndexWithParams(period int, cfg config.IndicatorConfig, opts ...volume.MFIOption) (*volume.MoneyFlowIndex, error) {
+	return volume.NewMoneyFlowIndexWithParams(period, cfg, opts...)
+}
+
+type MFIOption = volume.MFIOption
+
+func WithMFIAutoCorrect(enabled bool) volume.MFIOption {
+	return volume.WithAutoCorrect(enabled)
+}
+
+func WithMFIStrictOutputCheck(enabled bool) volume.MFIOption {
+	return volume.WithStrictOutputCheck(enabled)
+}
+
+func WithMFIHistory(n int) volume.MFIOption {
+	return volume.WithHistory(n)
+}
+
+func WithMFICrossoverInclusivity(mode core.CrossoverInclusivity) volume.MFIOption {
+	return volume.WithCrossoverInclusivity(mode)
+}
+
+func WithVolumeAutoScale(enabled bool) volume.MFIOption {
+	return volume.WithVolumeAutoScale(enabled)
 }
 
 func NewVWAP() *volume.VWAP {
 	return volume.NewVWAP()
 }
 
+func NewDecayVWAP(halfLifeBars float64) (*volume.VWAP, error) {
+	return volume.NewDecayVWAP(halfLifeBars)
+}
+
+type AccumulationDistribution = volume.AccumulationDistribution
+type ChaikinOscillator = volume.ChaikinOscillator
+
+func NewAccumulationDistribution() *volume.AccumulationDistribution {
+	return volume.NewAccumulationDistribution()
+}
+
+func NewChaikinOscillator() (*volume.ChaikinOscillator, error) {
+	return volume.NewChaikinOscillator()
+}
+
+func NewChaikinOscillatorWithParams(fast, slow int) (*volume.ChaikinOscillator, error) {
+	return volume.NewChaikinOscillatorWithParams(fast, slow)
+}
+
+type VolumeRSI = volume.VolumeRSI
+
+const (
+	DefaultVolumeRSIPeriod     = volume.DefaultVolumeRSIPeriod
+	DefaultVolumeRSIOverbought = volume.DefaultVolumeRSIOverbought
+	DefaultVolumeRSIOversold   = volume.DefaultVolumeRSIOversold
+)
+
+func NewVolumeRSI() (*volume.VolumeRSI, error) {
+	return volume.NewVolumeRSI()
+}
+
+func NewVolumeRSIWithParams(period int) (*volume.VolumeRSI, error) {
+	return volume.NewVolumeRSIWithParams(period)
+}
+
 // ---- Volatility indicators ----
 type AverageTrueRange = volatility.AverageTrueRange
 type ATROption = volatility.ATROption
+type ATRGapMode = volatility.GapMode
 type BollingerBands = volatility.BollingerBands
+type BollingerOption = volatility.BollingerOption
+type VolatilityRegime = volatility.Regime
+
+const (
+	VolatilityLow           = volatility.VolatilityLow
+	VolatilityNormal        = volatility.VolatilityNormal
+	VolatilityHigh          = volatility.VolatilityHigh
+	VolatilityExtreme       = volatility.VolatilityExtreme
+	DefaultRegimeLowPct     = volatility.DefaultRegimeLowPct
+	DefaultRegimeHighPct    = volatility.DefaultRegimeHighPct
+	DefaultRegimeExtremePct = volatility.DefaultRegimeExtremePct
+	IncludeGaps             = volatility.IncludeGaps
+	ExcludeGaps             = volatility.ExcludeGaps
+)
 
 func WithCloseValidation(enabled bool) volatility.ATROption {
 	return volatility.WithCloseValidation(enabled)
 }
 
+func WithATRAutoCorrect(enabled bool) volatility.ATROption {
+	return volatility.WithAutoCorrect(enabled)
+}
+
+func WithATRStrictOutputCheck(enabled bool) volatility.ATROption {
+	return volatility.WithStrictOutputCheck(enabled)
+}
+
+func WithATREarlyValues(enabled bool) volatility.ATROption {
+	return volatility.WithEarlyValues(enabled)
+}
+
 func NewAverageTrueRange() (*volatility.AverageTrueRange, error) {
 	return volatility.NewAverageTrueRange()
 }
@@ -206,6 +761,29 @@ func NewBollingerBands() (*volatility.BollingerBands, error) {
 	return volatility.NewBollingerBands()
 }
 
-func NewBollingerBandsWithParams(period int, multiplier float64) (*volatility.BollingerBands, error) {
-	return volatility.NewBollingerBandsWithParams(period, multiplier)
+func NewBollingerBandsWithParams(period int, multiplier float64, opts ...volatility.BollingerOption) (*volatility.BollingerBands, error) {
+	return volatility.NewBollingerBandsWithParams(period, multiplier, opts...)
+}
+
+func WithBollingerAdaptiveMultiplier(targetOutsideFrac float64) volatility.BollingerOption {
+	return volatility.WithAdaptiveMultiplier(targetOutsideFrac)
+}
+
+// ---- Statistical estimators ----
+type DominantCycle = stats.DominantCycle
+
+func NewDominantCycle() (*stats.DominantCycle, error) {
+	return stats.NewDominantCycle()
+}
+
+func NewDominantCycleWithParams(window, minLag, maxLag int) (*stats.DominantCycle, error) {
+	return stats.NewDominantCycleWithParams(window, minLag, maxLag)
+}
+
+type RollingSharpe = stats.RollingSharpe
+
+var ErrZeroVolatility = stats.ErrZeroVolatility
+
+func NewRollingSharpeWithParams(period int, riskFree float64) (*stats.RollingSharpe, error) {
+	return stats.NewRollingSharpeWithParams(period, riskFree)
 }
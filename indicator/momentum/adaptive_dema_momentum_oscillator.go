@@ -32,6 +32,39 @@ func EMASmoothingFactor(N int) float64 {
 	return 2.0 / float64(N+1)
 }
 
+// NormMode selects how the ADMO score is scaled before it is stored.
+type NormMode int
+
+const (
+	// NormZScore keeps the classic output: the DEMA's deviation from its mean
+	// in units of its own standard deviation, adaptively weighted.
+	NormZScore NormMode = iota
+	// NormATR measures the DEMA's deviation from its mean in units of the
+	// average true range over `length` bars instead of the DEMA's standard
+	// deviation, with the same adaptive weighting. A reading of 1 means the
+	// DEMA sits one ATR above its mean on any instrument.
+	NormATR
+	// NormPercentile reports the percentile rank (0–100) of the z-score among
+	// the last admoPercentileWindow z-scores, shifted by −50 so the median
+	// stays on the zero line: +40 is the 90th percentile.
+	NormPercentile
+)
+
+// String returns the mode name used by Describe.
+func (m NormMode) String() string {
+	switch m {
+	case NormATR:
+		return "atr"
+	case NormPercentile:
+		return "percentile"
+	default:
+		return "zscore"
+	}
+}
+
+// admoPercentileWindow is the z-score history NormPercentile ranks against.
+const admoPercentileWindow = 100
+
 // -----------------------------------------------------------------------------
 // Custom error values (error‑handling ergonomics)
 // -----------------------------------------------------------------------------
@@ -89,6 +122,11 @@ type AdaptiveDEMAMomentumOscillator struct {
 
 	demaWindow  []float64
 	stdevWindow []float64
+
+	extremes core.Extremes // output range since the last reset (see ObservedMin)
+
+	norm         NormMode  // output scaling (see SetNormalization)
+	scoreHistory []float64 // recent z-scores ranked by NormPercentile
 }
 
 // NewAdaptiveDEMAMomentumOscillator creates an oscillator with the default
@@ -194,6 +232,7 @@ func (admo *AdaptiveDEMAMomentumOscillator) Add(high, low, close float64) error
 		}
 		admo.amdoValues = append(admo.amdoValues, amdoValue)
 		admo.lastValue = amdoValue
+		admo.extremes.Observe(amdoValue)
 	}
 	return nil
 }
@@ -275,10 +314,78 @@ func (admo *AdaptiveDEMAMomentumOscillator) calculateADMO() (float64, error) {
 	}
 
 	// Final ADMO score.
-	finalScore := zScore * (1 + normalizedStdev*admo.stdWeight)
+	weight := 1 + normalizedStdev*admo.stdWeight
+	finalScore := zScore * weight
+	switch admo.norm {
+	case NormATR:
+		finalScore = 0
+		if atr := admo.averageTrueRange(); atr != 0 {
+			finalScore = (admo.demaWindow[len(admo.demaWindow)-1] - meanDema) / atr * weight
+		}
+	case NormPercentile:
+		admo.scoreHistory = core.KeepLast(append(admo.scoreHistory, finalScore), admoPercentileWindow)
+		finalScore = percentileRank(admo.scoreHistory, finalScore) - 50
+	}
 	return finalScore, nil
 }
 
+// averageTrueRange returns the mean true range of the last `length` bars; the
+// oldest retained bar, lacking a previous close, contributes its high-low
+// range. The caller must hold the lock.
+func (admo *AdaptiveDEMAMomentumOscillator) averageTrueRange() float64 {
+	n := len(admo.closes)
+	start := n - admo.length
+	sum := 0.0
+	for i := start; i < n; i++ {
+		tr := admo.highs[i] - admo.lows[i]
+		if i > 0 {
+			pc := admo.closes[i-1]
+			tr = math.Max(tr, math.Max(math.Abs(admo.highs[i]-pc), math.Abs(admo.lows[i]-pc)))
+		}
+		sum += tr
+	}
+	return sum / float64(admo.length)
+}
+
+// percentileRank returns the share of values below v, counting ties as half,
+// on a 0–100 scale.
+func percentileRank(values []float64, v float64) float64 {
+	below := 0.0
+	for _, x := range values {
+		switch {
+		case x < v:
+			below++
+		case x == v:
+			below += 0.5
+		}
+	}
+	return below / float64(len(values)) * 100
+}
+
+// SetNormalization selects the output scaling: NormZScore (the default),
+// NormATR or NormPercentile. ATR normalisation makes readings comparable
+// across instruments of different volatility; the percentile mode maps them
+// onto a fixed ±50 range. The oscillator is reset because values produced
+// under different modes are not comparable; the config thresholds used by
+// the overbought/oversold checks are not rescaled.
+func (admo *AdaptiveDEMAMomentumOscillator) SetNormalization(mode NormMode) error {
+	if mode != NormZScore && mode != NormATR && mode != NormPercentile {
+		return fmt.Errorf("ADMO: unknown normalization mode %d", mode)
+	}
+	admo.Lock()
+	admo.norm = mode
+	admo.Unlock()
+	admo.Reset()
+	return nil
+}
+
+// Normalization returns the output scaling mode.
+func (admo *AdaptiveDEMAMomentumOscillator) Normalization() NormMode {
+	admo.RLock()
+	defer admo.RUnlock()
+	return admo.norm
+}
+
 // Calculate returns the most recent ADMO value (or an error if none exist yet).
 func (admo *AdaptiveDEMAMomentumOscillator) Calculate() (float64, error) {
 	admo.RLock()
@@ -471,11 +578,29 @@ func (admo *AdaptiveDEMAMomentumOscillator) Reset() {
 	admo.amdoValues = admo.amdoValues[:0]
 	admo.demaWindow = admo.demaWindow[:0]
 	admo.stdevWindow = admo.stdevWindow[:0]
+	admo.scoreHistory = admo.scoreHistory[:0]
 
 	// Re‑initialize the EMA helpers with the current α.
 	admo.ema1 = DEMA{alpha: admo.ema1.alpha}
 	admo.ema2 = DEMA{alpha: admo.ema2.alpha}
 	admo.lastValue = 0
+	admo.extremes.Reset()
+}
+
+// ObservedMin returns the lowest ADMO value produced since construction or the
+// last Reset, or 0 before the first value.
+func (admo *AdaptiveDEMAMomentumOscillator) ObservedMin() float64 {
+	admo.RLock()
+	defer admo.RUnlock()
+	return admo.extremes.Min()
+}
+
+// ObservedMax returns the highest ADMO value produced since construction or
+// the last Reset, or 0 before the first value.
+func (admo *AdaptiveDEMAMomentumOscillator) ObservedMax() float64 {
+	admo.RLock()
+	defer admo.RUnlock()
+	return admo.extremes.Max()
 }
 
 // SetParameters updates the core look‑back lengths and the weighting factor.
@@ -551,6 +676,17 @@ func (admo *AdaptiveDEMAMomentumOscillator) GetPlotData(startTime, interval int6
 	}
 }
 
+// GetPlotDataRange returns the ADMO plot data for bars [from, to).
+func (admo *AdaptiveDEMAMomentumOscillator) GetPlotDataRange(startTime, interval int64, from, to int) []core.PlotData {
+	return core.SlicePlotData(admo.GetPlotData(startTime, interval), from, to)
+}
+
+// GetPlotDataV2 returns the ADMO line with its signals as typed markers
+// (crossovers and overbought/oversold) instead of the ±1/±2 scatter.
+func (admo *AdaptiveDEMAMomentumOscillator) GetPlotDataV2(startTime, interval int64) core.PlotDataWithMarkers {
+	return core.ExtractMarkers(admo.GetPlotData(startTime, interval), core.ZoneMarkerKinds)
+}
+
 // GetHighs returns a copy of the stored high prices.
 func (admo *AdaptiveDEMAMomentumOscillator) GetHighs() []float64 {
 	admo.RLock()
@@ -578,3 +714,21 @@ func (admo *AdaptiveDEMAMomentumOscillator) GetAMDOValues() []float64 {
 	defer admo.RUnlock()
 	return core.CopySlice(admo.amdoValues)
 }
+
+// Describe reports the ADMO's live parameters and z-score thresholds.
+func (admo *AdaptiveDEMAMomentumOscillator) Describe() core.IndicatorInfo {
+	admo.RLock()
+	defer admo.RUnlock()
+	return core.IndicatorInfo{
+		Name: "ADMO",
+		Params: map[string]any{
+			"length":        admo.length,
+			"stdevLength":   admo.stdevLength,
+			"stdWeight":     admo.stdWeight,
+			"overbought":    admo.config.AMDOOverbought,
+			"oversold":      admo.config.AMDOOversold,
+			"normalization": admo.norm.String(),
+		},
+		SamplesNeeded: max(admo.length, admo.stdevLength),
+	}
+}
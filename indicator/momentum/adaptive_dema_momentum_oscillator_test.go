@@ -294,3 +294,61 @@ func TestADMO_SetParametersRecompute(t *testing.T) {
 		t.Fatalf("expected a noticeable change after re‑parameterising (old=%v,new=%v)", oldVal, newVal)
 	}
 }
+
+func TestADMO_SetNormalizationATR(t *testing.T) {
+	// The same swings on a calm 100-priced series and a 10x more volatile
+	// 1000-priced one.
+	calm, _ := NewAdaptiveDEMAMomentumOscillator()
+	wild, _ := NewAdaptiveDEMAMomentumOscillator()
+	for _, osc := range []*AdaptiveDEMAMomentumOscillator{calm, wild} {
+		if err := osc.SetNormalization(NormATR); err != nil {
+			t.Fatalf("SetNormalization failed: %v", err)
+		}
+	}
+	if err := calm.SetNormalization(NormMode(7)); err == nil {
+		t.Fatal("expected error for unknown mode")
+	}
+	for i := range 80 {
+		c := 100 + 3*math.Sin(float64(i)/5) + 0.3*math.Sin(float64(i)*1.7)
+		h, l := c+0.4, c-0.5
+		_ = calm.Add(h, l, c)
+		_ = wild.Add(1000+10*(h-100), 1000+10*(l-100), 1000+10*(c-100))
+	}
+	a, b := calm.GetAMDOValues(), wild.GetAMDOValues()
+	if len(a) == 0 || len(a) != len(b) {
+		t.Fatalf("expected matching non-empty outputs, got %d and %d", len(a), len(b))
+	}
+	peak := 0.0
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > 1e-6*math.Max(1, math.Abs(a[i])) {
+			t.Fatalf("value %d: ATR-normalized outputs differ: %v vs %v", i, a[i], b[i])
+		}
+		peak = math.Max(peak, math.Abs(a[i]))
+	}
+	if peak == 0 || peak > 20 {
+		t.Fatalf("expected readings of a few ATRs, peak %v", peak)
+	}
+}
+
+func TestADMO_SetNormalizationPercentile(t *testing.T) {
+	osc, _ := NewAdaptiveDEMAMomentumOscillator()
+	if err := osc.SetNormalization(NormPercentile); err != nil {
+		t.Fatalf("SetNormalization failed: %v", err)
+	}
+	highs, lows, closes := genOHLC(150)
+	for i := range highs {
+		_ = osc.Add(highs[i], lows[i], closes[i])
+	}
+	vals := osc.GetAMDOValues()
+	if len(vals) == 0 {
+		t.Fatal("expected values")
+	}
+	for i, v := range vals {
+		if v < -50 || v > 50 {
+			t.Fatalf("value %d outside [-50, 50]: %v", i, v)
+		}
+	}
+	if osc.Describe().Params["normalization"] != "percentile" {
+		t.Fatalf("unexpected Describe params %v", osc.Describe().Params)
+	}
+}
@@ -0,0 +1,166 @@
+package momentum
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/evdnx/goti/indicator/core"
+)
+
+const (
+	DefaultAdaptiveRSIMinPeriod        = 6
+	DefaultAdaptiveRSIMaxPeriod        = 24
+	DefaultAdaptiveRSIVolatilityPeriod = 14
+)
+
+// adaptiveRSIMaxValues bounds the retained AdaptiveRSI history.
+const adaptiveRSIMaxValues = 256
+
+// AdaptiveRSI is an RSI whose period follows volatility: each bar the
+// standard deviation of log returns over volatilityPeriod bars is mapped onto
+// [minPeriod, maxPeriod] with the ATSO's linear mapping (see
+// core.VolatilityToPeriod), inverted so that high volatility gives a short
+// period and calm markets a long one. Gains and losses are smoothed with
+// Wilder's recursion using the current bar's period.
+//
+// Because the smoothing constant changes from bar to bar, the output is
+// non-stationary: the same RSI reading means different things in different
+// regimes, and values are not comparable with a fixed-period RSI or with an
+// AdaptiveRSI over a different history.
+type AdaptiveRSI struct {
+	minPeriod        int
+	maxPeriod        int
+	volatilityPeriod int
+	volSensitivity   float64
+
+	closes  []float64
+	period  int // effective period of the latest value
+	avgGain float64
+	avgLoss float64
+	warm    bool
+
+	values    []float64
+	lastValue float64
+}
+
+// NewAdaptiveRSI builds an AdaptiveRSI with a 6–24 bar period driven by
+// 14-bar volatility.
+func NewAdaptiveRSI() (*AdaptiveRSI, error) {
+	return NewAdaptiveRSIWithParams(DefaultAdaptiveRSIMinPeriod, DefaultAdaptiveRSIMaxPeriod, DefaultAdaptiveRSIVolatilityPeriod)
+}
+
+// NewAdaptiveRSIWithParams builds an AdaptiveRSI with custom period bounds and
+// volatility window. minPeriod must be at least 2.
+func NewAdaptiveRSIWithParams(minPeriod, maxPeriod, volatilityPeriod int) (*AdaptiveRSI, error) {
+	if minPeriod < 2 || maxPeriod < minPeriod || volatilityPeriod < 1 {
+		return nil, errors.New("invalid period configuration")
+	}
+	return &AdaptiveRSI{
+		minPeriod:        minPeriod,
+		maxPeriod:        maxPeriod,
+		volatilityPeriod: volatilityPeriod,
+		volSensitivity:   2.0,
+		closes:           make([]float64, 0, max(maxPeriod, volatilityPeriod)+1),
+	}, nil
+}
+
+// SetVolatilitySensitivity rescales the volatility mapping like the ATSO's
+// setting of the same name: larger values need more volatility before the
+// period shrinks.
+func (a *AdaptiveRSI) SetVolatilitySensitivity(sens float64) error {
+	if sens <= 0 {
+		return fmt.Errorf("volatility sensitivity must be > 0")
+	}
+	a.volSensitivity = sens
+	return nil
+}
+
+// Add ingests a closing price, which must be positive. The first value
+// appears once max(maxPeriod, volatilityPeriod)+1 closes exist, seeded with
+// the simple average gain and loss over the effective period at that bar.
+func (a *AdaptiveRSI) Add(close float64) error {
+	if !core.IsValidPrice(close) {
+		return errors.New("invalid price: close must be positive")
+	}
+	window := max(a.maxPeriod, a.volatilityPeriod) + 1
+	a.closes = core.KeepLast(append(a.closes, close), window)
+	if len(a.closes) < window {
+		return nil
+	}
+
+	vol, err := core.LogReturnVolatility(a.closes, a.volatilityPeriod)
+	if err != nil {
+		return err
+	}
+	a.period = a.minPeriod + a.maxPeriod - core.VolatilityToPeriod(vol, a.volSensitivity, a.minPeriod, a.maxPeriod)
+
+	n := len(a.closes)
+	if !a.warm {
+		var gainSum, lossSum float64
+		for i := n - a.period; i < n; i++ {
+			gain, loss := core.GainLoss(a.closes[i] - a.closes[i-1])
+			gainSum += gain
+			lossSum += loss
+		}
+		a.avgGain = gainSum / float64(a.period)
+		a.avgLoss = lossSum / float64(a.period)
+		a.warm = true
+	} else {
+		gain, loss := core.GainLoss(a.closes[n-1] - a.closes[n-2])
+		p := float64(a.period)
+		a.avgGain = (a.avgGain*(p-1) + gain) / p
+		a.avgLoss = (a.avgLoss*(p-1) + loss) / p
+	}
+
+	a.lastValue = core.RSIFromAverages(a.avgGain, a.avgLoss)
+	a.values = core.KeepLast(append(a.values, a.lastValue), adaptiveRSIMaxValues)
+	return nil
+}
+
+// Calculate returns the latest AdaptiveRSI value.
+func (a *AdaptiveRSI) Calculate() (float64, error) {
+	if len(a.values) == 0 {
+		return 0, errors.New("no AdaptiveRSI data")
+	}
+	return a.lastValue, nil
+}
+
+// CurrentPeriod returns the effective period used for the latest value, or 0
+// before the first value.
+func (a *AdaptiveRSI) CurrentPeriod() int { return a.period }
+
+// Reset clears all state while preserving the configuration.
+func (a *AdaptiveRSI) Reset() {
+	a.closes = a.closes[:0]
+	a.period = 0
+	a.avgGain, a.avgLoss = 0, 0
+	a.warm = false
+	a.values = a.values[:0]
+	a.lastValue = 0
+}
+
+// GetValues returns a defensive copy of the AdaptiveRSI series.
+func (a *AdaptiveRSI) GetValues() []float64 { return core.CopySlice(a.values) }
+
+// GetPlotData returns the AdaptiveRSI series as a single line.
+func (a *AdaptiveRSI) GetPlotData(startTime, interval int64) []core.PlotData {
+	if len(a.values) == 0 {
+		return nil
+	}
+	x := make([]float64, len(a.values))
+	for i := range x {
+		x[i] = float64(i)
+	}
+	return []core.PlotData{{
+		Name:      "Adaptive RSI",
+		X:         x,
+		Y:         core.CopySlice(a.values),
+		Type:      "line",
+		Timestamp: core.GenerateTimestamps(startTime, len(a.values), interval),
+	}}
+}
+
+// GetPlotDataRange returns the adaptive RSI plot data for bars [from, to).
+func (a *AdaptiveRSI) GetPlotDataRange(startTime, interval int64, from, to int) []core.PlotData {
+	return core.SlicePlotData(a.GetPlotData(startTime, interval), from, to)
+}
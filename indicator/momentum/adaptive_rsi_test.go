@@ -0,0 +1,52 @@
+package momentum
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAdaptiveRSI_PeriodShrinksWhenVolatile(t *testing.T) {
+	a, err := NewAdaptiveRSIWithParams(4, 20, 10)
+	if err != nil {
+		t.Fatalf("constructor failed: %v", err)
+	}
+	if _, err := a.Calculate(); err == nil {
+		t.Fatal("expected an error before any data")
+	}
+
+	// A calm drift with ±0.1% wiggles.
+	price := 100.0
+	for i := 0; i < 40; i++ {
+		price *= 1 + 0.001*math.Sin(float64(i))
+		if err := a.Add(price); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	calm := a.CurrentPeriod()
+	if calm < 18 {
+		t.Fatalf("expected a long period in a calm market, got %d", calm)
+	}
+
+	// A volatile stretch with ±5% swings.
+	for i := 0; i < 15; i++ {
+		price *= 1 + 0.05*math.Sin(float64(i)*2)
+		if err := a.Add(price); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+		v, err := a.Calculate()
+		if err != nil || v < 0 || v > 100 {
+			t.Fatalf("bar %d: RSI %v out of range (%v)", i, v, err)
+		}
+	}
+	if volatile := a.CurrentPeriod(); volatile > calm-4 {
+		t.Fatalf("expected the period to shrink from %d in the volatile stretch, got %d", calm, volatile)
+	}
+
+	a.Reset()
+	if a.CurrentPeriod() != 0 || len(a.GetValues()) != 0 {
+		t.Fatal("Reset should clear the period and values")
+	}
+	if _, err := NewAdaptiveRSIWithParams(1, 10, 5); err == nil {
+		t.Fatal("expected an error for minPeriod below 2")
+	}
+}
@@ -0,0 +1,293 @@
+package momentum
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/evdnx/goti/indicator/core"
+)
+
+const (
+	DefaultAOFastPeriod = 5
+	DefaultAOSlowPeriod = 34
+	DefaultACPeriod     = 5
+)
+
+// aoMaxValues bounds the retained AO and AC history.
+const aoMaxValues = 256
+
+// Histogram colours carried in the PlotData Signal field of the AO and AC
+// bar series.
+const (
+	HistogramUp   = "up"
+	HistogramDown = "down"
+)
+
+// AwesomeOscillator implements Bill Williams' Awesome Oscillator:
+// SMA(5) of the median price (H+L)/2 minus SMA(34) of the median price.
+type AwesomeOscillator struct {
+	fastPeriod int
+	slowPeriod int
+	fast       *core.MovingAverage
+	slow       *core.MovingAverage
+
+	values    []float64
+	lastValue float64
+}
+
+// NewAwesomeOscillator builds an AO with the classic 5/34 periods.
+func NewAwesomeOscillator() (*AwesomeOscillator, error) {
+	return NewAwesomeOscillatorWithParams(DefaultAOFastPeriod, DefaultAOSlowPeriod)
+}
+
+// NewAwesomeOscillatorWithParams builds an AO with custom fast and slow SMA
+// periods; fast must be shorter than slow.
+func NewAwesomeOscillatorWithParams(fastPeriod, slowPeriod int) (*AwesomeOscillator, error) {
+	if fastPeriod < 1 || slowPeriod < 1 {
+		return nil, errors.New("periods must be at least 1")
+	}
+	if fastPeriod >= slowPeriod {
+		return nil, errors.New("fast period must be less than slow period")
+	}
+	fast, err := core.NewMovingAverage(core.SMAMovingAverage, fastPeriod)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fast SMA: %w", err)
+	}
+	slow, err := core.NewMovingAverage(core.SMAMovingAverage, slowPeriod)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create slow SMA: %w", err)
+	}
+	return &AwesomeOscillator{
+		fastPeriod: fastPeriod,
+		slowPeriod: slowPeriod,
+		fast:       fast,
+		slow:       slow,
+		values:     make([]float64, 0, slowPeriod),
+	}, nil
+}
+
+// Add ingests a bar's high and low. Values are produced once the slow SMA is
+// filled.
+func (ao *AwesomeOscillator) Add(high, low float64) error {
+	if high < low || !core.IsNonNegativePrice(low) {
+		return errors.New("invalid price data")
+	}
+	median := (high + low) / 2
+	if err := ao.fast.Add(median); err != nil {
+		return err
+	}
+	if err := ao.slow.Add(median); err != nil {
+		return err
+	}
+	slow, err := ao.slow.Calculate()
+	if err != nil {
+		return nil // slow SMA still warming up
+	}
+	fast, err := ao.fast.Calculate()
+	if err != nil {
+		return err
+	}
+	ao.lastValue = fast - slow
+	ao.values = core.KeepLast(append(ao.values, ao.lastValue), aoMaxValues)
+	return nil
+}
+
+// Calculate returns the latest AO value.
+func (ao *AwesomeOscillator) Calculate() (float64, error) {
+	if len(ao.values) == 0 {
+		return 0, errors.New("no AO data")
+	}
+	return ao.lastValue, nil
+}
+
+// IsZeroCross reports a zero-line cross on the latest bar: 1 when the AO
+// crossed above zero, -1 when it crossed below, 0 otherwise.
+func (ao *AwesomeOscillator) IsZeroCross() (int, error) {
+	return zeroCross(ao.values)
+}
+
+// IsSaucer reports Bill Williams' saucer on the latest three bars: 1 for a
+// bullish saucer (AO above zero, a lower bar followed by a higher one), -1
+// for a bearish saucer (AO below zero, a higher bar followed by a lower one),
+// 0 otherwise.
+func (ao *AwesomeOscillator) IsSaucer() (int, error) {
+	n := len(ao.values)
+	if n < 3 {
+		return 0, errors.New("insufficient data for saucer")
+	}
+	a, b, c := ao.values[n-3], ao.values[n-2], ao.values[n-1]
+	switch {
+	case a > 0 && b > 0 && c > 0 && b < a && c > b:
+		return 1, nil
+	case a < 0 && b < 0 && c < 0 && b > a && c < b:
+		return -1, nil
+	}
+	return 0, nil
+}
+
+// Reset clears all state while preserving the periods.
+func (ao *AwesomeOscillator) Reset() {
+	ao.fast.Reset()
+	ao.slow.Reset()
+	ao.values = ao.values[:0]
+	ao.lastValue = 0
+}
+
+// GetValues returns a defensive copy of the AO series.
+func (ao *AwesomeOscillator) GetValues() []float64 { return core.CopySlice(ao.values) }
+
+// GetPlotData emits the AO as two histogram series, "AO Up" and "AO Down",
+// whose Signal field is HistogramUp or HistogramDown (see histogramPlotData).
+func (ao *AwesomeOscillator) GetPlotData(startTime, interval int64) []core.PlotData {
+	return histogramPlotData("AO", ao.values, startTime, interval)
+}
+
+// GetPlotDataRange returns the Awesome Oscillator plot data for bars [from, to).
+func (ao *AwesomeOscillator) GetPlotDataRange(startTime, interval int64, from, to int) []core.PlotData {
+	return core.SlicePlotData(ao.GetPlotData(startTime, interval), from, to)
+}
+
+// AcceleratorOscillator implements Bill Williams' Accelerator Oscillator:
+// AO minus SMA(5) of AO, which tracks the acceleration of the AO's momentum.
+type AcceleratorOscillator struct {
+	ao     *AwesomeOscillator
+	period int
+	sma    *core.MovingAverage
+
+	values    []float64
+	lastValue float64
+}
+
+// NewAcceleratorOscillator builds an AC on a 5/34 AO with a 5-bar SMA.
+func NewAcceleratorOscillator() (*AcceleratorOscillator, error) {
+	return NewAcceleratorOscillatorWithParams(DefaultAOFastPeriod, DefaultAOSlowPeriod, DefaultACPeriod)
+}
+
+// NewAcceleratorOscillatorWithParams builds an AC with custom AO periods and
+// a custom SMA period for the AO.
+func NewAcceleratorOscillatorWithParams(fastPeriod, slowPeriod, period int) (*AcceleratorOscillator, error) {
+	ao, err := NewAwesomeOscillatorWithParams(fastPeriod, slowPeriod)
+	if err != nil {
+		return nil, err
+	}
+	if period < 1 {
+		return nil, errors.New("period must be at least 1")
+	}
+	sma, err := core.NewMovingAverage(core.SMAMovingAverage, period)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SMA: %w", err)
+	}
+	return &AcceleratorOscillator{
+		ao:     ao,
+		period: period,
+		sma:    sma,
+		values: make([]float64, 0, period),
+	}, nil
+}
+
+// Add ingests a bar's high and low. Values are produced once the SMA of the
+// AO is filled, i.e. after slowPeriod+period-1 bars.
+func (ac *AcceleratorOscillator) Add(high, low float64) error {
+	if err := ac.ao.Add(high, low); err != nil {
+		return err
+	}
+	ao, err := ac.ao.Calculate()
+	if err != nil {
+		return nil // AO still warming up
+	}
+	if err := ac.sma.AddValue(ao); err != nil {
+		return err
+	}
+	avg, err := ac.sma.Calculate()
+	if err != nil {
+		return nil // SMA of the AO still warming up
+	}
+	ac.lastValue = ao - avg
+	ac.values = core.KeepLast(append(ac.values, ac.lastValue), aoMaxValues)
+	return nil
+}
+
+// Calculate returns the latest AC value.
+func (ac *AcceleratorOscillator) Calculate() (float64, error) {
+	if len(ac.values) == 0 {
+		return 0, errors.New("no AC data")
+	}
+	return ac.lastValue, nil
+}
+
+// IsZeroCross reports a zero-line cross on the latest bar: 1 when the AC
+// crossed above zero, -1 when it crossed below, 0 otherwise.
+func (ac *AcceleratorOscillator) IsZeroCross() (int, error) {
+	return zeroCross(ac.values)
+}
+
+// AwesomeOscillator returns the AO the AC is derived from.
+func (ac *AcceleratorOscillator) AwesomeOscillator() *AwesomeOscillator { return ac.ao }
+
+// Reset clears all state while preserving the periods.
+func (ac *AcceleratorOscillator) Reset() {
+	ac.ao.Reset()
+	ac.sma.Reset()
+	ac.values = ac.values[:0]
+	ac.lastValue = 0
+}
+
+// GetValues returns a defensive copy of the AC series.
+func (ac *AcceleratorOscillator) GetValues() []float64 { return core.CopySlice(ac.values) }
+
+// GetPlotData emits the AC as two histogram series, "AC Up" and "AC Down",
+// whose Signal field is HistogramUp or HistogramDown (see histogramPlotData).
+func (ac *AcceleratorOscillator) GetPlotData(startTime, interval int64) []core.PlotData {
+	return histogramPlotData("AC", ac.values, startTime, interval)
+}
+
+// GetPlotDataRange returns the Accelerator Oscillator plot data for bars [from, to).
+func (ac *AcceleratorOscillator) GetPlotDataRange(startTime, interval int64, from, to int) []core.PlotData {
+	return core.SlicePlotData(ac.GetPlotData(startTime, interval), from, to)
+}
+
+// zeroCross compares the last two values against zero.
+func zeroCross(values []float64) (int, error) {
+	n := len(values)
+	if n < 2 {
+		return 0, errors.New("insufficient data for zero cross")
+	}
+	prev, cur := values[n-2], values[n-1]
+	switch {
+	case prev <= 0 && cur > 0:
+		return 1, nil
+	case prev >= 0 && cur < 0:
+		return -1, nil
+	}
+	return 0, nil
+}
+
+// histogramPlotData splits values into Bill Williams' two-colour histogram: a
+// bar is "up" (green) when it is above the previous bar, "down" (red) when it
+// is below, and keeps the previous colour when equal; the first bar is
+// compared with zero. Each colour is its own bar
+// series, tagged through Signal, with X holding the bar index so the two
+// series interleave on one axis.
+func histogramPlotData(name string, values []float64, startTime, interval int64) []core.PlotData {
+	if len(values) == 0 {
+		return nil
+	}
+	ts := core.GenerateTimestamps(startTime, len(values), interval)
+	up := core.PlotData{Name: name + " Up", Type: "bar", Signal: HistogramUp}
+	down := core.PlotData{Name: name + " Down", Type: "bar", Signal: HistogramDown}
+	prev, rising := 0.0, false
+	for i, v := range values {
+		if v != prev {
+			rising = v > prev
+		}
+		series := &down
+		if rising {
+			series = &up
+		}
+		series.X = append(series.X, float64(i))
+		series.Y = append(series.Y, v)
+		series.Timestamp = append(series.Timestamp, ts[i])
+		prev = v
+	}
+	return []core.PlotData{up, down}
+}
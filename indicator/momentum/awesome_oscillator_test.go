@@ -0,0 +1,133 @@
+package momentum
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAwesomeOscillator_InvalidParams(t *testing.T) {
+	if _, err := NewAwesomeOscillatorWithParams(34, 5); err == nil {
+		t.Fatal("expected error for fast >= slow")
+	}
+	if _, err := NewAcceleratorOscillatorWithParams(5, 34, 0); err == nil {
+		t.Fatal("expected error for AC period 0")
+	}
+}
+
+func TestAwesomeOscillator_TurnsPositive(t *testing.T) {
+	ao, _ := NewAwesomeOscillatorWithParams(3, 8)
+	ac, _ := NewAcceleratorOscillatorWithParams(3, 8, 3)
+	if _, err := ao.Calculate(); err == nil {
+		t.Fatal("expected error before the slow SMA is filled")
+	}
+
+	// A decline keeps the fast SMA below the slow one; the rally then lifts it
+	// above, and the AO must cross zero on the way.
+	price, crossed := 120.0, false
+	feed := func(step float64, bars int) {
+		for i := 0; i < bars; i++ {
+			price += step
+			if err := ao.Add(price+1, price-1); err != nil {
+				t.Fatalf("Add failed: %v", err)
+			}
+			if err := ac.Add(price+1, price-1); err != nil {
+				t.Fatalf("AC Add failed: %v", err)
+			}
+			if c, err := ao.IsZeroCross(); err == nil && c == 1 {
+				crossed = true
+			}
+		}
+	}
+	feed(-1, 15)
+	if v, _ := ao.Calculate(); v >= 0 {
+		t.Fatalf("expected negative AO in a decline, got %.4f", v)
+	}
+	feed(1, 10)
+	v, err := ao.Calculate()
+	if err != nil || v <= 0 {
+		t.Fatalf("expected positive AO after the rally, got %.4f (%v)", v, err)
+	}
+	if !crossed {
+		t.Fatal("expected a bullish zero cross")
+	}
+	if got, _ := ac.AwesomeOscillator().Calculate(); got != v {
+		t.Fatalf("AC's AO %.4f differs from standalone AO %.4f", got, v)
+	}
+	if _, err := ac.Calculate(); err != nil {
+		t.Fatalf("AC Calculate failed: %v", err)
+	}
+
+	plots := ao.GetPlotData(0, 60)
+	if len(plots) != 2 || plots[0].Signal != HistogramUp || plots[1].Signal != HistogramDown {
+		t.Fatalf("unexpected plot series: %+v", plots)
+	}
+	if n := len(plots[0].Y) + len(plots[1].Y); n != len(ao.GetValues()) {
+		t.Fatalf("histogram has %d bars, want %d", n, len(ao.GetValues()))
+	}
+	// Rising bars are coloured up.
+	if last := plots[0].X[len(plots[0].X)-1]; int(last) != len(ao.GetValues())-1 {
+		t.Fatalf("expected the last rising bar to be up, got last up index %v", last)
+	}
+}
+
+func TestAwesomeOscillator_Saucer(t *testing.T) {
+	// AO(2,3) of each median path, with high/low one point either side.
+	saucer := func(medians ...float64) (int, error) {
+		ao, _ := NewAwesomeOscillatorWithParams(2, 3)
+		for _, m := range medians {
+			if err := ao.Add(m+1, m-1); err != nil {
+				t.Fatalf("Add failed: %v", err)
+			}
+		}
+		return ao.IsSaucer()
+	}
+	if _, err := saucer(10, 12, 14, 14.5); err == nil {
+		t.Fatal("expected error without three values")
+	}
+	// AO 1, 0.75, 11/12: a pause in a rally.
+	if s, _ := saucer(10, 12, 14, 14.5, 19); s != 1 {
+		t.Fatalf("expected bullish saucer, got %d", s)
+	}
+	// AO -1, -0.75, -11/12: the mirrored decline.
+	if s, _ := saucer(30, 28, 26, 25.5, 21); s != -1 {
+		t.Fatalf("expected bearish saucer, got %d", s)
+	}
+	// AO -5/6, -1, 1/6: the dip-and-rise shape, but across zero.
+	if s, _ := saucer(12, 11, 8, 8, 9); s != 0 {
+		t.Fatalf("expected no saucer across zero, got %d", s)
+	}
+}
+
+func TestAcceleratorOscillator_Value(t *testing.T) {
+	ac, _ := NewAcceleratorOscillatorWithParams(3, 8, 5)
+	sma := func(xs []float64) float64 {
+		sum := 0.0
+		for _, x := range xs {
+			sum += x
+		}
+		return sum / float64(len(xs))
+	}
+	var medians, aos []float64
+	for i := 0; i < 40; i++ {
+		m := 100 + 10*math.Sin(float64(i)/5)
+		if err := ac.Add(m+1, m-1); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+		medians = append(medians, m)
+		got, err := ac.Calculate()
+		if len(medians) >= 8 {
+			aos = append(aos, sma(medians[len(medians)-3:])-sma(medians[len(medians)-8:]))
+		}
+		// The first AC needs 8 bars for the AO plus 4 more for its SMA(5).
+		if len(aos) < 5 {
+			if err == nil {
+				t.Fatalf("bar %d: expected AC to be warming up", i)
+			}
+			continue
+		}
+		want := aos[len(aos)-1] - sma(aos[len(aos)-5:])
+		if err != nil || math.Abs(got-want) > 1e-9 {
+			t.Fatalf("bar %d: AC = %v (%v), want AO − SMA5(AO) = %v", i, got, err, want)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package momentum
 
 import (
 	"errors"
+	"fmt"
 	"math"
 
 	"github.com/evdnx/goti/indicator/core"
@@ -16,13 +17,20 @@ const (
 
 // CommodityChannelIndex implements the CCI indicator.
 // It uses typical price [(H+L+C)/3], a simple moving average of typical prices,
-// and the mean deviation around that average.
+// and the mean deviation around that average. SetWeighting switches both
+// averages to linear (WMA) recency weights.
 type CommodityChannelIndex struct {
-	period int
+	period    int
+	weighting core.MovingAverageType // SMA (default) or WMA
 
 	typicalPrices []float64
 	cciValues     []float64
 	lastValue     float64
+	centerLine    []float64 // typical-price average behind each CCI value
+	lastMeanDev   float64
+
+	transform func(float64) float64 // optional output hook (see SetOutputTransform)
+	extremes  core.Extremes         // output range since the last reset (see ObservedMin)
 }
 
 // NewCommodityChannelIndex builds a CCI with the default 20-period window.
@@ -37,6 +45,7 @@ func NewCommodityChannelIndexWithParams(period int) (*CommodityChannelIndex, err
 	}
 	return &CommodityChannelIndex{
 		period:        period,
+		weighting:     core.SMAMovingAverage,
 		typicalPrices: make([]float64, 0, period),
 		cciValues:     make([]float64, 0, period),
 	}, nil
@@ -51,8 +60,12 @@ func (c *CommodityChannelIndex) Add(high, low, close float64) error {
 	c.typicalPrices = append(c.typicalPrices, tp)
 
 	if len(c.typicalPrices) >= c.period {
-		c.lastValue = c.computeCCI()
+		cci, center, meanDev := c.computeCCI()
+		c.lastValue = core.ApplyTransform(c.transform, cci)
 		c.cciValues = append(c.cciValues, c.lastValue)
+		c.centerLine = append(c.centerLine, center)
+		c.lastMeanDev = meanDev
+		c.extremes.Observe(c.lastValue)
 	}
 	c.trimSlices()
 	return nil
@@ -87,6 +100,44 @@ func (c *CommodityChannelIndex) Reset() {
 	c.typicalPrices = c.typicalPrices[:0]
 	c.cciValues = c.cciValues[:0]
 	c.lastValue = 0
+	c.centerLine = c.centerLine[:0]
+	c.lastMeanDev = 0
+	c.extremes.Reset()
+}
+
+// ObservedMin returns the lowest CCI value produced since construction or
+// the last Reset, or 0 before the first value.
+func (c *CommodityChannelIndex) ObservedMin() float64 { return c.extremes.Min() }
+
+// ObservedMax returns the highest CCI value produced since construction or
+// the last Reset, or 0 before the first value.
+func (c *CommodityChannelIndex) ObservedMax() float64 { return c.extremes.Max() }
+
+// SetOutputTransform passes every CCI value through fn before it is stored,
+// so Calculate, IsOverbought/IsOversold and GetPlotData agree on the
+// transformed series. nil restores the identity; the CCI is reset.
+func (c *CommodityChannelIndex) SetOutputTransform(fn func(float64) float64) {
+	c.transform = fn
+	c.Reset()
+}
+
+// Validate scans the retained typical prices, CCI values and center line for
+// NaN or ±Inf and reports the first one found with its index in the retained
+// slice, then checks the latest value and mean deviation.
+func (c *CommodityChannelIndex) Validate() error {
+	if err := core.ValidateFinite("typicalPrices", c.typicalPrices); err != nil {
+		return err
+	}
+	if err := core.ValidateFinite("cciValues", c.cciValues); err != nil {
+		return err
+	}
+	if err := core.ValidateFinite("centerLine", c.centerLine); err != nil {
+		return err
+	}
+	if err := core.CheckFinite("lastValue", c.lastValue); err != nil {
+		return err
+	}
+	return core.CheckFinite("lastMeanDev", c.lastMeanDev)
 }
 
 // SetPeriod updates the lookback window and resets the indicator.
@@ -99,9 +150,44 @@ func (c *CommodityChannelIndex) SetPeriod(period int) error {
 	return nil
 }
 
+// SetWeighting selects how the bars of the window are weighted in both the
+// average of the typical prices and the mean deviation around it. SMA (the
+// default) weighs every bar equally; WMA weighs bar k of the window by k, so
+// the newest bar counts period times as much as the oldest. The CCI then
+// reacts sooner to turns without shortening the period. Other types are
+// rejected; the CCI is reset.
+func (c *CommodityChannelIndex) SetWeighting(maType core.MovingAverageType) error {
+	if maType != core.SMAMovingAverage && maType != core.WMAMovingAverage {
+		return fmt.Errorf("unsupported CCI weighting %q: use SMA or WMA", maType)
+	}
+	c.weighting = maType
+	c.Reset()
+	return nil
+}
+
+// Weighting returns the bar weighting used by the CCI averages.
+func (c *CommodityChannelIndex) Weighting() core.MovingAverageType { return c.weighting }
+
 // GetValues returns the CCI series (defensive copy).
 func (c *CommodityChannelIndex) GetValues() []float64 { return core.CopySlice(c.cciValues) }
 
+// GetCenterLine returns a defensive copy of the CCI's center line: the
+// average typical price of the window behind each CCI value, aligned with
+// GetValues. It is an SMA by default and a WMA after SetWeighting(WMA), and
+// is unaffected by SetOutputTransform.
+func (c *CommodityChannelIndex) GetCenterLine() []float64 { return core.CopySlice(c.centerLine) }
+
+// GetMeanDeviation returns the mean absolute deviation of the typical prices
+// around the center line for the latest window, the CCI's denominator before
+// the 0.015 scaling. Bands at center ± k·meanDev therefore sit where the CCI
+// reads ±k/0.015.
+func (c *CommodityChannelIndex) GetMeanDeviation() (float64, error) {
+	if len(c.cciValues) == 0 {
+		return 0, errors.New("no CCI data")
+	}
+	return c.lastMeanDev, nil
+}
+
 // GetPlotData returns plot data for the CCI line.
 func (c *CommodityChannelIndex) GetPlotData(startTime, interval int64) []core.PlotData {
 	if len(c.cciValues) == 0 {
@@ -121,28 +207,59 @@ func (c *CommodityChannelIndex) GetPlotData(startTime, interval int64) []core.Pl
 	}}
 }
 
-func (c *CommodityChannelIndex) computeCCI() float64 {
+// GetPlotDataRange returns the CCI plot data for bars [from, to).
+func (c *CommodityChannelIndex) GetPlotDataRange(startTime, interval int64, from, to int) []core.PlotData {
+	return core.SlicePlotData(c.GetPlotData(startTime, interval), from, to)
+}
+
+// computeCCI returns the CCI of the latest window together with its center
+// line value and mean deviation.
+func (c *CommodityChannelIndex) computeCCI() (cci, ma, meanDev float64) {
 	start := len(c.typicalPrices) - c.period
 	window := c.typicalPrices[start:]
 
-	sum := 0.0
-	for _, v := range window {
-		sum += v
+	// weight returns the weight of window[i]: 1 for SMA, i+1 for WMA.
+	weight := func(i int) float64 {
+		if c.weighting == core.WMAMovingAverage {
+			return float64(i + 1)
+		}
+		return 1
+	}
+
+	var sum, wsum float64
+	for i, v := range window {
+		sum += weight(i) * v
+		wsum += weight(i)
 	}
-	ma := sum / float64(c.period)
+	ma = sum / wsum
 
 	var devSum float64
-	for _, v := range window {
-		devSum += math.Abs(v - ma)
+	for i, v := range window {
+		devSum += weight(i) * math.Abs(v-ma)
 	}
-	meanDev := devSum / float64(c.period)
+	meanDev = devSum / wsum
 	if meanDev == 0 {
-		return 0
+		return 0, ma, 0
 	}
-	return (window[len(window)-1] - ma) / (cciConstant * meanDev)
+	return (window[len(window)-1] - ma) / (cciConstant * meanDev), ma, meanDev
 }
 
 func (c *CommodityChannelIndex) trimSlices() {
 	c.typicalPrices = core.KeepLast(c.typicalPrices, c.period)
 	c.cciValues = core.KeepLast(c.cciValues, c.period)
+	c.centerLine = core.KeepLast(c.centerLine, c.period)
+}
+
+// Describe reports the CCI period and zone levels.
+func (c *CommodityChannelIndex) Describe() core.IndicatorInfo {
+	return core.IndicatorInfo{
+		Name: "CCI",
+		Params: map[string]any{
+			"period":     c.period,
+			"weighting":  string(c.weighting),
+			"overbought": DefaultCCIOverbought,
+			"oversold":   DefaultCCIOversold,
+		},
+		SamplesNeeded: c.period,
+	}
 }
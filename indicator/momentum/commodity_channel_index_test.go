@@ -3,6 +3,8 @@ package momentum
 import (
 	"math"
 	"testing"
+
+	"github.com/evdnx/goti/indicator/core"
 )
 
 func TestCommodityChannelIndex_Calculation(t *testing.T) {
@@ -68,3 +70,133 @@ func TestCommodityChannelIndex_InvalidPeriod(t *testing.T) {
 		t.Fatal("expected error for period < 1")
 	}
 }
+
+func TestCommodityChannelIndex_WMAWeightingTurnsSooner(t *testing.T) {
+	sma, _ := NewCommodityChannelIndexWithParams(20)
+	wma, _ := NewCommodityChannelIndexWithParams(20)
+	if err := wma.SetWeighting(core.WMAMovingAverage); err != nil {
+		t.Fatalf("SetWeighting failed: %v", err)
+	}
+	if err := sma.SetWeighting(core.EMAMovingAverage); err == nil {
+		t.Fatal("expected EMA weighting to be rejected")
+	}
+	if sma.Weighting() != core.SMAMovingAverage {
+		t.Fatalf("default weighting should stay SMA, got %s", sma.Weighting())
+	}
+
+	// Forty rising bars, then a slower decline: count the falling bars each
+	// CCI needs to drop below zero.
+	firstNegative := func(c *CommodityChannelIndex) int {
+		for i := 0; i < 80; i++ {
+			p := 100 + float64(i)
+			if i >= 40 {
+				p = 140 - 0.5*float64(i-40)
+			}
+			if err := c.Add(p+1, p-1, p); err != nil {
+				t.Fatalf("Add failed: %v", err)
+			}
+			if v, err := c.Calculate(); err == nil && i >= 40 && v < 0 {
+				return i - 40
+			}
+		}
+		t.Fatal("CCI never turned negative")
+		return -1
+	}
+	smaTurn, wmaTurn := firstNegative(sma), firstNegative(wma)
+	if wmaTurn >= smaTurn {
+		t.Fatalf("expected WMA CCI to turn sooner: WMA after %d bars, SMA after %d", wmaTurn, smaTurn)
+	}
+}
+
+func TestCommodityChannelIndex_CenterLineAndMeanDeviation(t *testing.T) {
+	cci, err := NewCommodityChannelIndexWithParams(3)
+	if err != nil {
+		t.Fatalf("constructor error: %v", err)
+	}
+	if _, err := cci.GetMeanDeviation(); err == nil {
+		t.Fatal("expected an error before the first CCI value")
+	}
+
+	// Typical prices 9, 10, 11, 9, 12.
+	bars := [][3]float64{{10, 8, 9}, {11, 9, 10}, {12, 10, 11}, {10, 8, 9}, {13, 11, 12}}
+	for i, b := range bars {
+		if err := cci.Add(b[0], b[1], b[2]); err != nil {
+			t.Fatalf("Add failed at idx %d: %v", i, err)
+		}
+	}
+
+	want := []float64{10, 30.0 / 3, 32.0 / 3}
+	center := cci.GetCenterLine()
+	if len(center) != len(want) {
+		t.Fatalf("center line length = %d, want %d", len(center), len(want))
+	}
+	for i := range want {
+		if math.Abs(center[i]-want[i]) > 1e-9 {
+			t.Fatalf("center[%d] = %v, want SMA %v", i, center[i], want[i])
+		}
+	}
+
+	// Window 11, 9, 12 around 32/3: deviations 1/3, 5/3, 4/3.
+	meanDev, err := cci.GetMeanDeviation()
+	if err != nil {
+		t.Fatalf("GetMeanDeviation error: %v", err)
+	}
+	if math.Abs(meanDev-10.0/9) > 1e-9 {
+		t.Fatalf("mean deviation = %v, want %v", meanDev, 10.0/9)
+	}
+
+	cci.Reset()
+	if len(cci.GetCenterLine()) != 0 {
+		t.Fatal("expected Reset to clear the center line")
+	}
+}
+
+func TestCommodityChannelIndex_SetOutputTransform(t *testing.T) {
+	plain, _ := NewCommodityChannelIndexWithParams(10)
+	neg, _ := NewCommodityChannelIndexWithParams(10)
+	neg.SetOutputTransform(func(v float64) float64 { return -v })
+
+	overbought, oversold := 0, 0
+	for i := 0; i < 80; i++ {
+		p := 100 + 10*math.Sin(float64(i)/4)
+		for _, c := range []*CommodityChannelIndex{plain, neg} {
+			if err := c.Add(p+1, p-1, p); err != nil {
+				t.Fatalf("Add failed: %v", err)
+			}
+		}
+		want, err := plain.Calculate()
+		if err != nil {
+			continue
+		}
+		if got, _ := neg.Calculate(); math.Abs(got+want) > 1e-9 {
+			t.Fatalf("bar %d: transformed CCI = %v, want %v", i, got, -want)
+		}
+		ob, _ := plain.IsOverbought()
+		os, _ := plain.IsOversold()
+		negOB, _ := neg.IsOverbought()
+		negOS, _ := neg.IsOversold()
+		if negOB != os || negOS != ob {
+			t.Fatalf("bar %d: zones not mirrored (plain ob=%v os=%v, negated ob=%v os=%v)", i, ob, os, negOB, negOS)
+		}
+		if ob {
+			overbought++
+		}
+		if os {
+			oversold++
+		}
+	}
+	if overbought == 0 || oversold == 0 {
+		t.Fatalf("expected both zones on the sine path (overbought=%d oversold=%d)", overbought, oversold)
+	}
+
+	want := plain.GetPlotData(0, 60)[0].Y
+	got := neg.GetPlotData(0, 60)[0].Y
+	if len(got) != len(want) {
+		t.Fatalf("plot length %d, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if math.Abs(got[i]+want[i]) > 1e-9 {
+			t.Fatalf("plot[%d] = %v, want %v", i, got[i], -want[i])
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package momentum
+
+import (
+	"math"
+
+	"github.com/evdnx/goti/config"
+	"github.com/evdnx/goti/indicator/core"
+)
+
+// ScanDivergence screens many symbols for RSI divergence in one call. Each
+// symbol's closes are fed through a fresh RSI of rsiPeriod (default
+// configuration) and the closes and RSI are passed to
+// core.PivotDivergence with the given pivot widths. The result maps every
+// symbol with an active divergence to "bullish" or "bearish"; symbols without
+// one, and symbols whose closes the RSI rejects, are omitted. An invalid
+// rsiPeriod yields an empty map.
+func ScanDivergence(data map[string][]float64, rsiPeriod, leftBars, rightBars int) map[string]string {
+	out := make(map[string]string)
+	for symbol, closes := range data {
+		rsi, err := NewRelativeStrengthIndexWithParams(rsiPeriod, config.DefaultConfig())
+		if err != nil {
+			return out
+		}
+		osc, ok := rsiSeries(rsi, closes)
+		if !ok {
+			continue
+		}
+		if dir := core.PivotDivergence(closes, osc, leftBars, rightBars); dir != "" {
+			out[symbol] = dir
+		}
+	}
+	return out
+}
+
+// rsiSeries feeds closes through rsi and returns its value on every bar, NaN
+// during warm-up. ok is false when a close is rejected, since the series
+// would no longer line up with the prices.
+func rsiSeries(rsi *RelativeStrengthIndex, closes []float64) (osc []float64, ok bool) {
+	osc = make([]float64, len(closes))
+	for i, c := range closes {
+		if rsi.Add(c) != nil {
+			return nil, false
+		}
+		osc[i] = math.NaN()
+		if v, err := rsi.Calculate(); err == nil {
+			osc[i] = v
+		}
+	}
+	return osc, true
+}
@@ -0,0 +1,46 @@
+package momentum
+
+import "testing"
+
+func TestScanDivergence(t *testing.T) {
+	// A steep slide to a low, a rebound, then a choppy drift to a slightly
+	// lower low: price makes a lower low while the RSI makes a higher one.
+	var bull []float64
+	p := 100.0
+	for i := 0; i < 10; i++ {
+		bull = append(bull, p)
+		p -= 2
+	}
+	for i := 0; i < 5; i++ {
+		bull = append(bull, p)
+		p++
+	}
+	for i := 0; i < 16; i++ {
+		bull = append(bull, p)
+		if i%2 == 0 {
+			p -= 1.2
+		} else {
+			p += 0.6
+		}
+	}
+	for i := 0; i < 4; i++ {
+		bull = append(bull, p)
+		p += 0.5
+	}
+
+	flat := make([]float64, len(bull))
+	for i := range flat {
+		flat[i] = 100
+	}
+
+	got := ScanDivergence(map[string][]float64{"BULL": bull, "FLAT": flat}, 5, 3, 3)
+	if got["BULL"] != "bullish" {
+		t.Fatalf("expected a bullish divergence on BULL, got %q", got["BULL"])
+	}
+	if _, ok := got["FLAT"]; ok || len(got) != 1 {
+		t.Fatalf("expected only BULL to be reported, got %v", got)
+	}
+	if res := ScanDivergence(map[string][]float64{"BULL": bull}, 0, 3, 3); len(res) != 0 {
+		t.Fatalf("expected an empty result for an invalid period, got %v", res)
+	}
+}
@@ -0,0 +1,135 @@
+package momentum
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/evdnx/goti/indicator/core"
+)
+
+const DefaultElderRayPeriod = 13
+
+// elderRayMaxValues bounds the retained Bull/Bear Power history.
+const elderRayMaxValues = 256
+
+// ElderRay implements Dr. Alexander Elder's Bull and Bear Power:
+// BullPower = high - EMA(close) and BearPower = low - EMA(close).
+type ElderRay struct {
+	emaPeriod int
+	ema       *core.MovingAverage
+
+	bullPower []float64
+	bearPower []float64
+
+	lastBull float64
+	lastBear float64
+}
+
+// NewElderRay builds an Elder Ray with the classic 13-period EMA.
+func NewElderRay() (*ElderRay, error) {
+	return NewElderRayWithParams(DefaultElderRayPeriod)
+}
+
+// NewElderRayWithParams builds an Elder Ray with a custom EMA period.
+func NewElderRayWithParams(emaPeriod int) (*ElderRay, error) {
+	if emaPeriod < 1 {
+		return nil, errors.New("period must be at least 1")
+	}
+	ema, err := core.NewMovingAverage(core.EMAMovingAverage, emaPeriod)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create EMA: %w", err)
+	}
+	return &ElderRay{
+		emaPeriod: emaPeriod,
+		ema:       ema,
+		bullPower: make([]float64, 0, emaPeriod),
+		bearPower: make([]float64, 0, emaPeriod),
+	}, nil
+}
+
+// Add ingests a new OHLC bar. Values are produced once the EMA is seeded.
+func (e *ElderRay) Add(high, low, close float64) error {
+	if high < low || !core.IsNonNegativePrice(close) {
+		return errors.New("invalid price data")
+	}
+	if err := e.ema.Add(close); err != nil {
+		return err
+	}
+	ema, err := e.ema.Calculate()
+	if err != nil {
+		return nil // EMA still warming up
+	}
+	e.lastBull = high - ema
+	e.lastBear = low - ema
+	e.bullPower = core.KeepLast(append(e.bullPower, e.lastBull), elderRayMaxValues)
+	e.bearPower = core.KeepLast(append(e.bearPower, e.lastBear), elderRayMaxValues)
+	return nil
+}
+
+// Calculate returns the latest Bull Power and Bear Power.
+func (e *ElderRay) Calculate() (float64, float64, error) {
+	if len(e.bullPower) == 0 {
+		return 0, 0, errors.New("no Elder Ray data")
+	}
+	return e.lastBull, e.lastBear, nil
+}
+
+// Reset clears all state while preserving the EMA period.
+func (e *ElderRay) Reset() {
+	e.ema.Reset()
+	e.bullPower = e.bullPower[:0]
+	e.bearPower = e.bearPower[:0]
+	e.lastBull, e.lastBear = 0, 0
+}
+
+// SetPeriod updates the EMA period and resets the indicator.
+func (e *ElderRay) SetPeriod(emaPeriod int) error {
+	if emaPeriod < 1 {
+		return errors.New("period must be at least 1")
+	}
+	if err := e.ema.SetPeriod(emaPeriod); err != nil {
+		return err
+	}
+	e.emaPeriod = emaPeriod
+	e.Reset()
+	return nil
+}
+
+// GetBullPower returns a defensive copy of the Bull Power series.
+func (e *ElderRay) GetBullPower() []float64 { return core.CopySlice(e.bullPower) }
+
+// GetBearPower returns a defensive copy of the Bear Power series.
+func (e *ElderRay) GetBearPower() []float64 { return core.CopySlice(e.bearPower) }
+
+// GetPlotData emits Bull Power and Bear Power as histogram series.
+func (e *ElderRay) GetPlotData(startTime, interval int64) []core.PlotData {
+	if len(e.bullPower) == 0 {
+		return nil
+	}
+	x := make([]float64, len(e.bullPower))
+	for i := range x {
+		x[i] = float64(i)
+	}
+	ts := core.GenerateTimestamps(startTime, len(e.bullPower), interval)
+	return []core.PlotData{
+		{
+			Name:      "Bull Power",
+			X:         x,
+			Y:         e.bullPower,
+			Type:      "bar",
+			Timestamp: ts,
+		},
+		{
+			Name:      "Bear Power",
+			X:         x,
+			Y:         e.bearPower,
+			Type:      "bar",
+			Timestamp: ts,
+		},
+	}
+}
+
+// GetPlotDataRange returns the Elder Ray plot data for bars [from, to).
+func (e *ElderRay) GetPlotDataRange(startTime, interval int64, from, to int) []core.PlotData {
+	return core.SlicePlotData(e.GetPlotData(startTime, interval), from, to)
+}
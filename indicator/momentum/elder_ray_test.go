@@ -0,0 +1,53 @@
+package momentum
+
+import "testing"
+
+func TestElderRay_InvalidPeriod(t *testing.T) {
+	if _, err := NewElderRayWithParams(0); err == nil {
+		t.Fatal("expected error for period 0")
+	}
+}
+
+func TestElderRay_RisingSeries(t *testing.T) {
+	er, err := NewElderRayWithParams(5)
+	if err != nil {
+		t.Fatalf("constructor error: %v", err)
+	}
+	if _, _, err := er.Calculate(); err == nil {
+		t.Fatal("expected error before the EMA is seeded")
+	}
+
+	price := 120.0
+	for i := 0; i < 15; i++ {
+		price -= 2
+		if err := er.Add(price+1, price-1, price); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	_, bearAfterDecline, _ := er.Calculate()
+	if bearAfterDecline >= 0 {
+		t.Fatalf("expected negative Bear Power in a decline, got %.4f", bearAfterDecline)
+	}
+
+	for i := 0; i < 15; i++ {
+		price += 2
+		if err := er.Add(price+1, price-1, price); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	bull, bear, err := er.Calculate()
+	if err != nil {
+		t.Fatalf("Calculate returned error: %v", err)
+	}
+	if bull <= 0 {
+		t.Fatalf("expected positive Bull Power in a rally, got %.4f", bull)
+	}
+	if bear <= bearAfterDecline || bear < -1 {
+		t.Fatalf("expected Bear Power to recover toward zero, got %.4f (was %.4f)", bear, bearAfterDecline)
+	}
+
+	plots := er.GetPlotData(0, 60)
+	if len(plots) != 2 || len(plots[0].Y) != len(er.GetBullPower()) || len(plots[1].Y) != len(er.GetBearPower()) {
+		t.Fatalf("unexpected plot data: %+v", plots)
+	}
+}
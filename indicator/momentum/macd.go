@@ -15,11 +15,13 @@ const (
 
 // MACD implements the Moving Average Convergence Divergence indicator.
 // It tracks the MACD line (fast EMA - slow EMA), the signal line (EMA of MACD),
-// and the histogram (MACD - signal).
+// and the histogram (MACD - signal). SetMAType swaps the EMAs for another
+// average type.
 type MACD struct {
 	fastPeriod   int
 	slowPeriod   int
 	signalPeriod int
+	maType       core.MovingAverageType
 
 	fastEMA   *core.MovingAverage
 	slowEMA   *core.MovingAverage
@@ -28,6 +30,7 @@ type MACD struct {
 	macdValues      []float64
 	signalValues    []float64
 	histogramValues []float64
+	histogramColors []int // four-state code of each histogram bar, fixed when computed
 
 	lastMACD   float64
 	lastSignal float64
@@ -48,32 +51,43 @@ func NewMACDWithParams(fastPeriod, slowPeriod, signalPeriod int) (*MACD, error)
 		return nil, errors.New("fast period must be less than slow period")
 	}
 
-	fast, err := core.NewMovingAverage(core.EMAMovingAverage, fastPeriod)
+	fast, slow, signal, err := newMACDAverages(core.EMAMovingAverage, fastPeriod, slowPeriod, signalPeriod)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create fast EMA: %w", err)
-	}
-	slow, err := core.NewMovingAverage(core.EMAMovingAverage, slowPeriod)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create slow EMA: %w", err)
-	}
-	signal, err := core.NewMovingAverage(core.EMAMovingAverage, signalPeriod)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create signal EMA: %w", err)
+		return nil, err
 	}
 
 	return &MACD{
 		fastPeriod:      fastPeriod,
 		slowPeriod:      slowPeriod,
 		signalPeriod:    signalPeriod,
+		maType:          core.EMAMovingAverage,
 		fastEMA:         fast,
 		slowEMA:         slow,
 		signalEMA:       signal,
 		macdValues:      make([]float64, 0, signalPeriod),
 		signalValues:    make([]float64, 0, signalPeriod),
 		histogramValues: make([]float64, 0, signalPeriod),
+		histogramColors: make([]int, 0, signalPeriod),
 	}, nil
 }
 
+// newMACDAverages builds the fast, slow and signal averages of one type.
+func newMACDAverages(maType core.MovingAverageType, fastPeriod, slowPeriod, signalPeriod int) (fast, slow, signal *core.MovingAverage, err error) {
+	fast, err = core.NewMovingAverage(maType, fastPeriod)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create fast %s: %w", maType, err)
+	}
+	slow, err = core.NewMovingAverage(maType, slowPeriod)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create slow %s: %w", maType, err)
+	}
+	signal, err = core.NewMovingAverage(maType, signalPeriod)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create signal %s: %w", maType, err)
+	}
+	return fast, slow, signal, nil
+}
+
 // Add ingests a new closing price and updates the MACD series when possible.
 func (m *MACD) Add(close float64) error {
 	if !core.IsNonNegativePrice(close) {
@@ -100,8 +114,13 @@ func (m *MACD) Add(close float64) error {
 			m.signalValues = append(m.signalValues, sig)
 
 			hist := macd - sig
+			prevHist, prevColor := 0.0, 0
+			if n := len(m.histogramValues); n > 0 {
+				prevHist, prevColor = m.histogramValues[n-1], m.histogramColors[n-1]
+			}
 			m.lastHist = hist
 			m.histogramValues = append(m.histogramValues, hist)
+			m.histogramColors = append(m.histogramColors, macdHistogramColor(prevHist, hist, prevColor))
 		}
 	}
 
@@ -128,6 +147,7 @@ func (m *MACD) Reset() {
 	m.macdValues = m.macdValues[:0]
 	m.signalValues = m.signalValues[:0]
 	m.histogramValues = m.histogramValues[:0]
+	m.histogramColors = m.histogramColors[:0]
 	m.lastMACD, m.lastSignal, m.lastHist = 0, 0, 0
 }
 
@@ -139,22 +159,30 @@ func (m *MACD) SetPeriods(fastPeriod, slowPeriod, signalPeriod int) error {
 	if fastPeriod >= slowPeriod {
 		return errors.New("fast period must be less than slow period")
 	}
+	fast, slow, signal, err := newMACDAverages(m.maType, fastPeriod, slowPeriod, signalPeriod)
+	if err != nil {
+		return err
+	}
 	m.fastPeriod = fastPeriod
 	m.slowPeriod = slowPeriod
 	m.signalPeriod = signalPeriod
+	m.fastEMA = fast
+	m.slowEMA = slow
+	m.signalEMA = signal
+	m.Reset()
+	return nil
+}
 
-	fast, err := core.NewMovingAverage(core.EMAMovingAverage, fastPeriod)
-	if err != nil {
-		return err
-	}
-	slow, err := core.NewMovingAverage(core.EMAMovingAverage, slowPeriod)
-	if err != nil {
-		return err
-	}
-	signal, err := core.NewMovingAverage(core.EMAMovingAverage, signalPeriod)
+// SetMAType selects the average used for the fast, slow and signal lines:
+// SMA, EMA (the default), WMA, RMA or DEMA. A WMA- or DEMA-based MACD
+// reacts noticeably sooner to turns than the classic EMA version. The crossover and histogram
+// logic is unchanged; the periods are kept and the internal state is reset.
+func (m *MACD) SetMAType(maType core.MovingAverageType) error {
+	fast, slow, signal, err := newMACDAverages(maType, m.fastPeriod, m.slowPeriod, m.signalPeriod)
 	if err != nil {
 		return err
 	}
+	m.maType = maType
 	m.fastEMA = fast
 	m.slowEMA = slow
 	m.signalEMA = signal
@@ -162,6 +190,31 @@ func (m *MACD) SetPeriods(fastPeriod, slowPeriod, signalPeriod int) error {
 	return nil
 }
 
+// MAType returns the average type used for the MACD lines.
+func (m *MACD) MAType() core.MovingAverageType { return m.maType }
+
+// Validate scans the retained MACD, signal and histogram values for NaN or
+// ±Inf and reports the first one found with its index in the retained slice,
+// then checks the latest MACD, signal and histogram readings.
+func (m *MACD) Validate() error {
+	if err := core.ValidateFinite("macdValues", m.macdValues); err != nil {
+		return err
+	}
+	if err := core.ValidateFinite("signalValues", m.signalValues); err != nil {
+		return err
+	}
+	if err := core.ValidateFinite("histogramValues", m.histogramValues); err != nil {
+		return err
+	}
+	if err := core.CheckFinite("lastMACD", m.lastMACD); err != nil {
+		return err
+	}
+	if err := core.CheckFinite("lastSignal", m.lastSignal); err != nil {
+		return err
+	}
+	return core.CheckFinite("lastHist", m.lastHist)
+}
+
 // GetMACDValues returns a defensive copy of the MACD line values.
 func (m *MACD) GetMACDValues() []float64 { return core.CopySlice(m.macdValues) }
 
@@ -173,7 +226,81 @@ func (m *MACD) GetHistogramValues() []float64 {
 	return core.CopySlice(m.histogramValues)
 }
 
-// GetPlotData returns plot-friendly data for the MACD, signal, and histogram.
+// ValueInterpolated returns a point frac of the way from the previous MACD
+// line value to the latest one, for intrabar chart animation. frac must be in
+// [0, 1]; the indicator state is not modified.
+func (m *MACD) ValueInterpolated(frac float64) (float64, error) {
+	return core.InterpolateLast(m.macdValues, frac)
+}
+
+// Four-state MACD histogram colour codes returned by HistogramColors: the
+// sign says which side of zero the bar is on and the magnitude whether it is
+// growing away from zero (2) or shrinking toward it (1).
+const (
+	MACDRisingPositive  = 2  // above zero and above the previous bar
+	MACDFallingPositive = 1  // above zero and below the previous bar
+	MACDRisingNegative  = -1 // below zero and above the previous bar
+	MACDFallingNegative = -2 // below zero and below the previous bar
+)
+
+// HistogramColors returns one four-state colour code per histogram bar (see
+// MACDRisingPositive and friends). A zero bar counts as positive, a bar equal
+// to its predecessor keeps the predecessor's direction, and the first bar is
+// compared with zero. Each code is fixed when its bar is computed, so trimming
+// old bars never changes the colour of the oldest retained one.
+func (m *MACD) HistogramColors() []int {
+	return append([]int(nil), m.histogramColors...)
+}
+
+// macdHistogramColor returns the four-state code of histogram bar h given the
+// previous bar and its code (0 for the first bar).
+func macdHistogramColor(prev, h float64, prevColor int) int {
+	rising := prevColor == MACDRisingPositive || prevColor == MACDRisingNegative
+	if h != prev {
+		rising = h > prev
+	}
+	switch {
+	case h >= 0 && rising:
+		return MACDRisingPositive
+	case h >= 0:
+		return MACDFallingPositive
+	case rising:
+		return MACDRisingNegative
+	default:
+		return MACDFallingNegative
+	}
+}
+
+// macdCrossovers returns, per histogram bar, 1 where the MACD line crosses
+// above its signal line, -1 where it crosses below, and 0 otherwise.
+func (m *MACD) macdCrossovers() []float64 {
+	crosses := make([]float64, len(m.histogramValues))
+	for i := 1; i < len(m.histogramValues); i++ {
+		prev, cur := m.histogramValues[i-1], m.histogramValues[i]
+		if prev <= 0 && cur > 0 {
+			crosses[i] = 1
+		} else if prev >= 0 && cur < 0 {
+			crosses[i] = -1
+		}
+	}
+	return crosses
+}
+
+// macdPane groups the MACD series onto one chart panel; the histogram colour
+// codes and crossover signals get panes of their own so they are not drawn as
+// values beside it.
+const (
+	macdPane       = "MACD"
+	macdColorPane  = "MACD Histogram Colors"
+	macdSignalPane = "MACD Signals"
+)
+
+// GetPlotData returns the MACD line, signal line and histogram in the "MACD"
+// pane, a "Histogram Colors" scatter in the "MACD Histogram Colors" pane
+// whose Y values are the HistogramColors codes of the histogram bars, and a
+// "Signals" scatter in the "MACD Signals" pane marking crossovers of the
+// signal line (1 bullish, -1 bearish, 0 none). The signal and histogram start
+// later than the MACD line; their X indices stay aligned with it.
 func (m *MACD) GetPlotData(startTime, interval int64) []core.PlotData {
 	if len(m.macdValues) == 0 {
 		return nil
@@ -191,6 +318,7 @@ func (m *MACD) GetPlotData(startTime, interval int64) []core.PlotData {
 			Y:         m.macdValues,
 			Type:      "line",
 			Timestamp: timestamps,
+			Pane:      macdPane,
 		},
 	}
 	if len(m.signalValues) > 0 {
@@ -200,6 +328,7 @@ func (m *MACD) GetPlotData(startTime, interval int64) []core.PlotData {
 			Y:         m.signalValues,
 			Type:      "line",
 			Timestamp: timestamps[len(timestamps)-len(m.signalValues):],
+			Pane:      macdPane,
 		})
 	}
 	if len(m.histogramValues) > 0 {
@@ -209,14 +338,81 @@ func (m *MACD) GetPlotData(startTime, interval int64) []core.PlotData {
 			Y:         m.histogramValues,
 			Type:      "bar",
 			Timestamp: timestamps[len(timestamps)-len(m.histogramValues):],
+			Pane:      macdPane,
+		})
+		colors := make([]float64, len(m.histogramColors))
+		for i, c := range m.histogramColors {
+			colors[i] = float64(c)
+		}
+		plots = append(plots, core.PlotData{
+			Name:      "Histogram Colors",
+			X:         x[len(x)-len(colors):],
+			Y:         colors,
+			Type:      "scatter",
+			Signal:    "histogram_color",
+			Timestamp: timestamps[len(timestamps)-len(colors):],
+			Pane:      macdColorPane,
+		}, core.PlotData{
+			Name:      "Signals",
+			X:         x[len(x)-len(m.histogramValues):],
+			Y:         m.macdCrossovers(),
+			Type:      "scatter",
+			Timestamp: timestamps[len(timestamps)-len(m.histogramValues):],
+			Pane:      macdSignalPane,
 		})
 	}
 	return plots
 }
 
+// GetPlotDataRange returns the MACD plot data for bars [from, to).
+func (m *MACD) GetPlotDataRange(startTime, interval int64, from, to int) []core.PlotData {
+	return core.SlicePlotData(m.GetPlotData(startTime, interval), from, to)
+}
+
+// GetPlotDataV2 returns the GetPlotData series plus a marker on every bar
+// flagged in its "Signals" series ("bullish_cross" upwards, "bearish_cross"
+// downwards), valued at the MACD line.
+func (m *MACD) GetPlotDataV2(startTime, interval int64) core.PlotDataWithMarkers {
+	out := core.PlotDataWithMarkers{Series: m.GetPlotData(startTime, interval), Markers: []core.Marker{}}
+	offset := len(m.macdValues) - len(m.histogramValues)
+	for i, c := range m.macdCrossovers() {
+		kind := ""
+		if c > 0 {
+			kind = core.MarkerBullishCross
+		} else if c < 0 {
+			kind = core.MarkerBearishCross
+		}
+		if kind != "" {
+			out.Markers = append(out.Markers, core.Marker{Index: offset + i, Kind: kind, Value: m.macdValues[offset+i]})
+		}
+	}
+	return out
+}
+
 func (m *MACD) trimSlices() {
 	maxKeep := m.slowPeriod + m.signalPeriod
 	m.macdValues = core.KeepLast(m.macdValues, maxKeep)
 	m.signalValues = core.KeepLast(m.signalValues, maxKeep)
 	m.histogramValues = core.KeepLast(m.histogramValues, maxKeep)
+	m.histogramColors = core.KeepLast(m.histogramColors, maxKeep)
+}
+
+// Describe reports the MACD's periods and average type. SamplesNeeded counts the bars until the
+// signal line (and therefore the histogram) is available.
+func (m *MACD) Describe() core.IndicatorInfo {
+	slow, signal := m.slowPeriod, m.signalPeriod
+	if m.maType == core.DEMAMovingAverage {
+		// A DEMA of period n first reports after 2n-1 samples.
+		slow, signal = 2*slow-1, 2*signal-1
+	}
+	return core.IndicatorInfo{
+		Name: "MACD",
+		Params: map[string]any{
+			"fastPeriod":   m.fastPeriod,
+			"slowPeriod":   m.slowPeriod,
+			"signalPeriod": m.signalPeriod,
+			"maType":       string(m.maType),
+		},
+		SamplesNeeded: slow + signal - 1,
+	}
 }
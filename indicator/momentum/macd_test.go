@@ -1,6 +1,12 @@
 package momentum
 
-import "testing"
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/evdnx/goti/indicator/core"
+)
 
 func TestNewMACD_InvalidPeriods(t *testing.T) {
 	if _, err := NewMACDWithParams(0, 10, 3); err == nil {
@@ -59,3 +65,290 @@ func TestMACD_AddAndCalculate(t *testing.T) {
 		t.Fatalf("Histogram mismatch: got %.6f, want 0", histVal)
 	}
 }
+
+func TestMACD_ValueInterpolated(t *testing.T) {
+	m, _ := NewMACDWithParams(2, 4, 2)
+	for _, c := range []float64{10, 11, 13, 12, 15, 16} {
+		if err := m.Add(c); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	vals := m.GetMACDValues()
+	if len(vals) < 2 {
+		t.Fatalf("expected at least two MACD values, got %d", len(vals))
+	}
+	want := (vals[len(vals)-2] + vals[len(vals)-1]) / 2
+	got, err := m.ValueInterpolated(0.5)
+	if err != nil || !approxEqual(got, want) {
+		t.Fatalf("expected midpoint %v, got %v (%v)", want, got, err)
+	}
+	if after := m.GetMACDValues(); after[len(after)-1] != vals[len(vals)-1] {
+		t.Fatal("ValueInterpolated must not modify state")
+	}
+}
+
+func TestMACD_PlotDataSeries(t *testing.T) {
+	m, _ := NewMACDWithParams(3, 6, 3)
+	closes := []float64{10, 11, 12, 13, 14, 15, 14, 13, 12, 11, 10, 11, 12, 13, 14, 15, 16}
+	for _, c := range closes {
+		if err := m.Add(c); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	plots := m.GetPlotData(0, 60)
+	if len(plots) != 5 {
+		t.Fatalf("expected MACD, signal, histogram, colour and signal-marker series, got %d", len(plots))
+	}
+	for i, p := range plots {
+		want := "MACD"
+		if i == 3 {
+			want = "MACD Histogram Colors"
+		} else if i == 4 {
+			want = "MACD Signals"
+		}
+		if p.Pane != want {
+			t.Fatalf("series %s: expected pane %s, got %q", p.Name, want, p.Pane)
+		}
+		if len(p.X) != len(p.Y) || len(p.X) != len(p.Timestamp) {
+			t.Fatalf("series %s: misaligned lengths x=%d y=%d ts=%d", p.Name, len(p.X), len(p.Y), len(p.Timestamp))
+		}
+	}
+	macd, signal, hist, colors := plots[0], plots[1], plots[2], plots[3]
+	if len(colors.Y) != len(hist.Y) || colors.Signal != "histogram_color" {
+		t.Fatalf("colour series should tag every histogram bar, got %d of %d", len(colors.Y), len(hist.Y))
+	}
+	if len(signal.Y) != len(hist.Y) {
+		t.Fatalf("signal (%d) and histogram (%d) should cover the same bars", len(signal.Y), len(hist.Y))
+	}
+	offset := len(macd.Y) - len(hist.Y)
+	for i := range hist.Y {
+		if hist.X[i] != signal.X[i] || hist.X[i] != macd.X[offset+i] {
+			t.Fatalf("bar %d: X indices not aligned", i)
+		}
+		if !approxEqual(hist.Y[i], macd.Y[offset+i]-signal.Y[i]) {
+			t.Fatalf("bar %d: histogram %v != macd %v - signal %v", i, hist.Y[i], macd.Y[offset+i], signal.Y[i])
+		}
+	}
+
+	v2 := m.GetPlotDataV2(0, 60)
+	if len(v2.Markers) == 0 {
+		t.Fatal("expected crossover markers for a falling-then-rising series")
+	}
+	signals := plots[4]
+	if signals.Name != "Signals" || len(signals.Y) != len(hist.Y) {
+		t.Fatalf("signals series should tag every histogram bar, got %+v", signals)
+	}
+	flagged := 0
+	for i, s := range signals.Y {
+		if s != 0 {
+			flagged++
+		}
+		if signals.X[i] != hist.X[i] {
+			t.Fatalf("signal %d: X index not aligned with the histogram", i)
+		}
+	}
+	if flagged != len(v2.Markers) {
+		t.Fatalf("expected %d signal markers, got %d", len(v2.Markers), flagged)
+	}
+	for _, mk := range v2.Markers {
+		h := hist.Y[mk.Index-offset]
+		if (mk.Kind == "bullish_cross") != (h > 0) {
+			t.Fatalf("marker %+v disagrees with histogram sign %v", mk, h)
+		}
+		want := -1.0
+		if h > 0 {
+			want = 1
+		}
+		if signals.Y[mk.Index-offset] != want {
+			t.Fatalf("marker %+v: signal %v, want %v", mk, signals.Y[mk.Index-offset], want)
+		}
+	}
+}
+
+func TestMACD_HistogramColors(t *testing.T) {
+	// Grow, shrink, hold, cross below zero, deepen, recover through zero.
+	hist := []float64{0.5, 1, 0.8, 0.8, -0.2, -0.6, -0.3, 0, 0.4}
+	want := []int{
+		MACDRisingPositive, MACDRisingPositive, MACDFallingPositive, MACDFallingPositive,
+		MACDFallingNegative, MACDFallingNegative, MACDRisingNegative,
+		MACDRisingPositive, MACDRisingPositive,
+	}
+	if got := foldHistogramColors(hist); !reflect.DeepEqual(got, want) {
+		t.Fatalf("codes = %v, want %v", got, want)
+	}
+
+	m, _ := NewMACDWithParams(3, 6, 3)
+	closes := []float64{10, 10, 10, 10, 10, 10, 11, 13, 15, 16, 16, 15, 13, 12, 12, 12.5}
+	for _, c := range closes {
+		if err := m.Add(c); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	codes := m.HistogramColors()
+	if !reflect.DeepEqual(codes, foldHistogramColors(m.GetHistogramValues())) {
+		t.Fatalf("HistogramColors %v does not match the histogram", codes)
+	}
+	plots := m.GetPlotData(0, 60)
+	if len(plots) != 5 {
+		t.Fatalf("expected 5 series, got %d", len(plots))
+	}
+	colors := plots[3]
+	if colors.Name != "Histogram Colors" || len(colors.Y) != len(codes) {
+		t.Fatalf("unexpected colour series: %+v", colors)
+	}
+	for i, c := range codes {
+		if colors.Y[i] != float64(c) || colors.X[i] != plots[2].X[i] {
+			t.Fatalf("colour %d: got %v at x=%v, want %d at x=%v", i, colors.Y[i], colors.X[i], c, plots[2].X[i])
+		}
+	}
+}
+
+func TestMACD_HistogramColorsSurviveTrim(t *testing.T) {
+	m, _ := NewMACDWithParams(3, 6, 3)
+	var hist []float64
+	for i := 0; i < 60; i++ {
+		// A wave with a flat stretch, so equal bars must inherit the direction
+		// of bars that are later trimmed away.
+		c := 20 + 5*math.Sin(float64(i)/3)
+		if i >= 30 && i < 36 {
+			c = 20
+		}
+		if err := m.Add(c); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+		if _, _, h, err := m.Calculate(); err == nil {
+			hist = append(hist, h)
+		}
+	}
+	all := foldHistogramColors(hist)
+	codes := m.HistogramColors()
+	if len(codes) >= len(all) {
+		t.Fatalf("expected the colour history to be trimmed, got %d of %d", len(codes), len(all))
+	}
+	if want := all[len(all)-len(codes):]; !reflect.DeepEqual(codes, want) {
+		t.Fatalf("colours after trimming = %v, want %v", codes, want)
+	}
+}
+
+// foldHistogramColors colours a whole histogram bar by bar, as MACD.Add does.
+func foldHistogramColors(hist []float64) []int {
+	var codes []int
+	prev, prevColor := 0.0, 0
+	for _, h := range hist {
+		prevColor = macdHistogramColor(prev, h, prevColor)
+		codes = append(codes, prevColor)
+		prev = h
+	}
+	return codes
+}
+
+func TestMACD_SetMATypeWMALeads(t *testing.T) {
+	ema, _ := NewMACDWithParams(12, 26, 9)
+	wma, _ := NewMACDWithParams(12, 26, 9)
+	if err := wma.SetMAType(core.WMAMovingAverage); err != nil {
+		t.Fatalf("SetMAType failed: %v", err)
+	}
+	if err := wma.SetMAType("HMA"); err == nil {
+		t.Fatal("expected error for unsupported average type")
+	}
+	if wma.MAType() != core.WMAMovingAverage {
+		t.Fatalf("failed SetMAType must keep the type, got %s", wma.MAType())
+	}
+
+	// A slow cycle with a top at bar 100: both histograms are positive on the
+	// way up from bar 80 and turn negative near the top, the WMA one first.
+	var closes []float64
+	for i := 0; i < 160; i++ {
+		closes = append(closes, 100+20*math.Sin(2*math.Pi*float64(i-80)/80))
+	}
+	firstNegative := func(m *MACD) int {
+		for i, c := range closes {
+			if err := m.Add(c); err != nil {
+				t.Fatalf("Add failed: %v", err)
+			}
+			if _, _, hist, err := m.Calculate(); err == nil && i >= 80 && hist < 0 {
+				return i
+			}
+		}
+		return -1
+	}
+	emaTurn, wmaTurn := firstNegative(ema), firstNegative(wma)
+	if emaTurn < 0 || wmaTurn < 0 {
+		t.Fatalf("expected both histograms to turn negative, got EMA %d WMA %d", emaTurn, wmaTurn)
+	}
+	if wmaTurn >= emaTurn {
+		t.Fatalf("expected WMA-MACD to lead EMA-MACD, got WMA %d EMA %d", wmaTurn, emaTurn)
+	}
+}
+
+// emaRef is a plain SMA-seeded EMA over the non-NaN tail of xs; earlier
+// positions are NaN.
+func emaRef(xs []float64, n int) []float64 {
+	out := make([]float64, len(xs))
+	start := 0
+	for start < len(xs) && math.IsNaN(xs[start]) {
+		start++
+	}
+	alpha, sum := 2/float64(n+1), 0.0
+	for i := range xs {
+		switch k := i - start; {
+		case k < 0 || k < n-1:
+			if k >= 0 {
+				sum += xs[i]
+			}
+			out[i] = math.NaN()
+		case k == n-1:
+			out[i] = (sum + xs[i]) / float64(n)
+		default:
+			out[i] = alpha*xs[i] + (1-alpha)*out[i-1]
+		}
+	}
+	return out
+}
+
+func demaRef(xs []float64, n int) []float64 {
+	e1 := emaRef(xs, n)
+	e2 := emaRef(e1, n)
+	out := make([]float64, len(xs))
+	for i := range xs {
+		out[i] = 2*e1[i] - e2[i]
+	}
+	return out
+}
+
+func TestMACD_SetMATypeDEMA(t *testing.T) {
+	m, _ := NewMACDWithParams(3, 5, 2)
+	if err := m.SetMAType(core.DEMAMovingAverage); err != nil {
+		t.Fatalf("SetMAType(DEMA) failed: %v", err)
+	}
+	closes := []float64{10, 11, 13, 12, 15, 14, 18, 17, 16, 19, 22, 20, 21, 25, 23}
+	macd := make([]float64, len(closes))
+	fast, slow := demaRef(closes, 3), demaRef(closes, 5)
+	for i := range closes {
+		macd[i] = fast[i] - slow[i]
+	}
+	signal := demaRef(macd, 2)
+	if got := m.Describe().SamplesNeeded; got != 11 {
+		t.Fatalf("SamplesNeeded = %d, want 11", got)
+	}
+
+	// The slow DEMA needs 2·5−1 closes and the signal 2·2−1 MACD values.
+	for i, c := range closes {
+		if err := m.Add(c); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+		gotMACD, gotSignal, gotHist, err := m.Calculate()
+		if i < 8+2 {
+			if err == nil {
+				t.Fatalf("bar %d: expected the signal line to be warming up", i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("bar %d: Calculate failed: %v", i, err)
+		}
+		if !approxEqual(gotMACD, macd[i]) || !approxEqual(gotSignal, signal[i]) || !approxEqual(gotHist, macd[i]-signal[i]) {
+			t.Fatalf("bar %d: got %v/%v/%v, want %v/%v/%v", i, gotMACD, gotSignal, gotHist, macd[i], signal[i], macd[i]-signal[i])
+		}
+	}
+}
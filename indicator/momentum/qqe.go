@@ -0,0 +1,241 @@
+package momentum
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/evdnx/goti/config"
+	"github.com/evdnx/goti/indicator/core"
+)
+
+const (
+	DefaultQQERSIPeriod = 14
+	DefaultQQESmoothing = 5
+	DefaultQQEFactor    = 4.236
+)
+
+// qqeMaxValues bounds the retained fast/slow line history.
+const qqeMaxValues = 256
+
+// QQE implements the Quantitative Qualitative Estimation indicator. The fast
+// line is an EMA of the RSI. The volatility of that line ("RSI ATR") is the
+// absolute bar-to-bar change, Wilder-smoothed twice over 2·rsiPeriod−1 bars
+// and multiplied by factor. The slow line trails the fast line at that
+// distance: below it while the trend is up, above it while the trend is down,
+// and it only ratchets towards the fast line until the fast line crosses it.
+type QQE struct {
+	rsiPeriod int
+	smoothing int
+	factor    float64
+
+	rsi      *RelativeStrengthIndex
+	rsiMA    *core.MovingAverage // EMA of RSI → fast line
+	atrRSI   *core.MovingAverage // Wilder average of |Δ fast|
+	atrRSIMA *core.MovingAverage // second Wilder pass → band width / factor
+
+	prevFast  float64
+	hasPrev   bool
+	longBand  float64
+	shortBand float64
+	trend     int // 1 up, -1 down; 0 until the first trailing level
+	banded    bool
+
+	fast []float64
+	slow []float64
+}
+
+// NewQQE builds a QQE with the classic RSI(14), smoothing 5 and factor 4.236.
+func NewQQE() (*QQE, error) {
+	return NewQQEWithParams(DefaultQQERSIPeriod, DefaultQQESmoothing, DefaultQQEFactor)
+}
+
+// NewQQEWithParams builds a QQE with a custom RSI period, RSI smoothing
+// period and band factor.
+func NewQQEWithParams(rsiPeriod, smoothing int, factor float64) (*QQE, error) {
+	if rsiPeriod < 1 || smoothing < 1 {
+		return nil, errors.New("period must be at least 1")
+	}
+	if !(factor > 0) || math.IsInf(factor, 0) {
+		return nil, errors.New("factor must be positive")
+	}
+	rsi, err := NewRelativeStrengthIndexWithParams(rsiPeriod, config.DefaultConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RSI: %w", err)
+	}
+	rsiMA, err := core.NewMovingAverage(core.EMAMovingAverage, smoothing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RSI EMA: %w", err)
+	}
+	wilders := 2*rsiPeriod - 1
+	atrRSI, err := core.NewMovingAverage(core.RMAMovingAverage, wilders)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RSI ATR: %w", err)
+	}
+	atrRSIMA, err := core.NewMovingAverage(core.RMAMovingAverage, wilders)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RSI ATR average: %w", err)
+	}
+	return &QQE{
+		rsiPeriod: rsiPeriod,
+		smoothing: smoothing,
+		factor:    factor,
+		rsi:       rsi,
+		rsiMA:     rsiMA,
+		atrRSI:    atrRSI,
+		atrRSIMA:  atrRSIMA,
+		fast:      make([]float64, 0, 16),
+		slow:      make([]float64, 0, 16),
+	}, nil
+}
+
+// Add ingests a closing price. Both lines are produced once the RSI, its EMA
+// and the two Wilder passes are all warm.
+func (q *QQE) Add(close float64) error {
+	if err := q.rsi.Add(close); err != nil {
+		return err
+	}
+	r, err := q.rsi.Calculate()
+	if err != nil {
+		return nil // RSI still warming up
+	}
+	if err := q.rsiMA.AddValue(r); err != nil {
+		return err
+	}
+	fast, err := q.rsiMA.Calculate()
+	if err != nil {
+		return nil
+	}
+	prevFast, hadPrev := q.prevFast, q.hasPrev
+	q.prevFast, q.hasPrev = fast, true
+	if !hadPrev {
+		return nil
+	}
+
+	if err := q.atrRSI.AddValue(math.Abs(fast - prevFast)); err != nil {
+		return err
+	}
+	atr, err := q.atrRSI.Calculate()
+	if err != nil {
+		return nil
+	}
+	if err := q.atrRSIMA.AddValue(atr); err != nil {
+		return err
+	}
+	smoothed, err := q.atrRSIMA.Calculate()
+	if err != nil {
+		return nil
+	}
+	dar := smoothed * q.factor
+
+	newLong, newShort := fast-dar, fast+dar
+	if !q.banded {
+		q.longBand, q.shortBand, q.trend = newLong, newShort, 1
+		q.banded = true
+	} else {
+		prevLong, prevShort := q.longBand, q.shortBand
+		if prevFast > prevLong && fast > prevLong {
+			q.longBand = math.Max(prevLong, newLong)
+		} else {
+			q.longBand = newLong
+		}
+		if prevFast < prevShort && fast < prevShort {
+			q.shortBand = math.Min(prevShort, newShort)
+		} else {
+			q.shortBand = newShort
+		}
+		if fast > prevShort {
+			q.trend = 1
+		} else if fast < prevLong {
+			q.trend = -1
+		}
+	}
+
+	slow := q.shortBand
+	if q.trend == 1 {
+		slow = q.longBand
+	}
+	q.fast = core.KeepLast(append(q.fast, fast), qqeMaxValues)
+	q.slow = core.KeepLast(append(q.slow, slow), qqeMaxValues)
+	return nil
+}
+
+// Calculate returns the latest fast (smoothed RSI) and slow (trailing) lines.
+func (q *QQE) Calculate() (fast, slow float64, err error) {
+	if len(q.fast) == 0 {
+		return 0, 0, errors.New("no QQE data")
+	}
+	return q.fast[len(q.fast)-1], q.slow[len(q.slow)-1], nil
+}
+
+// GetFast returns a defensive copy of the fast line (EMA of RSI).
+func (q *QQE) GetFast() []float64 { return core.CopySlice(q.fast) }
+
+// GetSlow returns a defensive copy of the slow trailing line.
+func (q *QQE) GetSlow() []float64 { return core.CopySlice(q.slow) }
+
+// IsBullishCrossover reports whether the fast line crossed above the slow
+// line on the latest bar.
+func (q *QQE) IsBullishCrossover() (bool, error) {
+	n := len(q.fast)
+	if n < 2 {
+		return false, errors.New("insufficient data for crossover")
+	}
+	return q.fast[n-2] <= q.slow[n-2] && q.fast[n-1] > q.slow[n-1], nil
+}
+
+// IsBearishCrossover reports whether the fast line crossed below the slow
+// line on the latest bar.
+func (q *QQE) IsBearishCrossover() (bool, error) {
+	n := len(q.fast)
+	if n < 2 {
+		return false, errors.New("insufficient data for crossover")
+	}
+	return q.fast[n-2] >= q.slow[n-2] && q.fast[n-1] < q.slow[n-1], nil
+}
+
+// Reset clears all state while preserving the parameters.
+func (q *QQE) Reset() {
+	q.rsi.Reset()
+	q.rsiMA.Reset()
+	q.atrRSI.Reset()
+	q.atrRSIMA.Reset()
+	q.prevFast, q.hasPrev = 0, false
+	q.longBand, q.shortBand = 0, 0
+	q.trend, q.banded = 0, false
+	q.fast = q.fast[:0]
+	q.slow = q.slow[:0]
+}
+
+// GetPlotData emits the fast and slow lines.
+func (q *QQE) GetPlotData(startTime, interval int64) []core.PlotData {
+	if len(q.fast) == 0 {
+		return nil
+	}
+	x := make([]float64, len(q.fast))
+	for i := range x {
+		x[i] = float64(i)
+	}
+	ts := core.GenerateTimestamps(startTime, len(q.fast), interval)
+	return []core.PlotData{
+		{
+			Name:      "QQE Fast",
+			X:         x,
+			Y:         core.CopySlice(q.fast),
+			Type:      "line",
+			Timestamp: ts,
+		},
+		{
+			Name:      "QQE Slow",
+			X:         x,
+			Y:         core.CopySlice(q.slow),
+			Type:      "line",
+			Timestamp: ts,
+		},
+	}
+}
+
+// GetPlotDataRange returns the QQE plot data for bars [from, to).
+func (q *QQE) GetPlotDataRange(startTime, interval int64, from, to int) []core.PlotData {
+	return core.SlicePlotData(q.GetPlotData(startTime, interval), from, to)
+}
@@ -0,0 +1,67 @@
+package momentum
+
+import "testing"
+
+func TestQQE_InvalidParams(t *testing.T) {
+	if _, err := NewQQEWithParams(0, 5, 4.236); err == nil {
+		t.Fatal("expected error for RSI period 0")
+	}
+	if _, err := NewQQEWithParams(14, 0, 4.236); err == nil {
+		t.Fatal("expected error for smoothing 0")
+	}
+	if _, err := NewQQEWithParams(14, 5, 0); err == nil {
+		t.Fatal("expected error for a non-positive factor")
+	}
+}
+
+func TestQQE_TrailsTrend(t *testing.T) {
+	q, err := NewQQEWithParams(5, 3, DefaultQQEFactor)
+	if err != nil {
+		t.Fatalf("constructor error: %v", err)
+	}
+	if _, _, err := q.Calculate(); err == nil {
+		t.Fatal("expected error before warm-up")
+	}
+
+	// Zig-zag uptrend: two steps up, one step down.
+	price := 100.0
+	for i := 0; i < 80; i++ {
+		if i%3 == 2 {
+			price--
+		} else {
+			price += 2
+		}
+		if err := q.Add(price); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+		if bear, err := q.IsBearishCrossover(); err == nil && bear {
+			t.Fatalf("bar %d: premature bearish crossover in an uptrend", i)
+		}
+	}
+	fast, slow := q.GetFast(), q.GetSlow()
+	if len(fast) < 10 {
+		t.Fatalf("expected QQE values after warm-up, got %d", len(fast))
+	}
+	for i := range fast {
+		if slow[i] >= fast[i] {
+			t.Fatalf("bar %d: slow %.4f should trail below fast %.4f", i, slow[i], fast[i])
+		}
+		if i > 0 && slow[i] < slow[i-1] {
+			t.Fatalf("bar %d: trailing level fell from %.4f to %.4f", i, slow[i-1], slow[i])
+		}
+	}
+
+	// A sustained decline must eventually flip the trailing level.
+	crossed := false
+	for i := 0; i < 40 && !crossed; i++ {
+		price -= 3
+		_ = q.Add(price)
+		crossed, _ = q.IsBearishCrossover()
+	}
+	if !crossed {
+		t.Fatal("expected a bearish crossover after the reversal")
+	}
+	if plot := q.GetPlotData(0, 60); len(plot) != 2 || plot[1].Name != "QQE Slow" {
+		t.Fatalf("unexpected plot data %+v", plot)
+	}
+}
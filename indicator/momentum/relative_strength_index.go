@@ -28,10 +28,89 @@ type RelativeStrengthIndex struct {
 	rsiValues []float64
 	lastValue float64
 	config    config.IndicatorConfig
+	emitted   int // values produced since the last reset (see CalculateWithConfidence)
+	bars      int // bars accepted since the last reset (see FirstValueBarIndex)
 
 	// Smoothed averages – maintained across calls after the first full period.
 	avgGain float64
 	avgLoss float64
+	warm    bool // averages were seeded from a full period of deltas
+	cutler  bool // plain SMAs of gains/losses instead of Wilder smoothing
+
+	minPeriods int // deltas required before the first (approximate) value; 0 = period
+
+	transform    func(float64) float64 // optional output hook (see SetOutputTransform)
+	extremes     core.Extremes         // output range since the last reset (see ObservedMin)
+	strictOutput bool                  // Add fails on a non-finite RSI (see WithStrictOutputCheck)
+	divergence   core.DivergenceWindow // closes and RSI values for IsDivergenceWithStrength
+
+	historyLen int       // RSI values kept for ValueAtPercentile; 0 = rsiValues only
+	retention  int       // RSI values kept in rsiValues; 0 = period (see SetRetentionLength)
+	history    []float64 // see WithHistory
+
+	inclusivity core.CrossoverInclusivity // boundary rule of the crossover helpers
+	crossDelta  float64                   // minimum move beyond the level (see SetCrossoverThreshold)
+	invertPrice bool                      // Add feeds 1/close (see WithInvertedPrice)
+
+	// Optional percentile-based thresholds (see WithDynamicThresholds).
+	dynWindow  int
+	dynHiPct   float64
+	dynLoPct   float64
+	dynHistory []float64
+	dynLevels  [2]float64   // overbought/oversold percentiles of the full window
+	levels     [][2]float64 // thresholds in effect when each rsiValues entry was produced
+}
+
+// RSIOption configures a RelativeStrengthIndex instance.
+type RSIOption func(*RelativeStrengthIndex)
+
+// WithDynamicThresholds replaces the fixed overbought/oversold levels with the
+// hiPct/loPct percentiles (0–100) of the last window RSI values. Until window
+// values exist the configured fixed thresholds are used.
+func WithDynamicThresholds(window int, hiPct, loPct float64) RSIOption {
+	return func(r *RelativeStrengthIndex) {
+		r.dynWindow = window
+		r.dynHiPct = hiPct
+		r.dynLoPct = loPct
+	}
+}
+
+// WithStrictOutputCheck makes Add return an error wrapping core.ErrNonFinite
+// as soon as it computes a NaN or ±Inf RSI. The value is still recorded, so
+// Validate keeps reporting it until Reset.
+func WithStrictOutputCheck(enabled bool) RSIOption {
+	return func(r *RelativeStrengthIndex) { r.strictOutput = enabled }
+}
+
+// WithCutlerMethod computes the average gain and loss as simple means of the
+// last period deltas (Cutler's RSI) instead of Wilder's recursion. Values then
+// depend only on the last period+1 closes, with no warm-up path dependence,
+// which matches platforms that publish Cutler's variant.
+func WithCutlerMethod(enabled bool) RSIOption {
+	return func(r *RelativeStrengthIndex) { r.cutler = enabled }
+}
+
+// WithHistory keeps the last n RSI values for ValueAtPercentile, which
+// otherwise only sees the values GetRSIValues retains (period values unless
+// SetRetentionLength says otherwise).
+func WithHistory(n int) RSIOption {
+	return func(r *RelativeStrengthIndex) { r.historyLen = n }
+}
+
+// WithCrossoverInclusivity sets whether IsBullishCrossover and
+// IsBearishCrossover accept a previous RSI exactly on the threshold. The
+// default is inclusive; CrossoverStrict stops an RSI pinned at a threshold of
+// 0 or 100 from firing as soon as it moves.
+func WithCrossoverInclusivity(mode core.CrossoverInclusivity) RSIOption {
+	return func(r *RelativeStrengthIndex) { r.inclusivity = mode }
+}
+
+// WithInvertedPrice computes the RSI of 1/close (see core.InvertPrice), so a
+// downtrend reads like an uptrend on normal data and bearish setups can be
+// screened with the bullish rules. GetCloses and the return helpers then
+// report the inverted closes. A zero close is rejected while enabled.
+func WithInvertedPrice(enabled bool) RSIOption {
+	return func(r *RelativeStrengthIndex) { r.invertPrice = enabled }
 }
 
 // NewRelativeStrengthIndex creates an RSI calculator with the default period (5)
@@ -41,20 +120,36 @@ func NewRelativeStrengthIndex() (*RelativeStrengthIndex, error) {
 }
 
 // NewRelativeStrengthIndexWithParams creates an RSI calculator with a custom
-// period and configuration.
-func NewRelativeStrengthIndexWithParams(period int, cfg config.IndicatorConfig) (*RelativeStrengthIndex, error) {
+// period and configuration. Functional options are applied last.
+func NewRelativeStrengthIndexWithParams(period int, cfg config.IndicatorConfig, opts ...RSIOption) (*RelativeStrengthIndex, error) {
 	if period < 1 {
 		return nil, errors.New("period must be at least 1")
 	}
 	if cfg.RSIOverbought <= cfg.RSIOversold {
 		return nil, errors.New("RSI overbought threshold must be greater than oversold")
 	}
-	return &RelativeStrengthIndex{
+	rsi := &RelativeStrengthIndex{
 		period:    period,
 		closes:    make([]float64, 0, period+1),
 		rsiValues: make([]float64, 0, period),
 		config:    cfg,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(rsi)
+	}
+	if rsi.dynWindow != 0 {
+		if rsi.dynWindow < 2 {
+			return nil, errors.New("dynamic threshold window must be at least 2")
+		}
+		if rsi.dynLoPct < 0 || rsi.dynHiPct > 100 || rsi.dynLoPct >= rsi.dynHiPct {
+			return nil, errors.New("dynamic threshold percentiles must satisfy 0 <= lo < hi <= 100")
+		}
+		rsi.dynHistory = make([]float64, 0, rsi.dynWindow)
+	}
+	if rsi.historyLen < 0 {
+		return nil, errors.New("history length must be non-negative")
+	}
+	return rsi, nil
 }
 
 // Add appends a new closing price. When enough data is present it updates the RSI.
@@ -62,30 +157,117 @@ func (rsi *RelativeStrengthIndex) Add(close float64) error {
 	if !core.IsNonNegativePrice(close) {
 		return errors.New("invalid price")
 	}
+	if rsi.invertPrice {
+		inv, err := core.InvertPrice(close)
+		if err != nil {
+			return err
+		}
+		close = inv
+	}
 	rsi.closes = append(rsi.closes, close)
+	rsi.bars++
 
+	var outErr error
 	// Start calculating once we have period+1 points (the first delta needs a full
-	// window of prior closes).
-	if len(rsi.closes) >= rsi.period+1 {
+	// window of prior closes), or minPeriods+1 points when SetMinPeriods lowered
+	// the threshold.
+	if len(rsi.closes) >= rsi.emitAfter()+1 {
 		newRSI, err := rsi.calculateRSI()
 		if err != nil {
 			return fmt.Errorf("calculateRSI failed: %w", err)
 		}
+		newRSI = core.ApplyTransform(rsi.transform, newRSI)
 		rsi.rsiValues = append(rsi.rsiValues, newRSI)
+		rsi.emitted++
+		rsi.extremes.Observe(newRSI)
+		rsi.divergence.Observe(close, newRSI)
 		rri := newRSI // store for convenience
 		rsi.lastValue = rri
+		if rsi.dynWindow > 0 && rsi.warm {
+			rsi.dynHistory = core.KeepLast(append(rsi.dynHistory, newRSI), rsi.dynWindow)
+			rsi.updateDynamicLevels()
+		}
+		overbought, oversold := rsi.Thresholds()
+		rsi.levels = append(rsi.levels, [2]float64{overbought, oversold})
+		if rsi.historyLen > 0 {
+			rsi.history = core.KeepLast(append(rsi.history, newRSI), rsi.historyLen)
+		}
+		if rsi.strictOutput {
+			outErr = core.CheckFinite("rsi", newRSI)
+		}
 	}
 	rsi.trimSlices()
+	return outErr
+}
+
+// Validate scans the retained closes and RSI values for NaN or ±Inf and
+// reports the first one found with its index in the retained slice, then
+// checks the Wilder averages and the latest value.
+func (rsi *RelativeStrengthIndex) Validate() error {
+	if err := core.ValidateFinite("closes", rsi.closes); err != nil {
+		return err
+	}
+	if err := core.ValidateFinite("rsiValues", rsi.rsiValues); err != nil {
+		return err
+	}
+	if err := core.CheckFinite("avgGain", rsi.avgGain); err != nil {
+		return err
+	}
+	if err := core.CheckFinite("avgLoss", rsi.avgLoss); err != nil {
+		return err
+	}
+	return core.CheckFinite("lastValue", rsi.lastValue)
+}
+
+// SetRetentionLength sets how many RSI values are kept for GetValues,
+// GetPlotData and the crossover helpers, e.g. 500 for a chart, without
+// touching the closes the calculation needs. 0 restores the default of one
+// period; shrinking trims the retained values at once.
+func (rsi *RelativeStrengthIndex) SetRetentionLength(n int) error {
+	if n < 0 {
+		return errors.New("retention length must be non-negative")
+	}
+	rsi.retention = n
+	rsi.trimSlices()
 	return nil
 }
 
-// trimSlices keeps the internal slices bounded to the configured period.
+// trimSlices keeps the closes bounded to the configured period and the RSI
+// values to the retention length.
 func (rsi *RelativeStrengthIndex) trimSlices() {
 	if len(rsi.closes) > rsi.period+1 {
 		rsi.closes = rsi.closes[len(rsi.closes)-rsi.period-1:]
 	}
-	if len(rsi.rsiValues) > rsi.period {
-		rsi.rsiValues = rsi.rsiValues[len(rsi.rsiValues)-rsi.period:]
+	keep := rsi.period
+	if rsi.retention > 0 {
+		keep = rsi.retention
+	}
+	rsi.rsiValues = core.KeepLast(rsi.rsiValues, keep)
+	rsi.levels = core.KeepLast(rsi.levels, keep)
+}
+
+// Thresholds returns the overbought/oversold levels currently in effect:
+// the configured fixed levels, or the rolling percentiles when
+// WithDynamicThresholds is enabled and its window is full.
+func (rsi *RelativeStrengthIndex) Thresholds() (overbought, oversold float64) {
+	if rsi.dynWindow == 0 || len(rsi.dynHistory) < rsi.dynWindow {
+		return rsi.config.RSIOverbought, rsi.config.RSIOversold
+	}
+	return rsi.dynLevels[0], rsi.dynLevels[1]
+}
+
+// updateDynamicLevels recomputes the percentile thresholds once per bar, so
+// Thresholds does not sort the window on every call.
+func (rsi *RelativeStrengthIndex) updateDynamicLevels() {
+	if len(rsi.dynHistory) < rsi.dynWindow {
+		return
+	}
+	rsi.dynLevels = [2]float64{rsi.config.RSIOverbought, rsi.config.RSIOversold}
+	if hi, err := core.Percentile(rsi.dynHistory, rsi.dynHiPct); err == nil {
+		rsi.dynLevels[0] = hi
+	}
+	if lo, err := core.Percentile(rsi.dynHistory, rsi.dynLoPct); err == nil {
+		rsi.dynLevels[1] = lo
 	}
 }
 
@@ -95,18 +277,20 @@ func (rsi *RelativeStrengthIndex) trimSlices() {
 //   - Afterwards we update the smoothed averages with the *single* most‑recent
 //     gain/loss and then derive the RSI from the smoothed values.
 func (rsi *RelativeStrengthIndex) calculateRSI() (float64, error) {
-	if len(rsi.closes) < rsi.period+1 {
-		return 0, fmt.Errorf("insufficient data: need %d, have %d", rsi.period+1, len(rsi.closes))
+	if len(rsi.closes) < rsi.emitAfter()+1 {
+		return 0, fmt.Errorf("insufficient data: need %d, have %d", rsi.emitAfter()+1, len(rsi.closes))
 	}
 
-	// First RSI – seed the smoothed averages with simple means.
-	if len(rsi.rsiValues) == 0 {
-		// Slice containing exactly (period+1) most‑recent closes.
-		startIdx := len(rsi.closes) - rsi.period - 1
-		closes := rsi.closes[startIdx:]
+	// Until a full period is seen, seed the smoothed averages with simple means
+	// of the deltas available. Before that (only possible with SetMinPeriods)
+	// the window is partial and the value approximate. Cutler's method keeps
+	// using the simple means of the window on every bar.
+	if !rsi.warm || rsi.cutler {
+		window := min(len(rsi.closes)-1, rsi.period)
+		closes := rsi.closes[len(rsi.closes)-window-1:]
 
 		gainSum, lossSum := 0.0, 0.0
-		for i := 1; i <= rsi.period; i++ {
+		for i := 1; i <= window; i++ {
 			diff := closes[i] - closes[i-1]
 			if diff > 0 {
 				gainSum += diff
@@ -114,8 +298,9 @@ func (rsi *RelativeStrengthIndex) calculateRSI() (float64, error) {
 				lossSum -= diff // make loss positive
 			}
 		}
-		rsi.avgGain = gainSum / float64(rsi.period)
-		rsi.avgLoss = lossSum / float64(rsi.period)
+		rsi.avgGain = gainSum / float64(window)
+		rsi.avgLoss = lossSum / float64(window)
+		rsi.warm = window == rsi.period
 	} else {
 		// Wilder smoothing: incorporate the *single* most‑recent gain/loss.
 		last := rsi.closes[len(rsi.closes)-1]
@@ -154,6 +339,18 @@ func (rsi *RelativeStrengthIndex) Calculate() (float64, error) {
 	return rsi.lastValue, nil
 }
 
+// CalculateWithConfidence returns the latest RSI with a warm-up confidence
+// from WarmupConfidence: 0 on the first value, while the Wilder averages
+// still carry their seed, rising to 1 three periods later. Strategies can use
+// it to down-weight early signals.
+func (rsi *RelativeStrengthIndex) CalculateWithConfidence() (value, confidence float64, err error) {
+	value, err = rsi.Calculate()
+	if err != nil {
+		return 0, 0, err
+	}
+	return value, core.WarmupConfidence(rsi.emitted, rsi.period), nil
+}
+
 // GetLastValue returns the last RSI value (convenience wrapper).
 func (rsi *RelativeStrengthIndex) GetLastValue() float64 {
 	return rsi.lastValue
@@ -166,7 +363,8 @@ func (rsi *RelativeStrengthIndex) IsBullishCrossover() (bool, error) {
 	}
 	curr := rsi.rsiValues[len(rsi.rsiValues)-1]
 	prev := rsi.rsiValues[len(rsi.rsiValues)-2]
-	return prev <= rsi.config.RSIOversold && curr > rsi.config.RSIOversold, nil
+	_, oversold := rsi.Thresholds()
+	return core.CrossedAbove(prev, curr, oversold, rsi.inclusivity.AllowsEqual(true)) && curr-oversold >= rsi.crossDelta, nil
 }
 
 // IsBearishCrossover checks whether RSI crossed below the overbought threshold.
@@ -176,7 +374,20 @@ func (rsi *RelativeStrengthIndex) IsBearishCrossover() (bool, error) {
 	}
 	curr := rsi.rsiValues[len(rsi.rsiValues)-1]
 	prev := rsi.rsiValues[len(rsi.rsiValues)-2]
-	return prev >= rsi.config.RSIOverbought && curr < rsi.config.RSIOverbought, nil
+	overbought, _ := rsi.Thresholds()
+	return core.CrossedBelow(prev, curr, overbought, rsi.inclusivity.AllowsEqual(true)) && overbought-curr >= rsi.crossDelta, nil
+}
+
+// SetCrossoverThreshold makes IsBullishCrossover and IsBearishCrossover fire
+// only when the RSI ends at least delta points beyond the level, e.g. 2 to
+// ignore a 30.1 that barely clears oversold in a range. 0, the default, keeps
+// any strict cross. The crossover markers of GetPlotData are unaffected.
+func (rsi *RelativeStrengthIndex) SetCrossoverThreshold(delta float64) error {
+	if err := core.ValidateCrossoverThreshold(delta); err != nil {
+		return err
+	}
+	rsi.crossDelta = delta
+	return nil
 }
 
 // GetOverboughtOversold reports the current overbought/oversold status.
@@ -185,16 +396,29 @@ func (rsi *RelativeStrengthIndex) GetOverboughtOversold() (string, error) {
 		return "", errors.New("no RSI data")
 	}
 	curr := rsi.rsiValues[len(rsi.rsiValues)-1]
+	overbought, oversold := rsi.Thresholds()
 	switch {
-	case curr > rsi.config.RSIOverbought:
+	case curr > overbought:
 		return "Overbought", nil
-	case curr < rsi.config.RSIOversold:
+	case curr < oversold:
 		return "Oversold", nil
 	default:
 		return "Neutral", nil
 	}
 }
 
+// ZoneTransition reports whether the latest RSI entered or left the
+// overbought or oversold zone, comparing the last two values against the
+// thresholds in effect (see core.ClassifyZoneTransition).
+func (rsi *RelativeStrengthIndex) ZoneTransition() (core.ZoneEvent, error) {
+	if len(rsi.rsiValues) < 2 {
+		return core.ZoneNone, errors.New("insufficient data for zone transition")
+	}
+	overbought, oversold := rsi.Thresholds()
+	n := len(rsi.rsiValues)
+	return core.ClassifyZoneTransition(rsi.rsiValues[n-2], rsi.rsiValues[n-1], overbought, oversold), nil
+}
+
 // IsDivergence checks for bullish or bearish divergence signals.
 func (rsi *RelativeStrengthIndex) IsDivergence() (bool, string, error) {
 	if len(rsi.rsiValues) < 2 || len(rsi.closes) < 2 {
@@ -202,23 +426,164 @@ func (rsi *RelativeStrengthIndex) IsDivergence() (bool, string, error) {
 	}
 	currentRSI := rsi.rsiValues[len(rsi.rsiValues)-1]
 	priceTrend := rsi.closes[len(rsi.closes)-1] - rsi.closes[len(rsi.closes)-2]
+	overbought, oversold := rsi.Thresholds()
 
-	if currentRSI > rsi.config.RSIOverbought && priceTrend < 0 {
+	if currentRSI > overbought && priceTrend < 0 {
 		return true, "Bearish", nil
 	}
-	if currentRSI < rsi.config.RSIOversold && priceTrend > 0 {
+	if currentRSI < oversold && priceTrend > 0 {
 		return true, "Bullish", nil
 	}
 	return false, "", nil
 }
 
+// IsDivergenceWithStrength looks for a pivot divergence between the closes and
+// the RSI over the last 64 RSI bars (see core.PivotDivergenceStrength): the
+// last two pivot lows or highs of price, two bars wide, against the RSI on
+// the same bars. It returns "Bullish" or "Bearish" with the per-bar gap
+// between the price and RSI slopes joining those pivots, each taken as a
+// share of its range over the window, so setups can be
+// ranked or filtered, or "" and 0 without a divergence. Unlike IsDivergence
+// it ignores the overbought/oversold zones.
+func (rsi *RelativeStrengthIndex) IsDivergenceWithStrength() (string, float64, error) {
+	dir, strength, ok := rsi.divergence.Strength()
+	if !ok {
+		return "", 0, errors.New("insufficient data for divergence")
+	}
+	switch dir {
+	case core.DivergenceBullish:
+		return "Bullish", strength, nil
+	case core.DivergenceBearish:
+		return "Bearish", strength, nil
+	}
+	return "", 0, nil
+}
+
+// Peek returns the RSI that Add(close) followed by Calculate would produce,
+// without committing the bar: the update runs on a copy of the smoothing state
+// and is discarded. Useful for order previews and what-if analysis.
+func (rsi *RelativeStrengthIndex) Peek(close float64) (float64, error) {
+	c := rsi.clone()
+	if err := c.Add(close); err != nil {
+		return 0, err
+	}
+	return c.Calculate()
+}
+
+// Compute feeds every close through Add and returns the RSI value emitted for
+// each bar, skipping warm-up bars (and any close Add rejects). The indicator
+// stays warmed with the whole series, ready for further Add calls.
+func (rsi *RelativeStrengthIndex) Compute(closes []float64) []float64 {
+	out := make([]float64, 0, len(closes))
+	for _, c := range closes {
+		if rsi.Add(c) != nil {
+			continue
+		}
+		if v, err := rsi.Calculate(); err == nil {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// clone returns a copy whose slices do not share backing arrays with rsi.
+func (rsi *RelativeStrengthIndex) clone() *RelativeStrengthIndex {
+	c := *rsi
+	c.closes = core.CopySlice(rsi.closes)
+	c.rsiValues = core.CopySlice(rsi.rsiValues)
+	c.dynHistory = core.CopySlice(rsi.dynHistory)
+	c.levels = append([][2]float64(nil), rsi.levels...)
+	c.history = core.CopySlice(rsi.history)
+	c.divergence = rsi.divergence.Clone()
+	return &c
+}
+
 // Reset clears all stored data and smoothing state.
 func (rsi *RelativeStrengthIndex) Reset() {
 	rsi.closes = rsi.closes[:0]
 	rsi.rsiValues = rsi.rsiValues[:0]
 	rsi.lastValue = 0
+	rsi.emitted = 0
+	rsi.bars = 0
 	rsi.avgGain = 0
 	rsi.avgLoss = 0
+	rsi.warm = false
+	rsi.dynHistory = rsi.dynHistory[:0]
+	rsi.levels = rsi.levels[:0]
+	rsi.history = rsi.history[:0]
+	rsi.extremes.Reset()
+	rsi.divergence.Reset()
+}
+
+// ValueAtPercentile returns the p-th percentile (p in [0, 1]) of the retained
+// RSI values – the last WithHistory values when configured, else the values
+// kept under SetRetentionLength – interpolating linearly between ranks.
+// ValueAtPercentile(0.95) is a data-driven overbought level.
+func (rsi *RelativeStrengthIndex) ValueAtPercentile(p float64) (float64, error) {
+	if !(p >= 0 && p <= 1) {
+		return 0, fmt.Errorf("percentile must be within [0, 1], got %v", p)
+	}
+	values := rsi.rsiValues
+	if rsi.historyLen > 0 {
+		values = rsi.history
+	}
+	return core.Percentile(values, p*100)
+}
+
+// ValueHistogram bins the same retained RSI values as ValueAtPercentile
+// with core.Histogram, showing whether the RSI spends its time mid-range or
+// at the extremes. Configure WithHistory (or SetRetentionLength) for a
+// meaningful sample; by default only one period of values is retained.
+func (rsi *RelativeStrengthIndex) ValueHistogram(bins int) (edges []float64, counts []int) {
+	values := rsi.rsiValues
+	if rsi.historyLen > 0 {
+		values = rsi.history
+	}
+	return core.Histogram(values, bins)
+}
+
+// ObservedMin returns the lowest RSI value produced since construction or
+// the last Reset, or 0 before the first value. It is not limited to the
+// values GetRSIValues still retains (see SetRetentionLength).
+func (rsi *RelativeStrengthIndex) ObservedMin() float64 { return rsi.extremes.Min() }
+
+// ObservedMax returns the highest RSI value produced since construction or
+// the last Reset, or 0 before the first value.
+func (rsi *RelativeStrengthIndex) ObservedMax() float64 { return rsi.extremes.Max() }
+
+// SetOutputTransform passes every RSI value through fn before it is stored,
+// so Calculate, the crossover and divergence checks, dynamic thresholds and
+// GetPlotData all see the transformed series. The Wilder averages themselves
+// are untouched. nil restores the identity. The RSI is reset so the stored
+// series is never a mix of raw and transformed values.
+func (rsi *RelativeStrengthIndex) SetOutputTransform(fn func(float64) float64) {
+	rsi.transform = fn
+	rsi.Reset()
+}
+
+// SetMinPeriods lets the RSI emit values once n price changes exist instead
+// of waiting for a full period, like pandas' min_periods. Values produced
+// before the period fills are averaged over the partial window and should be
+// treated as approximate (see IsWarm). n must lie in [1, period]; n == period
+// restores the default behaviour.
+func (rsi *RelativeStrengthIndex) SetMinPeriods(n int) error {
+	if n < 1 || n > rsi.period {
+		return fmt.Errorf("min periods must be within [1, %d], got %d", rsi.period, n)
+	}
+	rsi.minPeriods = n
+	return nil
+}
+
+// IsWarm reports whether the latest RSI value is based on a full period.
+func (rsi *RelativeStrengthIndex) IsWarm() bool { return rsi.warm }
+
+// emitAfter returns the number of price changes needed before a value is
+// emitted. A min-periods setting above a since-lowered period is capped.
+func (rsi *RelativeStrengthIndex) emitAfter() int {
+	if rsi.minPeriods > 0 && rsi.minPeriods < rsi.period {
+		return rsi.minPeriods
+	}
+	return rsi.period
 }
 
 // SetPeriod updates the calculation period (and trims slices accordingly).
@@ -231,6 +596,7 @@ func (rsi *RelativeStrengthIndex) SetPeriod(period int) error {
 	// Changing the period invalidates the existing smoothed averages.
 	rsi.avgGain = 0
 	rsi.avgLoss = 0
+	rsi.warm = false
 	return nil
 }
 
@@ -239,11 +605,48 @@ func (rsi *RelativeStrengthIndex) GetCloses() []float64 {
 	return core.CopySlice(rsi.closes)
 }
 
+// GetReturns returns the simple bar-to-bar returns of the retained closes.
+// Closes are trimmed to period+1, so at most period returns are available.
+func (rsi *RelativeStrengthIndex) GetReturns() []float64 {
+	return core.Returns(rsi.closes)
+}
+
+// GetLogReturns returns the log returns of the retained closes, subject to
+// the same period-bound history as GetReturns.
+func (rsi *RelativeStrengthIndex) GetLogReturns() []float64 {
+	return core.LogReturns(rsi.closes)
+}
+
 // GetRSIValues returns a copy of the calculated RSI values.
 func (rsi *RelativeStrengthIndex) GetRSIValues() []float64 {
 	return core.CopySlice(rsi.rsiValues)
 }
 
+// FirstValueBarIndex returns the index of the bar that produced the first
+// value in GetRSIValues, counting accepted bars from 0 since construction or
+// the last Reset, so value i belongs to bar FirstValueBarIndex()+i. It is -1
+// while no value is retained.
+func (rsi *RelativeStrengthIndex) FirstValueBarIndex() int {
+	if len(rsi.rsiValues) == 0 {
+		return -1
+	}
+	return rsi.bars - len(rsi.rsiValues)
+}
+
+// OutputSlope returns how far the RSI moved over the last n bars. It reads the
+// retained RSI values, so n must be below the retention length (the period
+// unless SetRetentionLength raised it).
+func (rsi *RelativeStrengthIndex) OutputSlope(n int) (float64, error) {
+	return core.OutputSlope(rsi.rsiValues, n)
+}
+
+// ValueInterpolated returns a point frac of the way from the previous RSI
+// value to the latest one, for intrabar chart animation. frac must be in
+// [0, 1]; the RSI itself is not modified.
+func (rsi *RelativeStrengthIndex) ValueInterpolated(frac float64) (float64, error) {
+	return core.InterpolateLast(rsi.rsiValues, frac)
+}
+
 // GetPlotData prepares data for visualisation, including signal annotations.
 func (rsi *RelativeStrengthIndex) GetPlotData(startTime, interval int64) []core.PlotData {
 	var plotData []core.PlotData
@@ -256,19 +659,25 @@ func (rsi *RelativeStrengthIndex) GetPlotData(startTime, interval int64) []core.
 
 	for i := range rsi.rsiValues {
 		x[i] = float64(i)
+		// Judge each bar against the levels in effect when it was produced, so
+		// dynamic thresholds do not repaint earlier markers.
+		overbought, oversold := rsi.Thresholds()
+		if i < len(rsi.levels) {
+			overbought, oversold = rsi.levels[i][0], rsi.levels[i][1]
+		}
 
 		if i > 0 {
 			// Detect crossovers for signalling.
-			if rsi.rsiValues[i-1] <= rsi.config.RSIOversold && rsi.rsiValues[i] > rsi.config.RSIOversold {
+			if rsi.rsiValues[i-1] <= oversold && rsi.rsiValues[i] > oversold {
 				signals[i] = 1 // bullish
-			} else if rsi.rsiValues[i-1] >= rsi.config.RSIOverbought && rsi.rsiValues[i] < rsi.config.RSIOverbought {
+			} else if rsi.rsiValues[i-1] >= overbought && rsi.rsiValues[i] < overbought {
 				signals[i] = -1 // bearish
 			}
 		}
 		// Persistent overbought/oversold markers.
-		if rsi.rsiValues[i] > rsi.config.RSIOverbought {
+		if rsi.rsiValues[i] > overbought {
 			signals[i] = 2
-		} else if rsi.rsiValues[i] < rsi.config.RSIOversold {
+		} else if rsi.rsiValues[i] < oversold {
 			signals[i] = -2
 		}
 	}
@@ -289,3 +698,34 @@ func (rsi *RelativeStrengthIndex) GetPlotData(startTime, interval int64) []core.
 	})
 	return plotData
 }
+
+// GetPlotDataRange returns the RSI plot data for bars [from, to).
+func (rsi *RelativeStrengthIndex) GetPlotDataRange(startTime, interval int64, from, to int) []core.PlotData {
+	return core.SlicePlotData(rsi.GetPlotData(startTime, interval), from, to)
+}
+
+// GetPlotDataV2 returns the RSI line with its signals as typed markers
+// ("bullish_cross", "overbought", …) rather than GetPlotData's numeric codes.
+func (rsi *RelativeStrengthIndex) GetPlotDataV2(startTime, interval int64) core.PlotDataWithMarkers {
+	return core.ExtractMarkers(rsi.GetPlotData(startTime, interval), core.ZoneMarkerKinds)
+}
+
+// Describe reports the RSI's live parameters, including the dynamic threshold
+// settings when WithDynamicThresholds is active.
+func (rsi *RelativeStrengthIndex) Describe() core.IndicatorInfo {
+	params := map[string]any{
+		"period":     rsi.period,
+		"overbought": rsi.config.RSIOverbought,
+		"oversold":   rsi.config.RSIOversold,
+		"minPeriods": rsi.emitAfter(),
+	}
+	if rsi.cutler {
+		params["method"] = "cutler"
+	}
+	if rsi.dynWindow > 0 {
+		params["dynamicWindow"] = rsi.dynWindow
+		params["dynamicHighPct"] = rsi.dynHiPct
+		params["dynamicLowPct"] = rsi.dynLoPct
+	}
+	return core.IndicatorInfo{Name: "RSI", Params: params, SamplesNeeded: rsi.emitAfter() + 1}
+}
@@ -2,9 +2,13 @@ package momentum
 
 import (
 	"errors"
+	"math"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/evdnx/goti/config"
+	"github.com/evdnx/goti/indicator/core"
 )
 
 // ---------------------------------------------------------------------------
@@ -412,3 +416,807 @@ func TestRSI_GetPlotData(t *testing.T) {
 		t.Fatalf("RSI PlotData length mismatch")
 	}
 }
+
+func TestRSI_GetPlotDataRange(t *testing.T) {
+	rsi, err := NewRelativeStrengthIndexWithParams(14, config.DefaultConfig())
+	if err != nil {
+		t.Fatalf("constructor error: %v", err)
+	}
+	for i := 0; i < 40; i++ {
+		_ = rsi.Add(float64(10 + i%7))
+	}
+	full := rsi.GetPlotData(1609459200, 60)
+	data := rsi.GetPlotDataRange(1609459200, 60, 3, 8)
+	if len(data) == 0 || data[0].Name != "Relative Strength Index" {
+		t.Fatalf("expected RSI line series, got %+v", data)
+	}
+	line := data[0]
+	if len(line.X) != 5 || len(line.Y) != 5 || len(line.Timestamp) != 5 {
+		t.Fatalf("expected 5 points, got x=%d y=%d ts=%d", len(line.X), len(line.Y), len(line.Timestamp))
+	}
+	if line.Timestamp[0] != full[0].Timestamp[3] || line.Y[4] != full[0].Y[7] {
+		t.Fatalf("range not aligned with full export")
+	}
+}
+
+func TestRSI_GetReturns(t *testing.T) {
+	rsi := newDefaultRSI(t)
+	for _, p := range []float64{100, 102, 101, 105, 104, 108, 110, 107} {
+		if err := rsi.Add(p); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	closes := rsi.GetCloses()
+	simple := rsi.GetReturns()
+	logs := rsi.GetLogReturns()
+	if len(simple) != len(closes)-1 || len(logs) != len(closes)-1 {
+		t.Fatalf("expected %d returns, got %d simple / %d log", len(closes)-1, len(simple), len(logs))
+	}
+	for i := 1; i < len(closes); i++ {
+		if !approxEqual(simple[i-1], closes[i]/closes[i-1]-1) {
+			t.Fatalf("simple return %d mismatch: %v", i-1, simple[i-1])
+		}
+		if !approxEqual(logs[i-1], math.Log(closes[i]/closes[i-1])) {
+			t.Fatalf("log return %d mismatch: %v", i-1, logs[i-1])
+		}
+	}
+}
+
+func TestRSI_WithDynamicThresholds(t *testing.T) {
+	cfg := config.DefaultConfig()
+	if _, err := NewRelativeStrengthIndexWithParams(5, cfg, WithDynamicThresholds(1, 90, 10)); err == nil {
+		t.Fatal("expected error for window < 2")
+	}
+	if _, err := NewRelativeStrengthIndexWithParams(5, cfg, WithDynamicThresholds(20, 10, 90)); err == nil {
+		t.Fatal("expected error when lo >= hi")
+	}
+
+	rsi, err := NewRelativeStrengthIndexWithParams(5, cfg, WithDynamicThresholds(20, 90, 10))
+	if err != nil {
+		t.Fatalf("constructor error: %v", err)
+	}
+	if ob, os := rsi.Thresholds(); ob != cfg.RSIOverbought || os != cfg.RSIOversold {
+		t.Fatalf("expected fixed thresholds before the window fills, got %v/%v", ob, os)
+	}
+
+	// Strong uptrend with shallow pullbacks keeps RSI persistently high.
+	price := 100.0
+	for i := 0; i < 40; i++ {
+		if i%4 == 3 {
+			price -= 0.5
+		} else {
+			price += 2
+		}
+		if err := rsi.Add(price); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	ob, os := rsi.Thresholds()
+	if ob <= 70 {
+		t.Fatalf("expected dynamic overbought above 70, got %.2f", ob)
+	}
+	if os <= cfg.RSIOversold {
+		t.Fatalf("expected dynamic oversold above the fixed level, got %.2f", os)
+	}
+	if zone, _ := rsi.GetOverboughtOversold(); zone == "Overbought" && rsi.GetLastValue() <= ob {
+		t.Fatalf("zone must use dynamic level %.2f, RSI %.2f", ob, rsi.GetLastValue())
+	}
+}
+
+func TestRSI_Describe(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.RSIOverbought = 75
+	rsi, err := NewRelativeStrengthIndexWithParams(7, cfg)
+	if err != nil {
+		t.Fatalf("constructor error: %v", err)
+	}
+	info := rsi.Describe()
+	if info.Name != "RSI" {
+		t.Fatalf("unexpected name %q", info.Name)
+	}
+	if info.Params["period"] != 7 || info.Params["overbought"] != 75.0 || info.Params["oversold"] != 30.0 {
+		t.Fatalf("unexpected params %+v", info.Params)
+	}
+	if _, ok := info.Params["dynamicWindow"]; ok {
+		t.Fatal("dynamic threshold params should be omitted when disabled")
+	}
+	if info.SamplesNeeded != 8 {
+		t.Fatalf("expected SamplesNeeded 8, got %d", info.SamplesNeeded)
+	}
+
+	for i := 0; i < info.SamplesNeeded-1; i++ {
+		_ = rsi.Add(100 + float64(i))
+	}
+	if _, err := rsi.Calculate(); err == nil {
+		t.Fatal("RSI should not be ready one sample short of SamplesNeeded")
+	}
+	_ = rsi.Add(110)
+	if _, err := rsi.Calculate(); err != nil {
+		t.Fatalf("RSI should be ready after SamplesNeeded samples: %v", err)
+	}
+}
+
+// divergenceShape is a rally, a fast drop to a pivot low, a bounce and a
+// five-bar slide of `slide` per bar to a lower low with a three-bar rebound:
+// with a period-5 RSI the second low has the higher RSI, a bullish pivot
+// divergence.
+func divergenceShape(slide float64) []float64 {
+	shape := []float64{
+		0.5, 1, 1.5, 2, 2.5, 3, 3.5, 4, // rally
+		2, 0, -2, -4, -6, // fast drop to the first low
+		-4.5, -3, -1.5, 0, // bounce
+	}
+	for k := 1; k <= 5; k++ {
+		shape = append(shape, -slide*float64(k))
+	}
+	low := -5 * slide
+	return append(shape, low+1, low+2, low+3)
+}
+
+func TestRSI_IsDivergenceWithStrength(t *testing.T) {
+	run := func(shape []float64, scale float64) (*RelativeStrengthIndex, string, float64) {
+		rsi := newDefaultRSI(t)
+		_ = rsi.SetRetentionLength(len(shape))
+		for _, d := range shape {
+			if err := rsi.Add(100 + scale*d); err != nil {
+				t.Fatalf("Add failed: %v", err)
+			}
+		}
+		dir, strength, err := rsi.IsDivergenceWithStrength()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return rsi, dir, strength
+	}
+	_, shallowDir, shallow := run(divergenceShape(1.4), 1)
+	_, steepDir, steep := run(divergenceShape(2.2), 1)
+	if shallowDir != "Bullish" || steepDir != "Bullish" {
+		t.Fatalf("expected bullish divergences, got %q / %q", shallowDir, steepDir)
+	}
+	if shallow <= 0 || steep <= shallow {
+		t.Fatalf("a deeper lower low must score higher: steep %v, shallow %v", steep, shallow)
+	}
+
+	// Both legs are measured against their own range, so stretching the
+	// moves around 100 – which leaves the RSI unchanged – keeps the strength.
+	plainRSI, _, plain := run(divergenceShape(1.4), 1)
+	stretchedRSI, _, stretched := run(divergenceShape(1.4), 3)
+	a, b := plainRSI.GetRSIValues(), stretchedRSI.GetRSIValues()
+	for i := range a {
+		if !approxEqual(a[i], b[i]) {
+			t.Fatalf("scaling the moves should not change the RSI: %v vs %v", a, b)
+		}
+	}
+	if !approxEqual(plain, stretched) {
+		t.Fatalf("strength should not depend on the price scale: %v vs %v", plain, stretched)
+	}
+
+	rsi := newDefaultRSI(t)
+	if _, _, err := rsi.IsDivergenceWithStrength(); err == nil {
+		t.Fatal("expected an error before enough bars exist")
+	}
+	for i := 0; i < 30; i++ {
+		_ = rsi.Add(100 + float64(i) + 2*float64(i%3))
+	}
+	if dir, strength, err := rsi.IsDivergenceWithStrength(); err != nil || dir != "" || strength != 0 {
+		t.Fatalf("expected no divergence in a rising market, got %q %v %v", dir, strength, err)
+	}
+}
+
+func TestRSI_OutputSlope(t *testing.T) {
+	rsi, _ := NewRelativeStrengthIndex()
+	if _, err := rsi.OutputSlope(1); err == nil {
+		t.Fatal("expected error without RSI values")
+	}
+	rsi.rsiValues = []float64{40, 42.5, 45, 47.5}
+	got, err := rsi.OutputSlope(1)
+	if err != nil || !approxEqual(got, 2.5) {
+		t.Fatalf("expected slope 2.5, got %v (%v)", got, err)
+	}
+}
+
+func TestRSI_SetMinPeriods(t *testing.T) {
+	rsi, _ := NewRelativeStrengthIndexWithParams(14, config.DefaultConfig())
+	if err := rsi.SetMinPeriods(15); err == nil {
+		t.Fatal("expected error for min periods above the period")
+	}
+	if err := rsi.SetMinPeriods(2); err != nil {
+		t.Fatalf("SetMinPeriods failed: %v", err)
+	}
+	if got := rsi.Describe().SamplesNeeded; got != 3 {
+		t.Fatalf("expected Describe to report 3 samples needed, got %d", got)
+	}
+	ref, _ := NewRelativeStrengthIndexWithParams(14, config.DefaultConfig())
+
+	closes := []float64{100, 102, 101, 103, 104, 102, 105, 107, 106, 108, 110, 109, 111, 112, 110, 113}
+	for i, c := range closes {
+		_ = rsi.Add(c)
+		_ = ref.Add(c)
+		_, err := rsi.Calculate()
+		switch {
+		case i < 2 && err == nil:
+			t.Fatalf("bar %d: expected no value yet", i+1)
+		case i >= 2 && err != nil:
+			t.Fatalf("bar %d: expected an early value, got %v", i+1, err)
+		}
+		if rsi.IsWarm() != (i >= 14) {
+			t.Fatalf("bar %d: unexpected warm state %v", i+1, rsi.IsWarm())
+		}
+	}
+	// Once warm the partial start must not affect the Wilder series.
+	got, _ := rsi.Calculate()
+	want, _ := ref.Calculate()
+	if !approxEqual(got, want) {
+		t.Fatalf("expected %v after warm-up, got %v", want, got)
+	}
+}
+
+func TestRSI_SetOutputTransform(t *testing.T) {
+	raw := newDefaultRSI(t)
+	doubled := newDefaultRSI(t)
+	doubled.SetOutputTransform(func(v float64) float64 { return v * 2 })
+
+	closes := []float64{44, 44.3, 44.1, 44.6, 45.2, 44.9, 45.5, 45.1, 45.8, 46.0}
+	for _, c := range closes {
+		_ = raw.Add(c)
+		_ = doubled.Add(c)
+	}
+
+	got, _ := doubled.Calculate()
+	want, _ := raw.Calculate()
+	if !approxEqual(got, 2*want) {
+		t.Fatalf("Calculate: expected %v, got %v", 2*want, got)
+	}
+	rawVals, vals := raw.GetRSIValues(), doubled.GetRSIValues()
+	rawPlot, plot := raw.GetPlotData(0, 1), doubled.GetPlotData(0, 1)
+	for i := range rawVals {
+		if !approxEqual(vals[i], 2*rawVals[i]) || !approxEqual(plot[0].Y[i], 2*rawPlot[0].Y[i]) {
+			t.Fatalf("value %d not doubled: %v vs %v", i, vals[i], rawVals[i])
+		}
+	}
+
+	doubled.SetOutputTransform(nil)
+	for _, c := range closes {
+		_ = doubled.Add(c)
+	}
+	if got, _ := doubled.Calculate(); !approxEqual(got, want) {
+		t.Fatalf("nil transform should be the identity, got %v want %v", got, want)
+	}
+}
+
+func TestRSI_ObservedExtremes(t *testing.T) {
+	rsi := newDefaultRSI(t)
+	// A pure rally pins the RSI at 100; the later slide drags it down. The
+	// extremes must survive after the value slice has been trimmed.
+	for i := 0; i < 8; i++ {
+		_ = rsi.Add(100 + float64(i))
+	}
+	lowest := 100.0
+	for i := 0; i < 20; i++ {
+		_ = rsi.Add(107 - 0.5*float64(i))
+		v, _ := rsi.Calculate()
+		lowest = math.Min(lowest, v)
+	}
+	if rsi.ObservedMax() != 100 {
+		t.Fatalf("expected observed max 100, got %v", rsi.ObservedMax())
+	}
+	if rsi.ObservedMin() != lowest {
+		t.Fatalf("expected observed min %v, got %v", lowest, rsi.ObservedMin())
+	}
+	rsi.Reset()
+	if rsi.ObservedMin() != 0 || rsi.ObservedMax() != 0 {
+		t.Fatal("Reset should clear the observed extremes")
+	}
+}
+
+func TestRSI_ValueInterpolated(t *testing.T) {
+	rsi, _ := NewRelativeStrengthIndex()
+	rsi.rsiValues = []float64{50}
+	if _, err := rsi.ValueInterpolated(0.5); err == nil {
+		t.Fatal("expected error with a single RSI value")
+	}
+	rsi.rsiValues = []float64{50, 40, 60}
+	got, err := rsi.ValueInterpolated(0.5)
+	if err != nil || !approxEqual(got, 50) {
+		t.Fatalf("expected midpoint 50, got %v (%v)", got, err)
+	}
+	if _, err := rsi.ValueInterpolated(1.5); err == nil {
+		t.Fatal("expected error for fraction above 1")
+	}
+}
+
+func TestRSI_Peek(t *testing.T) {
+	rsi, _ := NewRelativeStrengthIndexWithParams(5, config.DefaultConfig())
+	for _, c := range []float64{100, 102, 101, 103, 104, 102, 105, 107} {
+		_ = rsi.Add(c)
+	}
+	before := rsi.GetRSIValues()
+	last, _ := rsi.Calculate()
+
+	peeked, err := rsi.Peek(99)
+	if err != nil {
+		t.Fatalf("Peek failed: %v", err)
+	}
+	if got, _ := rsi.Calculate(); got != last || len(rsi.GetRSIValues()) != len(before) {
+		t.Fatalf("Peek mutated state: %v -> %v", last, got)
+	}
+	_ = rsi.Add(99)
+	if got, _ := rsi.Calculate(); got != peeked {
+		t.Fatalf("Peek returned %v, Add+Calculate %v", peeked, got)
+	}
+	if _, err := rsi.Peek(-1); err == nil {
+		t.Fatal("expected error for an invalid price")
+	}
+}
+
+func TestRSI_GetPlotDataV2MatchesSignalCodes(t *testing.T) {
+	rsi, _ := NewRelativeStrengthIndexWithParams(3, config.DefaultConfig())
+	for _, c := range []float64{100, 104, 108, 112, 111, 105, 98, 92, 90, 94, 99, 103} {
+		_ = rsi.Add(c)
+	}
+	legacy := rsi.GetPlotData(0, 60)
+	v2 := rsi.GetPlotDataV2(0, 60)
+	if len(v2.Series) != 1 || v2.Series[0].Name != "Relative Strength Index" {
+		t.Fatalf("expected only the RSI line in Series, got %+v", v2.Series)
+	}
+
+	kinds := map[float64]string{1: "bullish_cross", -1: "bearish_cross", 2: "overbought", -2: "oversold"}
+	var want []core.Marker
+	for i, code := range legacy[1].Y {
+		if code != 0 {
+			want = append(want, core.Marker{Index: i, Kind: kinds[code], Value: legacy[0].Y[i]})
+		}
+	}
+	if len(want) == 0 {
+		t.Fatal("test series should produce signals")
+	}
+	if len(v2.Markers) != len(want) {
+		t.Fatalf("expected %d markers, got %d (%+v)", len(want), len(v2.Markers), v2.Markers)
+	}
+	for i := range want {
+		if v2.Markers[i] != want[i] {
+			t.Fatalf("marker %d: expected %+v, got %+v", i, want[i], v2.Markers[i])
+		}
+	}
+}
+
+func TestRSI_ComputeMatchesLoop(t *testing.T) {
+	closes := []float64{100, 102, 101, 103, 104, 102, 105, 107, 106, 108, 110, 109}
+	rsi, _ := NewRelativeStrengthIndexWithParams(5, config.DefaultConfig())
+	got := rsi.Compute(closes)
+
+	ref, _ := NewRelativeStrengthIndexWithParams(5, config.DefaultConfig())
+	var want []float64
+	for _, c := range closes {
+		_ = ref.Add(c)
+		if v, err := ref.Calculate(); err == nil {
+			want = append(want, v)
+		}
+	}
+	if len(got) != len(closes)-5 || len(got) != len(want) {
+		t.Fatalf("expected %d values, got %d (loop %d)", len(closes)-5, len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("value %d: Compute %v, loop %v", i, got[i], want[i])
+		}
+	}
+	if v, err := rsi.Calculate(); err != nil || v != want[len(want)-1] {
+		t.Fatalf("expected the RSI to stay warmed at %v, got %v (%v)", want[len(want)-1], v, err)
+	}
+}
+
+func TestRSI_ValidateFlagsNaN(t *testing.T) {
+	poisoned := func(opts ...RSIOption) *RelativeStrengthIndex {
+		rsi, err := NewRelativeStrengthIndexWithParams(5, config.DefaultConfig(), opts...)
+		if err != nil {
+			t.Fatalf("constructor error: %v", err)
+		}
+		n := 0
+		rsi.SetOutputTransform(func(v float64) float64 {
+			n++
+			if n == 3 {
+				return math.NaN()
+			}
+			return v
+		})
+		return rsi
+	}
+	closes := []float64{44, 44.3, 44.1, 44.6, 45.2, 44.9, 45.5, 45.1, 45.8}
+
+	rsi := poisoned()
+	for i, c := range closes {
+		if err := rsi.Add(c); err != nil {
+			t.Fatalf("Add %d failed without strict checking: %v", i, err)
+		}
+		if i == 6 {
+			if err := rsi.Validate(); err != nil {
+				t.Fatalf("expected clean state before the NaN, got %v", err)
+			}
+		}
+	}
+	err := rsi.Validate()
+	if !errors.Is(err, core.ErrNonFinite) || !strings.Contains(err.Error(), "rsiValues[2]") {
+		t.Fatalf("expected Validate to flag rsiValues[2], got %v", err)
+	}
+
+	strict := poisoned(WithStrictOutputCheck(true))
+	for i, c := range closes {
+		err := strict.Add(c)
+		if i == 7 {
+			if !errors.Is(err, core.ErrNonFinite) {
+				t.Fatalf("expected strict Add to fail on the NaN bar, got %v", err)
+			}
+			break
+		}
+		if err != nil {
+			t.Fatalf("Add %d failed: %v", i, err)
+		}
+	}
+}
+
+func TestRSI_ValidateFlagsScalarState(t *testing.T) {
+	rsi := newDefaultRSI(t)
+	for _, c := range []float64{44, 44.3, 44.1, 44.6, 45.2, 44.9, 45.5} {
+		if err := rsi.Add(c); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	if err := rsi.Validate(); err != nil {
+		t.Fatalf("expected clean state, got %v", err)
+	}
+	// A corrupted Wilder average poisons every later value even though the
+	// retained slices are still finite.
+	rsi.avgLoss = math.Inf(1)
+	err := rsi.Validate()
+	if !errors.Is(err, core.ErrNonFinite) || !strings.Contains(err.Error(), "avgLoss") {
+		t.Fatalf("expected Validate to flag avgLoss, got %v", err)
+	}
+}
+
+// newTriangleRSI feeds 105 closes stepping 100→105→100 by 1 into a Cutler
+// RSI(5). Each value is 20 times the up moves among the last five deltas, so
+// the RSI walks 100, 80, …, 0, 20, …, 80 every ten bars and ends at 80.
+func newTriangleRSI(t *testing.T, opts ...RSIOption) *RelativeStrengthIndex {
+	t.Helper()
+	rsi, err := NewRelativeStrengthIndexWithParams(5, config.DefaultConfig(), append(opts, WithCutlerMethod(true))...)
+	if err != nil {
+		t.Fatalf("constructor error: %v", err)
+	}
+	for i := 0; i < 105; i++ {
+		step := i % 10
+		if step > 5 {
+			step = 10 - step
+		}
+		if err := rsi.Add(100 + float64(step)); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	return rsi
+}
+
+func TestRSI_ValueAtPercentile(t *testing.T) {
+	// One full cycle: 0, 20, 20, 40, 40, 60, 60, 80, 80, 100.
+	rsi := newTriangleRSI(t, WithHistory(10))
+	if got, err := rsi.ValueAtPercentile(0.95); err != nil || !approxEqual(got, 91) {
+		t.Fatalf("expected 95th percentile 91, got %v (err %v)", got, err)
+	}
+	if got, _ := rsi.ValueAtPercentile(0.5); !approxEqual(got, 50) {
+		t.Fatalf("expected median 50, got %v", got)
+	}
+	if got, _ := rsi.ValueAtPercentile(0); !approxEqual(got, 0) {
+		t.Fatalf("expected minimum 0, got %v", got)
+	}
+	if _, err := rsi.ValueAtPercentile(95); err == nil {
+		t.Fatal("expected error for p outside [0, 1]")
+	}
+
+	// Without WithHistory only the last period values (0, 20, 40, 60, 80)
+	// are used.
+	short := newTriangleRSI(t)
+	if got, _ := short.ValueAtPercentile(0.5); !approxEqual(got, 40) {
+		t.Fatalf("expected median 40 of the retained values, got %v", got)
+	}
+}
+
+func TestRSI_WithCutlerMethod(t *testing.T) {
+	wilder, _ := NewRelativeStrengthIndexWithParams(5, config.DefaultConfig())
+	cutler, err := NewRelativeStrengthIndexWithParams(5, config.DefaultConfig(), WithCutlerMethod(true))
+	if err != nil {
+		t.Fatalf("constructor error: %v", err)
+	}
+	// A sharp early drop keeps weighing on Wilder's averages long after it has
+	// left Cutler's five-delta window.
+	closes := []float64{50, 49, 48, 40, 41, 42, 43, 44, 43, 44, 45, 46}
+	for _, c := range closes {
+		_ = wilder.Add(c)
+		_ = cutler.Add(c)
+	}
+	// Last five deltas: +1, -1, +1, +1, +1 → gains 4/5, losses 1/5, RSI 80.
+	got, _ := cutler.Calculate()
+	if !approxEqual(got, 80) {
+		t.Fatalf("expected Cutler RSI 80, got %v", got)
+	}
+	w, _ := wilder.Calculate()
+	if approxEqual(w, got) {
+		t.Fatalf("expected Wilder and Cutler RSI to diverge, both %v", w)
+	}
+	if cutler.Describe().Params["method"] != "cutler" {
+		t.Fatal("expected Describe to report the Cutler method")
+	}
+}
+
+func TestRSI_CalculateWithConfidence(t *testing.T) {
+	rsi, _ := NewRelativeStrengthIndexWithParams(5, config.DefaultConfig())
+	if _, _, err := rsi.CalculateWithConfidence(); err == nil {
+		t.Fatal("expected error before the first value")
+	}
+	prev := -1.0
+	for i := 0; i < 30; i++ {
+		_ = rsi.Add(100 + float64(i%7))
+		_, conf, err := rsi.CalculateWithConfidence()
+		if err != nil {
+			continue
+		}
+		if prev < 0 && conf != 0 {
+			t.Fatalf("first value should have confidence 0, got %v", conf)
+		}
+		if conf < prev {
+			t.Fatalf("confidence fell from %v to %v at bar %d", prev, conf, i)
+		}
+		prev = conf
+		// First value at bar 5; fully warm 3 periods (15 values) later.
+		if want := i >= 20; (conf == 1) != want {
+			t.Fatalf("bar %d: confidence %v, expected full confidence %v", i, conf, want)
+		}
+	}
+	rsi.Reset()
+	_ = rsi.Add(100)
+	if _, _, err := rsi.CalculateWithConfidence(); err == nil {
+		t.Fatal("expected Reset to clear the warm-up")
+	}
+}
+
+func TestRSI_CrossoverInclusivity(t *testing.T) {
+	// A steady decline pins the RSI at 0; with oversold 0 the first up-close
+	// "crosses" the level only under the inclusive (default) rule.
+	cfg := config.DefaultConfig()
+	cfg.RSIOversold = 0
+	for mode, want := range map[core.CrossoverInclusivity]bool{
+		core.CrossoverDefault:   true,
+		core.CrossoverInclusive: true,
+		core.CrossoverStrict:    false,
+	} {
+		rsi, _ := NewRelativeStrengthIndexWithParams(3, cfg, WithCrossoverInclusivity(mode))
+		for i := 0; i < 6; i++ {
+			_ = rsi.Add(100 - float64(i))
+		}
+		_ = rsi.Add(99)
+		got, err := rsi.IsBullishCrossover()
+		if err != nil {
+			t.Fatalf("IsBullishCrossover failed: %v", err)
+		}
+		if got != want {
+			t.Fatalf("mode %d: crossover %v, want %v", mode, got, want)
+		}
+	}
+}
+
+func TestRSI_FirstValueBarIndex(t *testing.T) {
+	rsi, _ := NewRelativeStrengthIndexWithParams(14, config.DefaultConfig())
+	if rsi.FirstValueBarIndex() != -1 {
+		t.Fatal("expected -1 before any value")
+	}
+	closes := make([]float64, 100)
+	for i := range closes {
+		closes[i] = 100 + 5*math.Sin(float64(i)/4)
+		if err := rsi.Add(closes[i]); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+		if i == 14 && rsi.FirstValueBarIndex() != 14 {
+			t.Fatalf("first RSI should belong to bar 14, got %d", rsi.FirstValueBarIndex())
+		}
+	}
+	vals := rsi.GetRSIValues()
+	first := rsi.FirstValueBarIndex()
+	if first+len(vals)-1 != len(closes)-1 {
+		t.Fatalf("first index %d + %d values does not end on bar %d", first, len(vals), len(closes)-1)
+	}
+
+	// The aligned bar must reproduce the retained value.
+	ref, _ := NewRelativeStrengthIndexWithParams(14, config.DefaultConfig())
+	for _, c := range closes[:first+1] {
+		_ = ref.Add(c)
+	}
+	if v, _ := ref.Calculate(); !approxEqual(v, vals[0]) {
+		t.Fatalf("bar %d: recomputed RSI %v, retained %v", first, v, vals[0])
+	}
+
+	rsi.Reset()
+	if rsi.FirstValueBarIndex() != -1 {
+		t.Fatal("Reset should clear the bar counter")
+	}
+}
+
+func TestRSI_ZoneTransition(t *testing.T) {
+	rsi, _ := NewRelativeStrengthIndexWithParams(3, config.DefaultConfig())
+	if _, err := rsi.ZoneTransition(); err == nil {
+		t.Fatal("expected an error before two values exist")
+	}
+	// Rally into overbought, ease out, sell off into oversold, recover. The
+	// RSI(3) reads 100, 100, 100, 50, 28.6, 17.4, 11.0, 7.0, 39.5, 60.2, 73.8
+	// from bar 3 on, against the 70/30 levels.
+	closes := []float64{100, 101, 102, 103, 104, 105, 103, 101, 99, 97, 95, 97, 99, 101}
+	want := []string{
+		4: "none", 5: "none", 6: "exited_overbought", 7: "entered_oversold",
+		8: "none", 9: "none", 10: "none", 11: "exited_oversold", 12: "none",
+		13: "entered_overbought",
+	}
+	for i, c := range closes {
+		_ = rsi.Add(c)
+		ev, err := rsi.ZoneTransition()
+		if i < 4 {
+			if err == nil {
+				t.Fatalf("bar %d: expected an error before two RSI values", i)
+			}
+			continue
+		}
+		if err != nil || ev.String() != want[i] {
+			t.Fatalf("bar %d: got %s (%v), want %s", i, ev, err, want[i])
+		}
+	}
+}
+
+func TestRSI_SetRetentionLength(t *testing.T) {
+	rsi, _ := NewRelativeStrengthIndexWithParams(14, config.DefaultConfig())
+	ref, _ := NewRelativeStrengthIndexWithParams(14, config.DefaultConfig())
+	if err := rsi.SetRetentionLength(-1); err == nil {
+		t.Fatal("expected error for negative retention")
+	}
+	if err := rsi.SetRetentionLength(100); err != nil {
+		t.Fatalf("SetRetentionLength failed: %v", err)
+	}
+	for i := 0; i < 200; i++ {
+		c := 100 + 5*math.Sin(float64(i)/4)
+		_ = rsi.Add(c)
+		_ = ref.Add(c)
+	}
+	if got := len(rsi.GetRSIValues()); got != 100 {
+		t.Fatalf("expected 100 retained RSI values, got %d", got)
+	}
+	if got := len(rsi.GetCloses()); got != 15 {
+		t.Fatalf("calculation buffer should stay at period+1 closes, got %d", got)
+	}
+	v, _ := rsi.Calculate()
+	want, _ := ref.Calculate()
+	if !approxEqual(v, want) {
+		t.Fatalf("retention changed the RSI: %v vs %v", v, want)
+	}
+	if first := rsi.FirstValueBarIndex(); first != 100 {
+		t.Fatalf("first retained value should belong to bar 100, got %d", first)
+	}
+
+	if err := rsi.SetRetentionLength(0); err != nil {
+		t.Fatalf("SetRetentionLength failed: %v", err)
+	}
+	if got := len(rsi.GetRSIValues()); got != 14 {
+		t.Fatalf("default retention should keep one period, got %d", got)
+	}
+}
+
+func TestRSI_ValueHistogram(t *testing.T) {
+	rsi := newTriangleRSI(t, WithHistory(10))
+	edges, counts := rsi.ValueHistogram(5)
+	if len(edges) != 6 || !approxEqual(edges[0], 0) || !approxEqual(edges[5], 100) {
+		t.Fatalf("unexpected edges %v", edges)
+	}
+	// Width 20 over 0, 20, 20, 40, 40, 60, 60, 80, 80, 100; the last bin also
+	// holds the maximum.
+	if want := []int{1, 2, 2, 2, 3}; !reflect.DeepEqual(counts, want) {
+		t.Fatalf("counts %v, want %v", counts, want)
+	}
+}
+
+func TestRSI_WithInvertedPrice(t *testing.T) {
+	plain, _ := NewRelativeStrengthIndexWithParams(5, config.DefaultConfig())
+	inverted, err := NewRelativeStrengthIndexWithParams(5, config.DefaultConfig(), WithInvertedPrice(true))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Uptrend with pullbacks: +2%, +2%, -1% repeated.
+	price := 100.0
+	for i := 0; i < 40; i++ {
+		price *= []float64{1.02, 1.02, 0.99}[i%3]
+		if err := plain.Add(price); err != nil {
+			t.Fatalf("plain Add: %v", err)
+		}
+		if err := inverted.Add(price); err != nil {
+			t.Fatalf("inverted Add: %v", err)
+		}
+		p, perr := plain.Calculate()
+		q, qerr := inverted.Calculate()
+		if (perr == nil) != (qerr == nil) {
+			t.Fatalf("bar %d: readiness differs (%v vs %v)", i, perr, qerr)
+		}
+		if perr != nil {
+			continue
+		}
+		// 1/x flips every move, so the inverted RSI mirrors the plain one
+		// around 50 up to the small percentage asymmetry of the reciprocal.
+		if math.Abs(p+q-100) > 1 {
+			t.Fatalf("bar %d: RSI %.3f and inverted RSI %.3f do not mirror", i, p, q)
+		}
+	}
+	if got := inverted.GetCloses(); math.Abs(got[len(got)-1]-1/price) > 1e-15 {
+		t.Fatalf("expected the inverted close %v, got %v", 1/price, got[len(got)-1])
+	}
+	if err := inverted.Add(0); err == nil {
+		t.Fatal("expected an error for a zero close while inverted")
+	}
+}
+
+func TestRSI_SetCrossoverThreshold(t *testing.T) {
+	rsi, _ := NewRelativeStrengthIndexWithParams(5, config.DefaultConfig())
+	if err := rsi.SetCrossoverThreshold(-1); err == nil {
+		t.Fatal("expected error for a negative threshold")
+	}
+	if err := rsi.SetCrossoverThreshold(2); err != nil {
+		t.Fatalf("SetCrossoverThreshold failed: %v", err)
+	}
+	plain, _ := NewRelativeStrengthIndexWithParams(5, config.DefaultConfig())
+	// A slide to RSI 0, a bounce that barely clears 30 (31.0), a dip and a
+	// clear recovery (55.3); then a rally, a dip just under 70 (69.2), a new
+	// high and a clear break (45.9).
+	closes := []float64{100, 99, 98, 97, 96, 95, 96.8, 95.8, 98.8, 99.8, 100.8, 101.8, 102.8, 103.8, 103, 104.3, 101.3}
+	var plainBull, plainBear, bull, bear []int
+	for i, c := range closes {
+		if err := plain.Add(c); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+		_ = rsi.Add(c)
+		if ok, _ := plain.IsBullishCrossover(); ok {
+			plainBull = append(plainBull, i)
+		}
+		if ok, _ := plain.IsBearishCrossover(); ok {
+			plainBear = append(plainBear, i)
+		}
+		if ok, _ := rsi.IsBullishCrossover(); ok {
+			bull = append(bull, i)
+		}
+		if ok, _ := rsi.IsBearishCrossover(); ok {
+			bear = append(bear, i)
+		}
+	}
+	if !reflect.DeepEqual(plainBull, []int{6, 8}) || !reflect.DeepEqual(plainBear, []int{14, 16}) {
+		t.Fatalf("unfiltered crosses: bullish %v, bearish %v", plainBull, plainBear)
+	}
+	if !reflect.DeepEqual(bull, []int{8}) || !reflect.DeepEqual(bear, []int{16}) {
+		t.Fatalf("filtered crosses: bullish %v, bearish %v; want [8] and [16]", bull, bear)
+	}
+}
+
+func TestRSI_DynamicThresholdPlotDoesNotRepaint(t *testing.T) {
+	rsi, _ := NewRelativeStrengthIndexWithParams(5, config.DefaultConfig(), WithDynamicThresholds(10, 80, 20))
+	if err := rsi.SetRetentionLength(200); err != nil {
+		t.Fatalf("SetRetentionLength failed: %v", err)
+	}
+	price := func(i int) float64 { return 100 + 5*math.Sin(float64(i)/3) + float64(i%4) }
+	for i := 0; i < 60; i++ {
+		_ = rsi.Add(price(i))
+	}
+	before := rsi.GetPlotData(0, 60)[1].Y
+	// A regime change moves the percentile thresholds a long way.
+	for i := 60; i < 90; i++ {
+		_ = rsi.Add(price(i) + 2*float64(i-59))
+	}
+	after := rsi.GetPlotData(0, 60)[1].Y
+	for i := range before {
+		if before[i] != after[i] {
+			t.Fatalf("marker %d repainted from %v to %v", i, before[i], after[i])
+		}
+	}
+	ob, os := rsi.Thresholds()
+	if ob == 70 && os == 30 {
+		t.Fatal("expected dynamic thresholds to be active")
+	}
+}
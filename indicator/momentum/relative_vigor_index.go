@@ -0,0 +1,186 @@
+package momentum
+
+import (
+	"errors"
+
+	"github.com/evdnx/goti/indicator/core"
+)
+
+const DefaultRVIPeriod = 10
+
+// rviMaxValues bounds the retained RVI and signal history.
+const rviMaxValues = 256
+
+// RelativeVigorIndex implements John Ehlers' Relative Vigor Index. Each bar's
+// close−open and high−low are smoothed with the symmetric 1-2-2-1 weights
+// over the last four bars; the RVI is the period sum of the smoothed
+// close−open divided by the period sum of the smoothed high−low. The signal
+// line applies the same 1-2-2-1 weights to the last four RVI values.
+type RelativeVigorIndex struct {
+	period int
+
+	co, hl     []float64 // last four close−open and high−low
+	nums, dens []float64 // last period smoothed close−open and high−low
+
+	rvi       []float64
+	signal    []float64
+	lastValue float64
+}
+
+// NewRelativeVigorIndex builds an RVI with the classic 10-bar period.
+func NewRelativeVigorIndex() (*RelativeVigorIndex, error) {
+	return NewRelativeVigorIndexWithParams(DefaultRVIPeriod)
+}
+
+// NewRelativeVigorIndexWithParams builds an RVI with a custom period.
+func NewRelativeVigorIndexWithParams(period int) (*RelativeVigorIndex, error) {
+	if period < 1 {
+		return nil, errors.New("period must be at least 1")
+	}
+	return &RelativeVigorIndex{
+		period: period,
+		co:     make([]float64, 0, 4),
+		hl:     make([]float64, 0, 4),
+		nums:   make([]float64, 0, period),
+		dens:   make([]float64, 0, period),
+	}, nil
+}
+
+// Add ingests a full OHLC bar. The first RVI appears after period+3 bars and
+// the first signal value three bars later. When every bar in the window has
+// no range (high == low) the denominator is zero and the RVI is 0.
+func (r *RelativeVigorIndex) Add(open, high, low, close float64) error {
+	if high < low || !core.IsNonNegativePrice(open) || !core.IsNonNegativePrice(low) || !core.IsNonNegativePrice(close) {
+		return errors.New("invalid price data")
+	}
+	r.co = core.KeepLast(append(r.co, close-open), 4)
+	r.hl = core.KeepLast(append(r.hl, high-low), 4)
+	if len(r.co) < 4 {
+		return nil
+	}
+	r.nums = core.KeepLast(append(r.nums, symmetricWeighted(r.co)), r.period)
+	r.dens = core.KeepLast(append(r.dens, symmetricWeighted(r.hl)), r.period)
+	if len(r.nums) < r.period {
+		return nil
+	}
+
+	var num, den float64
+	for i := range r.nums {
+		num += r.nums[i]
+		den += r.dens[i]
+	}
+	r.lastValue = 0
+	if den != 0 {
+		r.lastValue = num / den
+	}
+	r.rvi = core.KeepLast(append(r.rvi, r.lastValue), rviMaxValues)
+	if len(r.rvi) >= 4 {
+		r.signal = core.KeepLast(append(r.signal, symmetricWeighted(r.rvi[len(r.rvi)-4:])), rviMaxValues)
+	}
+	return nil
+}
+
+// Calculate returns the latest RVI value.
+func (r *RelativeVigorIndex) Calculate() (float64, error) {
+	if len(r.rvi) == 0 {
+		return 0, errors.New("no RVI data")
+	}
+	return r.lastValue, nil
+}
+
+// GetSignal returns the latest signal line value.
+func (r *RelativeVigorIndex) GetSignal() (float64, error) {
+	if len(r.signal) == 0 {
+		return 0, errors.New("no RVI signal data")
+	}
+	return r.signal[len(r.signal)-1], nil
+}
+
+// IsBullishCrossover reports whether the RVI crossed above its signal line on
+// the latest bar.
+func (r *RelativeVigorIndex) IsBullishCrossover() (bool, error) {
+	prev, cur, err := r.signalDiffs()
+	if err != nil {
+		return false, err
+	}
+	return prev <= 0 && cur > 0, nil
+}
+
+// IsBearishCrossover reports whether the RVI crossed below its signal line on
+// the latest bar.
+func (r *RelativeVigorIndex) IsBearishCrossover() (bool, error) {
+	prev, cur, err := r.signalDiffs()
+	if err != nil {
+		return false, err
+	}
+	return prev >= 0 && cur < 0, nil
+}
+
+// signalDiffs returns RVI − signal for the previous and the latest bar.
+func (r *RelativeVigorIndex) signalDiffs() (prev, cur float64, err error) {
+	n, m := len(r.rvi), len(r.signal)
+	if m < 2 {
+		return 0, 0, errors.New("insufficient data for crossover")
+	}
+	return r.rvi[n-2] - r.signal[m-2], r.rvi[n-1] - r.signal[m-1], nil
+}
+
+// Reset clears all state while preserving the period.
+func (r *RelativeVigorIndex) Reset() {
+	r.co = r.co[:0]
+	r.hl = r.hl[:0]
+	r.nums = r.nums[:0]
+	r.dens = r.dens[:0]
+	r.rvi = r.rvi[:0]
+	r.signal = r.signal[:0]
+	r.lastValue = 0
+}
+
+// GetValues returns a defensive copy of the RVI series.
+func (r *RelativeVigorIndex) GetValues() []float64 { return core.CopySlice(r.rvi) }
+
+// GetSignalValues returns a defensive copy of the signal line.
+func (r *RelativeVigorIndex) GetSignalValues() []float64 { return core.CopySlice(r.signal) }
+
+// GetPlotData returns the RVI and, once available, its signal line with X
+// indices aligned to the RVI bars.
+func (r *RelativeVigorIndex) GetPlotData(startTime, interval int64) []core.PlotData {
+	if len(r.rvi) == 0 {
+		return nil
+	}
+	x := make([]float64, len(r.rvi))
+	for i := range x {
+		x[i] = float64(i)
+	}
+	ts := core.GenerateTimestamps(startTime, len(r.rvi), interval)
+	plots := []core.PlotData{{
+		Name:      "RVI",
+		X:         x,
+		Y:         core.CopySlice(r.rvi),
+		Type:      "line",
+		Timestamp: ts,
+	}}
+	if len(r.signal) > 0 {
+		offset := len(r.rvi) - len(r.signal)
+		plots = append(plots, core.PlotData{
+			Name:      "RVI Signal",
+			X:         x[offset:],
+			Y:         core.CopySlice(r.signal),
+			Type:      "line",
+			Timestamp: ts[offset:],
+		})
+	}
+	return plots
+}
+
+// GetPlotDataRange returns the RVI plot data for bars [from, to).
+func (r *RelativeVigorIndex) GetPlotDataRange(startTime, interval int64, from, to int) []core.PlotData {
+	return core.SlicePlotData(r.GetPlotData(startTime, interval), from, to)
+}
+
+// symmetricWeighted applies the 1-2-2-1 weights to the last four values, the
+// newest last.
+func symmetricWeighted(v []float64) float64 {
+	n := len(v)
+	return (v[n-4] + 2*v[n-3] + 2*v[n-2] + v[n-1]) / 6
+}
@@ -0,0 +1,111 @@
+package momentum
+
+import "testing"
+
+func TestRelativeVigorIndex_InvalidInput(t *testing.T) {
+	if _, err := NewRelativeVigorIndexWithParams(0); err == nil {
+		t.Fatal("expected error for period 0")
+	}
+	r, _ := NewRelativeVigorIndex()
+	if err := r.Add(10, 9, 11, 10); err == nil {
+		t.Fatal("expected error for high < low")
+	}
+}
+
+func TestRelativeVigorIndex_ClosesAboveOpens(t *testing.T) {
+	r, err := NewRelativeVigorIndexWithParams(4)
+	if err != nil {
+		t.Fatalf("constructor error: %v", err)
+	}
+	// Bars closing near their highs: close−open is 60% of the range.
+	price := 100.0
+	for i := 0; i < 6; i++ {
+		if err := r.Add(price, price+1, price-0.25, price+0.75); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+		price += 0.5
+	}
+	if _, err := r.Calculate(); err == nil {
+		t.Fatal("expected no RVI before period+3 bars")
+	}
+	r.Add(price, price+1, price-0.25, price+0.75)
+	v, err := r.Calculate()
+	if err != nil {
+		t.Fatalf("Calculate failed: %v", err)
+	}
+	if !approxEqual(v, 0.6) {
+		t.Fatalf("expected RVI 0.6, got %v", v)
+	}
+	if _, err := r.GetSignal(); err == nil {
+		t.Fatal("expected no signal before four RVI values")
+	}
+
+	// Bearish bars drag the RVI below its lagging signal line.
+	for i := 0; i < 6; i++ {
+		r.Add(price+0.75, price+1, price-0.25, price)
+		price -= 0.5
+	}
+	v, _ = r.Calculate()
+	sig, err := r.GetSignal()
+	if err != nil || v >= sig || v >= 0 {
+		t.Fatalf("expected a negative RVI below its signal, got %v / %v (%v)", v, sig, err)
+	}
+	plots := r.GetPlotData(0, 60)
+	if len(plots) != 2 || plots[1].X[len(plots[1].X)-1] != plots[0].X[len(plots[0].X)-1] {
+		t.Fatalf("expected aligned RVI and signal series, got %+v", plots)
+	}
+
+	// Flat bars leave no range: the RVI falls back to 0.
+	r.Reset()
+	for i := 0; i < 7; i++ {
+		r.Add(100, 100, 100, 100)
+	}
+	if v, err := r.Calculate(); err != nil || v != 0 {
+		t.Fatalf("expected RVI 0 on zero-range bars, got %v (%v)", v, err)
+	}
+}
+
+func TestRelativeVigorIndex_Crossover(t *testing.T) {
+	r, _ := NewRelativeVigorIndexWithParams(3)
+	if _, err := r.IsBullishCrossover(); err == nil {
+		t.Fatal("expected error before two signal values")
+	}
+
+	// Ten bars closing near their lows, then bars closing near their highs:
+	// the RVI turns first and crosses its lagging signal line from below.
+	price, bullBar, bearBars := 100.0, -1, 0
+	for i := 0; i < 20; i++ {
+		if i < 10 {
+			r.Add(price+0.75, price+1, price-0.25, price)
+			price -= 0.5
+		} else {
+			r.Add(price, price+1, price-0.25, price+0.75)
+			price += 0.5
+		}
+		up, err := r.IsBullishCrossover()
+		if err != nil {
+			continue
+		}
+		if down, _ := r.IsBearishCrossover(); down {
+			bearBars++
+		}
+		if !up {
+			continue
+		}
+		if bullBar >= 0 {
+			t.Fatalf("second bullish crossover at bar %d (first at %d)", i, bullBar)
+		}
+		bullBar = i
+		rvi, sig := r.GetValues(), r.GetSignalValues()
+		n, m := len(rvi), len(sig)
+		if !(rvi[n-2] <= sig[m-2] && rvi[n-1] > sig[m-1]) {
+			t.Fatalf("bar %d: crossover without a cross (RVI %v → %v, signal %v → %v)", i, rvi[n-2], rvi[n-1], sig[m-2], sig[m-1])
+		}
+	}
+	if bullBar < 10 {
+		t.Fatalf("expected a bullish crossover after the turn at bar 10, got %d", bullBar)
+	}
+	if bearBars != 0 {
+		t.Fatalf("expected no bearish crossover, got %d", bearBars)
+	}
+}
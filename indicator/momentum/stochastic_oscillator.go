@@ -2,6 +2,7 @@ package momentum
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/evdnx/goti/indicator/core"
 )
@@ -11,14 +12,46 @@ const (
 	DefaultStochasticDPeriod    = 3
 	DefaultStochasticOverbought = 80.0
 	DefaultStochasticOversold   = 20.0
+	DefaultStochasticSlowing    = 3
 )
 
+// StochasticMode selects one of the three standard stochastic variants.
+type StochasticMode int
+
+const (
+	// StochFast reports the raw %K and its %D average (the default).
+	StochFast StochasticMode = iota
+	// StochSlow smooths raw %K with a 3-bar SMA before %D is averaged.
+	StochSlow
+	// StochFull smooths raw %K with a configurable slowing period (see
+	// SetSlowing), so %K period, slowing and %D period are all free.
+	StochFull
+)
+
+// String returns the variant name.
+func (m StochasticMode) String() string {
+	switch m {
+	case StochFast:
+		return "fast"
+	case StochSlow:
+		return "slow"
+	case StochFull:
+		return "full"
+	}
+	return fmt.Sprintf("StochasticMode(%d)", int(m))
+}
+
 // StochasticOscillator implements a classic %K / %D stochastic oscillator.
 // %K measures the current close relative to the recent high-low range, and
-// %D is a moving average of %K.
+// %D is a moving average of %K. In the slow and full modes the reported %K
+// is itself an SMA of the raw %K (see SetMode).
 type StochasticOscillator struct {
 	kPeriod int
 	dPeriod int
+	mode    StochasticMode
+	slowing int // SMA length applied to raw %K in StochFull
+
+	rawK []float64 // last raw %K values awaiting slowing
 
 	highs  []float64
 	lows   []float64
@@ -33,6 +66,8 @@ type StochasticOscillator struct {
 	baseIndex int   // absolute index of the first element in highs/lows/closes
 	highDeque []int // monotonic deque (indices) for highs (max)
 	lowDeque  []int // monotonic deque (indices) for lows (min)
+
+	extremes core.Extremes // %K range since the last reset (see ObservedMin)
 }
 
 // NewStochasticOscillator builds a stochastic oscillator with 14/3 defaults.
@@ -49,6 +84,7 @@ func NewStochasticOscillatorWithParams(kPeriod, dPeriod int) (*StochasticOscilla
 	return &StochasticOscillator{
 		kPeriod:   kPeriod,
 		dPeriod:   dPeriod,
+		slowing:   DefaultStochasticSlowing,
 		highs:     make([]float64, 0, kPeriod+1),
 		lows:      make([]float64, 0, kPeriod+1),
 		closes:    make([]float64, 0, kPeriod+1),
@@ -74,9 +110,14 @@ func (s *StochasticOscillator) Add(high, low, close float64) error {
 	s.pushLow(idx, low)
 
 	if len(s.closes) >= s.kPeriod {
-		k := s.computeK()
+		k, ok := s.slowK(s.computeK())
+		if !ok {
+			s.trimSlices()
+			return nil
+		}
 		s.lastK = k
 		s.kValues = append(s.kValues, k)
+		s.extremes.Observe(k)
 
 		if len(s.kValues) >= s.dPeriod {
 			sum := 0.0
@@ -92,6 +133,61 @@ func (s *StochasticOscillator) Add(high, low, close float64) error {
 	return nil
 }
 
+// slowK applies the mode's slowing to a raw %K. ok is false while fewer raw
+// values than the slowing period exist.
+func (s *StochasticOscillator) slowK(raw float64) (k float64, ok bool) {
+	n := s.effectiveSlowing()
+	if n == 1 {
+		return raw, true
+	}
+	s.rawK = core.KeepLast(append(s.rawK, raw), n)
+	if len(s.rawK) < n {
+		return 0, false
+	}
+	sum := 0.0
+	for _, v := range s.rawK {
+		sum += v
+	}
+	return sum / float64(n), true
+}
+
+// effectiveSlowing returns the %K smoothing length of the current mode.
+func (s *StochasticOscillator) effectiveSlowing() int {
+	switch s.mode {
+	case StochSlow:
+		return DefaultStochasticSlowing
+	case StochFull:
+		return s.slowing
+	}
+	return 1
+}
+
+// SetMode switches between the fast, slow and full variants and resets the
+// oscillator. The fast mode, the default, keeps the raw %K.
+func (s *StochasticOscillator) SetMode(mode StochasticMode) error {
+	if mode < StochFast || mode > StochFull {
+		return fmt.Errorf("unknown stochastic mode %d", int(mode))
+	}
+	s.mode = mode
+	s.Reset()
+	return nil
+}
+
+// Mode returns the current variant.
+func (s *StochasticOscillator) Mode() StochasticMode { return s.mode }
+
+// SetSlowing sets the SMA length applied to raw %K in StochFull mode (3 by
+// default) and resets the oscillator. A slowing of 1 makes the full mode
+// behave like the fast one.
+func (s *StochasticOscillator) SetSlowing(slowing int) error {
+	if slowing < 1 {
+		return errors.New("slowing must be at least 1")
+	}
+	s.slowing = slowing
+	s.Reset()
+	return nil
+}
+
 // Calculate returns the latest %K and %D values.
 func (s *StochasticOscillator) Calculate() (float64, float64, error) {
 	if len(s.kValues) == 0 {
@@ -124,14 +220,24 @@ func (s *StochasticOscillator) Reset() {
 	s.highs = s.highs[:0]
 	s.lows = s.lows[:0]
 	s.closes = s.closes[:0]
+	s.rawK = s.rawK[:0]
 	s.kValues = s.kValues[:0]
 	s.dValues = s.dValues[:0]
 	s.lastK, s.lastD = 0, 0
 	s.baseIndex = 0
 	s.highDeque = s.highDeque[:0]
 	s.lowDeque = s.lowDeque[:0]
+	s.extremes.Reset()
 }
 
+// ObservedMin returns the lowest %K value produced since construction or
+// the last Reset, or 0 before the first value.
+func (s *StochasticOscillator) ObservedMin() float64 { return s.extremes.Min() }
+
+// ObservedMax returns the highest %K value produced since construction or
+// the last Reset, or 0 before the first value.
+func (s *StochasticOscillator) ObservedMax() float64 { return s.extremes.Max() }
+
 // SetPeriods updates %K and %D periods and resets the oscillator.
 func (s *StochasticOscillator) SetPeriods(kPeriod, dPeriod int) error {
 	if kPeriod < 1 || dPeriod < 1 {
@@ -181,6 +287,11 @@ func (s *StochasticOscillator) GetPlotData(startTime, interval int64) []core.Plo
 	return plots
 }
 
+// GetPlotDataRange returns the stochastic plot data for bars [from, to).
+func (s *StochasticOscillator) GetPlotDataRange(startTime, interval int64, from, to int) []core.PlotData {
+	return core.SlicePlotData(s.GetPlotData(startTime, interval), from, to)
+}
+
 func (s *StochasticOscillator) computeK() float64 {
 	if len(s.highDeque) == 0 || len(s.lowDeque) == 0 {
 		return 0
@@ -254,3 +365,21 @@ func (s *StochasticOscillator) dropOutdatedIndices() {
 		s.lowDeque = s.lowDeque[1:]
 	}
 }
+
+// Describe reports the %K/%D periods, the mode with its effective slowing
+// and the fixed zone levels.
+func (s *StochasticOscillator) Describe() core.IndicatorInfo {
+	slowing := s.effectiveSlowing()
+	return core.IndicatorInfo{
+		Name: "Stochastic",
+		Params: map[string]any{
+			"kPeriod":    s.kPeriod,
+			"dPeriod":    s.dPeriod,
+			"mode":       s.mode.String(),
+			"slowing":    slowing,
+			"overbought": DefaultStochasticOverbought,
+			"oversold":   DefaultStochasticOversold,
+		},
+		SamplesNeeded: s.kPeriod + slowing + s.dPeriod - 2,
+	}
+}
@@ -72,3 +72,61 @@ func TestStochasticOscillator_OverboughtOversold(t *testing.T) {
 		t.Fatal("expected oversold after drop")
 	}
 }
+
+func TestStochasticOscillator_SlowAndFullModes(t *testing.T) {
+	feed := func(mode StochasticMode, slowing int) *StochasticOscillator {
+		stoch, err := NewStochasticOscillatorWithParams(5, 3)
+		if err != nil {
+			t.Fatalf("constructor error: %v", err)
+		}
+		if err := stoch.SetSlowing(slowing); err != nil {
+			t.Fatalf("SetSlowing error: %v", err)
+		}
+		if err := stoch.SetMode(mode); err != nil {
+			t.Fatalf("SetMode error: %v", err)
+		}
+		for i := 0; i < 12; i++ {
+			c := 100 + float64(i%4)*2 - float64(i%3)
+			if err := stoch.Add(c+1+float64(i%2), c-1, c); err != nil {
+				t.Fatalf("Add failed at bar %d: %v", i, err)
+			}
+		}
+		return stoch
+	}
+
+	fast := feed(StochFast, 2).GetKValues()
+	for _, tc := range []struct {
+		mode    StochasticMode
+		slowing int
+		window  int
+	}{
+		{StochSlow, 2, DefaultStochasticSlowing}, // slowing only applies to full
+		{StochFull, 2, 2},
+	} {
+		stoch := feed(tc.mode, tc.slowing)
+		slow := stoch.GetKValues()
+		if len(slow) != len(fast)-tc.window+1 {
+			t.Fatalf("%v: got %d %%K values, want %d", tc.mode, len(slow), len(fast)-tc.window+1)
+		}
+		for i, v := range slow {
+			sum := 0.0
+			for _, f := range fast[i : i+tc.window] {
+				sum += f
+			}
+			if !approxEqual(v, sum/float64(tc.window)) {
+				t.Fatalf("%v: %%K[%d] = %v, want SMA %v of fast %%K", tc.mode, i, v, sum/float64(tc.window))
+			}
+		}
+		if got := stoch.Describe().SamplesNeeded; got != 5+tc.window+3-2 {
+			t.Fatalf("%v: SamplesNeeded = %d", tc.mode, got)
+		}
+	}
+
+	stoch, _ := NewStochasticOscillator()
+	if err := stoch.SetMode(StochasticMode(7)); err == nil {
+		t.Fatal("expected an error for an unknown mode")
+	}
+	if err := stoch.SetSlowing(0); err == nil {
+		t.Fatal("expected an error for zero slowing")
+	}
+}
@@ -0,0 +1,174 @@
+// Package stats holds statistical estimators that describe a price series
+// rather than generate trading signals directly.
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/evdnx/goti/indicator/core"
+)
+
+const (
+	DefaultDominantCycleWindow  = 96
+	DefaultDominantCycleMinLag  = 4
+	DefaultDominantCycleMaxLag  = 48
+	dominantCyclePeakTolerance  = 0.9
+	dominantCycleMinCorrelation = 0.0
+)
+
+// DominantCycle estimates the dominant cycle length of a series from its
+// autocorrelation. Each update detrends the last `window` closes with a
+// least-squares line, computes the Pearson correlation of the series with
+// itself at every lag in [minLag, maxLag], and reports the first local peak
+// that reaches 90% of the strongest correlation. Preferring the first strong
+// peak keeps harmonics (2×, 3× the true period) from winning ties.
+//
+// Each update costs O(window × (maxLag-minLag)); both are bounded by the
+// constructor, so the cost per bar is fixed.
+type DominantCycle struct {
+	window int
+	minLag int
+	maxLag int
+
+	closes []float64
+	cycle  int
+	ready  bool
+}
+
+// NewDominantCycle creates an estimator with a 96-bar window searching lags
+// 4 through 48.
+func NewDominantCycle() (*DominantCycle, error) {
+	return NewDominantCycleWithParams(DefaultDominantCycleWindow, DefaultDominantCycleMinLag, DefaultDominantCycleMaxLag)
+}
+
+// NewDominantCycleWithParams creates an estimator with a custom window and
+// lag range. maxLag may not exceed half the window so every correlation is
+// computed over at least window/2 overlapping samples.
+func NewDominantCycleWithParams(window, minLag, maxLag int) (*DominantCycle, error) {
+	if minLag < 2 {
+		return nil, errors.New("minLag must be at least 2")
+	}
+	if maxLag <= minLag {
+		return nil, errors.New("maxLag must be greater than minLag")
+	}
+	if window < 2*maxLag {
+		return nil, fmt.Errorf("window must be at least 2*maxLag (%d), got %d", 2*maxLag, window)
+	}
+	return &DominantCycle{
+		window: window,
+		minLag: minLag,
+		maxLag: maxLag,
+		closes: make([]float64, 0, window+1),
+	}, nil
+}
+
+// Add appends a close and refreshes the estimate once the window is full.
+func (dc *DominantCycle) Add(close float64) error {
+	if !core.IsValidPrice(close) {
+		return errors.New("invalid price: close must be positive")
+	}
+	dc.closes = append(dc.closes, close)
+	dc.closes = core.KeepLast(dc.closes, dc.window)
+	if len(dc.closes) < dc.window {
+		return nil
+	}
+	if cycle, ok := dc.estimate(); ok {
+		dc.cycle = cycle
+		dc.ready = true
+	}
+	return nil
+}
+
+// CycleLength returns the most recent dominant-cycle estimate in bars.
+func (dc *DominantCycle) CycleLength() (int, error) {
+	if len(dc.closes) < dc.window {
+		return 0, fmt.Errorf("insufficient data: need %d, have %d", dc.window, len(dc.closes))
+	}
+	if !dc.ready {
+		return 0, errors.New("no dominant cycle detected")
+	}
+	return dc.cycle, nil
+}
+
+// Reset clears all samples while preserving the configuration.
+func (dc *DominantCycle) Reset() {
+	dc.closes = dc.closes[:0]
+	dc.cycle = 0
+	dc.ready = false
+}
+
+func (dc *DominantCycle) estimate() (int, bool) {
+	detrended := detrend(dc.closes)
+
+	corr := make([]float64, dc.maxLag+2)
+	best := math.Inf(-1)
+	for lag := dc.minLag - 1; lag <= dc.maxLag+1 && lag < len(detrended); lag++ {
+		corr[lag] = autocorrelation(detrended, lag)
+		if lag >= dc.minLag && lag <= dc.maxLag && corr[lag] > best {
+			best = corr[lag]
+		}
+	}
+	if best <= dominantCycleMinCorrelation {
+		return 0, false
+	}
+
+	for lag := dc.minLag; lag <= dc.maxLag; lag++ {
+		if corr[lag] >= corr[lag-1] && corr[lag] >= corr[lag+1] && corr[lag] >= dominantCyclePeakTolerance*best {
+			return lag, true
+		}
+	}
+	return 0, false
+}
+
+// detrend removes the least-squares line from values.
+func detrend(values []float64) []float64 {
+	n := float64(len(values))
+	var sumX, sumY, sumXY, sumXX float64
+	for i, v := range values {
+		x := float64(i)
+		sumX += x
+		sumY += v
+		sumXY += x * v
+		sumXX += x * x
+	}
+	slope := 0.0
+	if den := n*sumXX - sumX*sumX; den != 0 {
+		slope = (n*sumXY - sumX*sumY) / den
+	}
+	intercept := (sumY - slope*sumX) / n
+
+	out := make([]float64, len(values))
+	for i, v := range values {
+		out[i] = v - (intercept + slope*float64(i))
+	}
+	return out
+}
+
+// autocorrelation is the Pearson correlation between values[lag:] and
+// values[:len-lag].
+func autocorrelation(values []float64, lag int) float64 {
+	n := len(values) - lag
+	if n < 2 {
+		return 0
+	}
+	var sumA, sumB float64
+	for i := 0; i < n; i++ {
+		sumA += values[i+lag]
+		sumB += values[i]
+	}
+	meanA, meanB := sumA/float64(n), sumB/float64(n)
+	var cov, varA, varB float64
+	for i := 0; i < n; i++ {
+		da := values[i+lag] - meanA
+		db := values[i] - meanB
+		cov += da * db
+		varA += da * da
+		varB += db * db
+	}
+	if varA == 0 || varB == 0 {
+		return 0
+	}
+	return cov / math.Sqrt(varA*varB)
+}
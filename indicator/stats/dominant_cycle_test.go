@@ -0,0 +1,63 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDominantCycle_Sinusoid(t *testing.T) {
+	for _, period := range []int{10, 17, 25} {
+		dc, err := NewDominantCycle()
+		if err != nil {
+			t.Fatalf("constructor error: %v", err)
+		}
+		for i := 0; i < 150; i++ {
+			// Sinusoid riding on a gentle uptrend.
+			price := 100 + 0.05*float64(i) + 5*math.Sin(2*math.Pi*float64(i)/float64(period))
+			if err := dc.Add(price); err != nil {
+				t.Fatalf("Add failed: %v", err)
+			}
+		}
+		got, err := dc.CycleLength()
+		if err != nil {
+			t.Fatalf("period %d: unexpected error: %v", period, err)
+		}
+		if got < period-1 || got > period+1 {
+			t.Fatalf("period %d: estimate %d is off by more than one bar", period, got)
+		}
+	}
+}
+
+func TestDominantCycle_Warmup(t *testing.T) {
+	dc, _ := NewDominantCycleWithParams(40, 4, 20)
+	for i := 0; i < 39; i++ {
+		_ = dc.Add(100 + float64(i%7))
+	}
+	if _, err := dc.CycleLength(); err == nil {
+		t.Fatal("expected error before the window fills")
+	}
+	_ = dc.Add(100)
+	if _, err := dc.CycleLength(); err != nil {
+		t.Fatalf("expected an estimate once the window is full: %v", err)
+	}
+	dc.Reset()
+	if _, err := dc.CycleLength(); err == nil {
+		t.Fatal("expected error after Reset")
+	}
+}
+
+func TestDominantCycle_InvalidParams(t *testing.T) {
+	if _, err := NewDominantCycleWithParams(96, 1, 48); err == nil {
+		t.Fatal("expected error for minLag < 2")
+	}
+	if _, err := NewDominantCycleWithParams(96, 10, 10); err == nil {
+		t.Fatal("expected error for maxLag <= minLag")
+	}
+	if _, err := NewDominantCycleWithParams(50, 4, 30); err == nil {
+		t.Fatal("expected error for window < 2*maxLag")
+	}
+	dc, _ := NewDominantCycle()
+	if err := dc.Add(-1); err == nil {
+		t.Fatal("expected error for negative price")
+	}
+}
@@ -0,0 +1,139 @@
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// DefaultSharpeAnnualization is the number of daily bars in a trading year.
+const DefaultSharpeAnnualization = 252
+
+// ErrZeroVolatility is returned by Sharpe and Sortino when the window's
+// (downside) deviation is zero and the ratio is undefined.
+var ErrZeroVolatility = errors.New("zero volatility in window")
+
+// RollingSharpe computes the Sharpe and Sortino ratios of the last `period`
+// per-bar returns, e.g. the bar-to-bar returns of a strategy's equity curve.
+// Returns are taken in excess of a per-bar risk-free rate and the ratios are
+// scaled by √annualization (252 by default). Window sums are maintained
+// incrementally, so each Add is O(1).
+type RollingSharpe struct {
+	period        int
+	riskFree      float64 // per-bar risk-free rate subtracted from every return
+	annualization float64
+
+	excess []float64 // ring buffer of excess returns
+	next   int       // ring position of the oldest value once full
+
+	sum    float64 // Σ excess
+	sumSq  float64 // Σ excess²
+	downSq float64 // Σ min(excess, 0)²
+}
+
+// NewRollingSharpeWithParams creates a rolling ratio over period returns.
+// riskFree is expressed per bar (an annual rate of 4% on daily bars is about
+// 0.04/252). The period must be at least 2 for a sample deviation to exist.
+func NewRollingSharpeWithParams(period int, riskFree float64) (*RollingSharpe, error) {
+	if period < 2 {
+		return nil, errors.New("period must be at least 2")
+	}
+	if math.IsNaN(riskFree) || math.IsInf(riskFree, 0) {
+		return nil, errors.New("risk-free rate must be finite")
+	}
+	return &RollingSharpe{
+		period:        period,
+		riskFree:      riskFree,
+		annualization: DefaultSharpeAnnualization,
+		excess:        make([]float64, 0, period),
+	}, nil
+}
+
+// SetAnnualization sets the number of bars per year used to scale the ratios;
+// 1 reports them per bar. The return window is kept.
+func (rs *RollingSharpe) SetAnnualization(barsPerYear float64) error {
+	if !(barsPerYear > 0) || math.IsInf(barsPerYear, 0) {
+		return errors.New("annualization must be positive")
+	}
+	rs.annualization = barsPerYear
+	return nil
+}
+
+// Add appends a per-bar return (0.01 for +1%).
+func (rs *RollingSharpe) Add(ret float64) error {
+	if math.IsNaN(ret) || math.IsInf(ret, 0) {
+		return fmt.Errorf("invalid return %f", ret)
+	}
+	x := ret - rs.riskFree
+	if len(rs.excess) < rs.period {
+		rs.excess = append(rs.excess, x)
+	} else {
+		old := rs.excess[rs.next]
+		rs.sum -= old
+		rs.sumSq -= old * old
+		rs.downSq -= downside(old)
+		rs.excess[rs.next] = x
+		rs.next = (rs.next + 1) % rs.period
+	}
+	rs.sum += x
+	rs.sumSq += x * x
+	rs.downSq += downside(x)
+	return nil
+}
+
+// Sharpe returns mean(excess) / stddev(excess) · √annualization over the
+// window, using the sample standard deviation.
+func (rs *RollingSharpe) Sharpe() (float64, error) {
+	mean, err := rs.mean()
+	if err != nil {
+		return 0, err
+	}
+	n := float64(len(rs.excess))
+	variance := (rs.sumSq - n*mean*mean) / (n - 1)
+	if rs.negligible(variance) {
+		return 0, ErrZeroVolatility
+	}
+	return mean / math.Sqrt(variance) * math.Sqrt(rs.annualization), nil
+}
+
+// Sortino returns mean(excess) / downside deviation · √annualization, where
+// the downside deviation is √(Σ min(excess, 0)² / n). A window without any
+// negative excess return has no downside and yields ErrZeroVolatility.
+func (rs *RollingSharpe) Sortino() (float64, error) {
+	mean, err := rs.mean()
+	if err != nil {
+		return 0, err
+	}
+	dd := rs.downSq / float64(len(rs.excess))
+	if rs.negligible(dd) {
+		return 0, ErrZeroVolatility
+	}
+	return mean / math.Sqrt(dd) * math.Sqrt(rs.annualization), nil
+}
+
+// Reset clears the window while preserving the configuration.
+func (rs *RollingSharpe) Reset() {
+	rs.excess = rs.excess[:0]
+	rs.next = 0
+	rs.sum, rs.sumSq, rs.downSq = 0, 0, 0
+}
+
+func (rs *RollingSharpe) mean() (float64, error) {
+	if len(rs.excess) < rs.period {
+		return 0, fmt.Errorf("insufficient data: need %d, have %d", rs.period, len(rs.excess))
+	}
+	return rs.sum / float64(rs.period), nil
+}
+
+// negligible treats a variance that incremental round-off could produce from a
+// constant window as zero.
+func (rs *RollingSharpe) negligible(variance float64) bool {
+	return variance <= 1e-12*rs.sumSq/float64(len(rs.excess))
+}
+
+func downside(x float64) float64 {
+	if x < 0 {
+		return x * x
+	}
+	return 0
+}
@@ -0,0 +1,88 @@
+package stats
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestRollingSharpe_SteadyVersusVolatile(t *testing.T) {
+	steady, err := NewRollingSharpeWithParams(20, 0)
+	if err != nil {
+		t.Fatalf("constructor error: %v", err)
+	}
+	volatile, _ := NewRollingSharpeWithParams(20, 0)
+	if _, err := steady.Sharpe(); err == nil {
+		t.Fatal("expected error before the window fills")
+	}
+
+	for i := 0; i < 60; i++ {
+		// Same 0.1% average return; the volatile series swings ±3%.
+		_ = steady.Add(0.001 + 0.0002*math.Sin(float64(i)))
+		_ = volatile.Add(0.001 + 0.03*math.Sin(float64(i)))
+	}
+	hi, err := steady.Sharpe()
+	if err != nil {
+		t.Fatalf("Sharpe error: %v", err)
+	}
+	lo, err := volatile.Sharpe()
+	if err != nil {
+		t.Fatalf("Sharpe error: %v", err)
+	}
+	if hi < 10 {
+		t.Fatalf("expected a high annualised Sharpe for steady returns, got %.2f", hi)
+	}
+	if lo >= hi/10 {
+		t.Fatalf("expected the volatile Sharpe %.2f to be far below %.2f", lo, hi)
+	}
+
+	if _, err := steady.Sortino(); !errors.Is(err, ErrZeroVolatility) {
+		t.Fatalf("expected ErrZeroVolatility without losing bars, got %v", err)
+	}
+	sortino, err := volatile.Sortino()
+	if err != nil || sortino <= 0 {
+		t.Fatalf("expected a positive Sortino, got %.4f (%v)", sortino, err)
+	}
+}
+
+func TestRollingSharpe_MatchesBatch(t *testing.T) {
+	rs, _ := NewRollingSharpeWithParams(5, 0.0001)
+	_ = rs.SetAnnualization(1)
+	rets := []float64{0.02, -0.01, 0.015, 0.03, -0.02, 0.01, 0.005, -0.004}
+	for _, r := range rets {
+		_ = rs.Add(r)
+	}
+	window := rets[len(rets)-5:]
+	var mean, ss, down float64
+	for _, r := range window {
+		mean += (r - 0.0001) / 5
+	}
+	for _, r := range window {
+		x := r - 0.0001
+		ss += (x - mean) * (x - mean)
+		if x < 0 {
+			down += x * x
+		}
+	}
+	sharpe, _ := rs.Sharpe()
+	if want := mean / math.Sqrt(ss/4); math.Abs(sharpe-want) > 1e-9 {
+		t.Fatalf("Sharpe: expected %v, got %v", want, sharpe)
+	}
+	sortino, _ := rs.Sortino()
+	if want := mean / math.Sqrt(down/5); math.Abs(sortino-want) > 1e-9 {
+		t.Fatalf("Sortino: expected %v, got %v", want, sortino)
+	}
+}
+
+func TestRollingSharpe_ZeroVolatility(t *testing.T) {
+	if _, err := NewRollingSharpeWithParams(1, 0); err == nil {
+		t.Fatal("expected error for period < 2")
+	}
+	rs, _ := NewRollingSharpeWithParams(4, 0)
+	for i := 0; i < 10; i++ {
+		_ = rs.Add(0.003)
+	}
+	if _, err := rs.Sharpe(); !errors.Is(err, ErrZeroVolatility) {
+		t.Fatalf("expected ErrZeroVolatility for constant returns, got %v", err)
+	}
+}
@@ -3,7 +3,6 @@ package trend
 import (
 	"errors"
 	"fmt"
-	"math"
 	"strings"
 
 	"github.com/evdnx/goti/config"
@@ -29,6 +28,9 @@ type AdaptiveTrendStrengthOscillator struct {
 	rawValues        []float64 // raw, unsmoothed ATSO values (used for cross‑overs)
 	ema              *core.MovingAverage
 	config           config.IndicatorConfig
+	extremes         core.Extremes // smoothed output range since the last reset
+	manualPeriod     int           // pinned look-back, 0 when adaptive
+	lastPeriod       int           // look-back used for the latest raw value
 }
 
 // NewAdaptiveTrendStrengthOscillator creates an oscillator with the “standard”
@@ -93,7 +95,7 @@ func (atso *AdaptiveTrendStrengthOscillator) Add(high, low, close float64) error
 
 	// ----- 3️⃣  Compute raw ATSO once we have at least minPeriod points -------
 	if len(atso.closes) >= atso.minPeriod {
-		raw, err := atso.calculateATSO()
+		raw, period, err := atso.calculateATSO()
 		if err != nil {
 			// If the error is due to not‑yet‑ready volatility or simply
 			// insufficient data, we wait for more bars – do **not** store a
@@ -108,6 +110,7 @@ func (atso *AdaptiveTrendStrengthOscillator) Add(high, low, close float64) error
 
 		// ----- 4️⃣  Record the genuine raw value for crossover detection -------
 		atso.rawValues = append(atso.rawValues, raw)
+		atso.lastPeriod = period
 
 		// ----- 5️⃣  Feed the raw value into the EMA ----------------------------
 		// Use AddValue because raw ATSO can be negative.
@@ -120,6 +123,8 @@ func (atso *AdaptiveTrendStrengthOscillator) Add(high, low, close float64) error
 		if err != nil {
 			// EMA not seeded yet – treat the smoothed output as zero.
 			smoothed = 0
+		} else {
+			atso.extremes.Observe(smoothed)
 		}
 		atso.atsoValues = append(atso.atsoValues, smoothed)
 	}
@@ -188,11 +193,37 @@ func (atso *AdaptiveTrendStrengthOscillator) SetVolatilitySensitivity(sens float
 	return nil
 }
 
+// SetManualPeriod pins the look-back to p bars, bypassing the volatility
+// mapping, e.g. to debug the trend-strength step or to run the ATSO as a
+// fixed-period oscillator. p need not lie within [minPeriod, maxPeriod] but
+// must be at least 2, since a single bar has no trend strength. The override
+// survives Reset.
+func (atso *AdaptiveTrendStrengthOscillator) SetManualPeriod(p int) error {
+	if p < 2 {
+		return fmt.Errorf("manual period must be at least 2, got %d", p)
+	}
+	atso.manualPeriod = p
+	return nil
+}
+
+// ClearManualPeriod restores the volatility-driven look-back.
+func (atso *AdaptiveTrendStrengthOscillator) ClearManualPeriod() { atso.manualPeriod = 0 }
+
+// CurrentAdaptivePeriod returns the look-back used for the most recent raw
+// ATSO value, which is the manual period while one is set.
+func (atso *AdaptiveTrendStrengthOscillator) CurrentAdaptivePeriod() (int, error) {
+	if atso.lastPeriod == 0 {
+		return 0, errors.New("no ATSO values calculated yet")
+	}
+	return atso.lastPeriod, nil
+}
+
 // ---------------------------------------------------------------------------
 //  Core calculation helpers
 // ---------------------------------------------------------------------------
 
-// calculateATSO computes a *raw* ATSO value for the most recent window.
+// calculateATSO computes a *raw* ATSO value for the most recent window and
+// returns it together with the look-back it used.
 // The algorithm follows the description in the original repo:
 //
 //	1️⃣  Determine an adaptive look‑back period based on recent volatility.
@@ -201,25 +232,28 @@ func (atso *AdaptiveTrendStrengthOscillator) SetVolatilitySensitivity(sens float
 //
 // The function returns an error if there isn’t enough data for the chosen
 // window or if volatility cannot be measured.
-func (atso *AdaptiveTrendStrengthOscillator) calculateATSO() (float64, error) {
-	// ---- Step 1 – adaptive period -----------------------------------------
+func (atso *AdaptiveTrendStrengthOscillator) calculateATSO() (float64, int, error) {
+	// ---- Step 1 – adaptive period -----------------------------------------
 	if len(atso.closes) < atso.minPeriod {
-		return 0, fmt.Errorf("insufficient data: need %d, have %d", atso.minPeriod, len(atso.closes))
+		return 0, 0, fmt.Errorf("insufficient data: need %d, have %d", atso.minPeriod, len(atso.closes))
 	}
 
-	// Volatility is measured as the standard deviation of log‑returns over the
-	// most recent `volatilityPeriod` bars.
-	vol, err := atso.computeVolatility()
-	if err != nil {
-		return 0, fmt.Errorf("volatility error: %w", err)
+	adaptPeriod := atso.manualPeriod
+	if adaptPeriod == 0 {
+		// Volatility is measured as the standard deviation of log‑returns
+		// over the most recent `volatilityPeriod` bars.
+		vol, err := atso.computeVolatility()
+		if err != nil {
+			return 0, 0, fmt.Errorf("volatility error: %w", err)
+		}
+		// Map volatility to a period in the range [minPeriod, maxPeriod].
+		adaptPeriod = atso.mapVolatilityToPeriod(vol)
 	}
-	// Map volatility to a period in the range [minPeriod, maxPeriod].
-	adaptPeriod := atso.mapVolatilityToPeriod(vol)
 
 	// ---- Step 2 – trend strength -------------------------------------------
 	// Need at least `adaptPeriod` points to compute the strength.
 	if len(atso.closes) < adaptPeriod {
-		return 0, fmt.Errorf("insufficient data for adaptive period %d", adaptPeriod)
+		return 0, 0, fmt.Errorf("insufficient data for adaptive period %d", adaptPeriod)
 	}
 	startIdx := len(atso.closes) - adaptPeriod
 	highs := atso.highs[startIdx:]
@@ -235,73 +269,25 @@ func (atso *AdaptiveTrendStrengthOscillator) calculateATSO() (float64, error) {
 		}
 	}
 	if upSum+downSum == 0 {
-		return 0, fmt.Errorf("division by zero in trend strength")
+		return 0, 0, fmt.Errorf("division by zero in trend strength")
 	}
 	raw := ((upSum - downSum) / (upSum + downSum)) * 100 // range ≈ [-100, +100]
-	return raw, nil
+	return raw, adaptPeriod, nil
 }
 
 // computeVolatility returns the standard deviation of log‑returns over the
-// most recent `volatilityPeriod` bars.  If fewer bars are available it falls
-// back to whatever data exists, but returns an error if the resulting slice
-// is empty (which would cause a divide‑by‑zero later).
+// most recent `volatilityPeriod` bars, falling back to whatever data exists
+// when fewer bars are available (see core.LogReturnVolatility).
 func (atso *AdaptiveTrendStrengthOscillator) computeVolatility() (float64, error) {
-	if len(atso.closes) < 2 {
-		return 0, fmt.Errorf("insufficient data for volatility")
-	}
-	// Use the smaller of the configured period and the amount of data we have.
-	n := atso.volatilityPeriod
-	if len(atso.closes)-1 < n {
-		n = len(atso.closes) - 1
-	}
-	if n <= 0 {
-		return 0, fmt.Errorf("volatility period resolved to zero")
-	}
-	start := len(atso.closes) - n - 1 // we need n+1 closes to get n returns
-	ret := make([]float64, n)
-	for i := 0; i < n; i++ {
-		ret[i] = math.Log(atso.closes[start+i+1] / atso.closes[start+i])
-	}
-	mean := 0.0
-	for _, r := range ret {
-		mean += r
-	}
-	mean /= float64(n)
-
-	var variance float64
-	for _, r := range ret {
-		diff := r - mean
-		variance += diff * diff
-	}
-	variance /= float64(n)
-	return math.Sqrt(variance), nil
+	return core.LogReturnVolatility(atso.closes, atso.volatilityPeriod)
 }
 
 // mapVolatilityToPeriod converts a volatility measurement into an adaptive
 // look‑back length.  The mapping is linear between minPeriod and maxPeriod
-// and scaled by the user‑provided volatility‑sensitivity factor.
+// and scaled by the user‑provided volatility‑sensitivity factor (see
+// core.VolatilityToPeriod).
 func (atso *AdaptiveTrendStrengthOscillator) mapVolatilityToPeriod(vol float64) int {
-	// Normalise volatility to a 0‑1 range using an arbitrary “typical” max.
-	// The constant 0.05 works well for most equity data; callers can tweak
-	// volSensitivity if they need a different response curve.
-	normalized := vol / (atso.volSensitivity * 0.05)
-	if normalized > 1 {
-		normalized = 1
-	}
-	if normalized < 0 {
-		normalized = 0
-	}
-	periodRange := float64(atso.maxPeriod - atso.minPeriod)
-	adapt := float64(atso.minPeriod) + normalized*periodRange
-	// Round to nearest integer and clamp to the allowed bounds.
-	p := int(math.Round(adapt))
-	if p < atso.minPeriod {
-		p = atso.minPeriod
-	}
-	if p > atso.maxPeriod {
-		p = atso.maxPeriod
-	}
-	return p
+	return core.VolatilityToPeriod(vol, atso.volSensitivity, atso.minPeriod, atso.maxPeriod)
 }
 
 // ---------------------------------------------------------------------------
@@ -352,6 +338,21 @@ func (atso *AdaptiveTrendStrengthOscillator) Calculate() (float64, error) {
 	return atso.atsoValues[len(atso.atsoValues)-1], nil
 }
 
+// OutputSlope returns the change in the smoothed ATSO (the series Calculate
+// reports) over the last n bars.
+func (atso *AdaptiveTrendStrengthOscillator) OutputSlope(n int) (float64, error) {
+	return core.OutputSlope(atso.atsoValues, n)
+}
+
+// ObservedMin returns the lowest smoothed ATSO value produced since construction or
+// the last Reset, or 0 before the first value. The zero
+// placeholders emitted while the EMA seeds are not counted.
+func (atso *AdaptiveTrendStrengthOscillator) ObservedMin() float64 { return atso.extremes.Min() }
+
+// ObservedMax returns the highest smoothed ATSO value produced since construction or
+// the last Reset, or 0 before the first value.
+func (atso *AdaptiveTrendStrengthOscillator) ObservedMax() float64 { return atso.extremes.Max() }
+
 // Reset clears all internal buffers and re‑initialises the EMA so the oscillator
 // can be reused from a clean state.
 func (atso *AdaptiveTrendStrengthOscillator) Reset() error {
@@ -361,6 +362,8 @@ func (atso *AdaptiveTrendStrengthOscillator) Reset() error {
 	atso.atsoValues = atso.atsoValues[:0]
 	atso.rawValues = atso.rawValues[:0]
 	atso.ema.Reset()
+	atso.extremes.Reset()
+	atso.lastPeriod = 0
 	return nil
 }
 
@@ -401,6 +404,11 @@ func (atso *AdaptiveTrendStrengthOscillator) GetPlotData() []core.PlotData {
 	}
 }
 
+// GetPlotDataRange returns the ATSO plot data for bars [from, to).
+func (atso *AdaptiveTrendStrengthOscillator) GetPlotDataRange(from, to int) []core.PlotData {
+	return core.SlicePlotData(atso.GetPlotData(), from, to)
+}
+
 // ---------------------------------------------------------------------------
 //  Crossover detection
 // ---------------------------------------------------------------------------
@@ -434,3 +442,20 @@ func (atso *AdaptiveTrendStrengthOscillator) IsBearishCrossover() bool {
 	}
 	return false
 }
+
+// Describe reports the ATSO's adaptive period bounds and smoothing length.
+// SamplesNeeded counts the bars until the EMA is seeded; Calculate reports 0
+// before that.
+func (atso *AdaptiveTrendStrengthOscillator) Describe() core.IndicatorInfo {
+	return core.IndicatorInfo{
+		Name: "ATSO",
+		Params: map[string]any{
+			"minPeriod":        atso.minPeriod,
+			"maxPeriod":        atso.maxPeriod,
+			"volatilityPeriod": atso.volatilityPeriod,
+			"volSensitivity":   atso.volSensitivity,
+			"emaPeriod":        atso.config.ATSEMAperiod,
+		},
+		SamplesNeeded: atso.minPeriod + atso.config.ATSEMAperiod - 1,
+	}
+}
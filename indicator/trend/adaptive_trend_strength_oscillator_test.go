@@ -196,6 +196,14 @@ func TestATSO_PlotData(t *testing.T) {
 	if len(data[1].Y) != len(atso.atsoValues) {
 		t.Fatalf("Signals Y length mismatch")
 	}
+
+	ranged := atso.GetPlotDataRange(1, 3)
+	if len(ranged) != 2 || len(ranged[0].X) != 2 || ranged[0].X[0] != 1 {
+		t.Fatalf("expected two series covering X 1–2, got %+v", ranged)
+	}
+	if ranged[1].Y[0] != data[1].Y[1] {
+		t.Fatalf("range misaligned: %v vs %v", ranged[1].Y, data[1].Y)
+	}
 }
 
 // Edge‑case: calling Calculate before any data should return an error.
@@ -333,3 +341,67 @@ func TestATSO_EMASeed(t *testing.T) {
 		t.Fatalf("ATSO Calculate returned %v, but EMA is %v", calcVal, emaVal)
 	}
 }
+
+func TestATSO_OutputSlope(t *testing.T) {
+	atso, _ := NewAdaptiveTrendStrengthOscillator()
+	if _, err := atso.OutputSlope(1); err == nil {
+		t.Fatal("expected error without ATSO values")
+	}
+	atso.atsoValues = []float64{0.1, 0.3, 0.5, 0.7}
+	if got, err := atso.OutputSlope(1); err != nil || !approxEqual(got, 0.2) {
+		t.Fatalf("expected slope 0.2, got %v (%v)", got, err)
+	}
+}
+
+func TestATSO_CurrentAdaptivePeriodAndManualOverride(t *testing.T) {
+	feed := func(atso *AdaptiveTrendStrengthOscillator, swing float64) {
+		for i := 0; i < 40; i++ {
+			c := 100 + 0.5*float64(i)
+			if i%2 == 1 {
+				c += swing
+			}
+			if err := atso.Add(c+1, c-1, c); err != nil {
+				t.Fatalf("Add error at bar %d: %v", i, err)
+			}
+		}
+	}
+
+	calm, _ := NewAdaptiveTrendStrengthOscillator()
+	if _, err := calm.CurrentAdaptivePeriod(); err == nil {
+		t.Fatal("expected an error before the first value")
+	}
+	feed(calm, 0)
+	volatile, _ := NewAdaptiveTrendStrengthOscillator()
+	feed(volatile, 8)
+
+	calmPeriod, err := calm.CurrentAdaptivePeriod()
+	if err != nil {
+		t.Fatalf("CurrentAdaptivePeriod error: %v", err)
+	}
+	volatilePeriod, err := volatile.CurrentAdaptivePeriod()
+	if err != nil {
+		t.Fatalf("CurrentAdaptivePeriod error: %v", err)
+	}
+	if calmPeriod == volatilePeriod {
+		t.Fatalf("expected different periods for calm and volatile series, both %d", calmPeriod)
+	}
+
+	if err := volatile.SetManualPeriod(1); err == nil {
+		t.Fatal("expected an error for a manual period below 2")
+	}
+	if err := volatile.SetManualPeriod(7); err != nil {
+		t.Fatalf("SetManualPeriod error: %v", err)
+	}
+	volatile.Reset()
+	feed(volatile, 8)
+	if p, _ := volatile.CurrentAdaptivePeriod(); p != 7 {
+		t.Fatalf("manual period not applied: got %d, want 7", p)
+	}
+
+	volatile.ClearManualPeriod()
+	volatile.Reset()
+	feed(volatile, 8)
+	if p, _ := volatile.CurrentAdaptivePeriod(); p != volatilePeriod {
+		t.Fatalf("after ClearManualPeriod got %d, want adaptive %d", p, volatilePeriod)
+	}
+}
@@ -0,0 +1,197 @@
+package trend
+
+import (
+	"errors"
+
+	"github.com/evdnx/goti/indicator/core"
+)
+
+const DefaultGannHiLoPeriod = 3
+
+// gannHiLoMaxValues bounds the retained activator history.
+const gannHiLoMaxValues = 256
+
+// GannHiLo implements the Gann HiLo Activator, a trailing line built from a
+// simple moving average of highs and one of lows. While the close stays above
+// the prior high-SMA the activator trails the low-SMA (uptrend); once the close
+// drops below the prior low-SMA it switches to the high-SMA (downtrend).
+type GannHiLo struct {
+	period int
+
+	highs   []float64
+	lows    []float64
+	highSum float64
+	lowSum  float64
+
+	prevHighSMA float64
+	prevLowSMA  float64
+	hasPrevSMA  bool
+
+	uptrend bool
+	flipped bool
+
+	values []float64
+	trends []bool
+
+	lastValue float64
+}
+
+// NewGannHiLo creates an activator with the default period (3).
+func NewGannHiLo() (*GannHiLo, error) {
+	return NewGannHiLoWithParams(DefaultGannHiLoPeriod)
+}
+
+// NewGannHiLoWithParams creates an activator with a custom SMA period.
+func NewGannHiLoWithParams(period int) (*GannHiLo, error) {
+	if period < 1 {
+		return nil, errors.New("period must be at least 1")
+	}
+	return &GannHiLo{
+		period: period,
+		highs:  make([]float64, 0, period+1),
+		lows:   make([]float64, 0, period+1),
+		values: make([]float64, 0, 16),
+		trends: make([]bool, 0, 16),
+	}, nil
+}
+
+// Add appends a new candle. Activator values are produced once period+1
+// candles have been seen, because the close is compared against the SMAs of
+// the preceding window.
+func (g *GannHiLo) Add(high, low, close float64) error {
+	if high < low {
+		return errors.New("invalid price: high < low")
+	}
+	if !core.IsValidPrice(high) || !core.IsValidPrice(low) || !core.IsValidPrice(close) {
+		return errors.New("invalid price: all prices must be positive")
+	}
+
+	g.highs = append(g.highs, high)
+	g.lows = append(g.lows, low)
+	g.highSum += high
+	g.lowSum += low
+	if len(g.highs) > g.period {
+		g.highSum -= g.highs[0]
+		g.lowSum -= g.lows[0]
+		g.highs = core.KeepLast(g.highs, g.period)
+		g.lows = core.KeepLast(g.lows, g.period)
+	}
+	if len(g.highs) < g.period {
+		return nil
+	}
+
+	highSMA := g.highSum / float64(g.period)
+	lowSMA := g.lowSum / float64(g.period)
+
+	if g.hasPrevSMA {
+		g.flipped = false
+		switch {
+		case len(g.values) == 0:
+			// Seed the direction from where the close sits relative to the
+			// prior channel midpoint.
+			g.uptrend = close >= (g.prevHighSMA+g.prevLowSMA)/2
+		case !g.uptrend && close > g.prevHighSMA:
+			g.uptrend = true
+			g.flipped = true
+		case g.uptrend && close < g.prevLowSMA:
+			g.uptrend = false
+			g.flipped = true
+		}
+
+		value := highSMA
+		if g.uptrend {
+			value = lowSMA
+		}
+		g.values = append(g.values, value)
+		g.trends = append(g.trends, g.uptrend)
+		g.lastValue = value
+		g.values = core.KeepLast(g.values, gannHiLoMaxValues)
+		g.trends = core.KeepLast(g.trends, gannHiLoMaxValues)
+	}
+
+	g.prevHighSMA = highSMA
+	g.prevLowSMA = lowSMA
+	g.hasPrevSMA = true
+	return nil
+}
+
+// Calculate returns the latest activator value and whether it is currently
+// trailing an uptrend.
+func (g *GannHiLo) Calculate() (float64, bool, error) {
+	if len(g.values) == 0 {
+		return 0, false, errors.New("no Gann HiLo data")
+	}
+	return g.lastValue, g.uptrend, nil
+}
+
+// JustFlipped reports whether the most recent candle reversed the trend.
+func (g *GannHiLo) JustFlipped() bool { return g.flipped }
+
+// Reset clears internal state while preserving the period.
+func (g *GannHiLo) Reset() {
+	g.highs = g.highs[:0]
+	g.lows = g.lows[:0]
+	g.highSum, g.lowSum = 0, 0
+	g.prevHighSMA, g.prevLowSMA = 0, 0
+	g.hasPrevSMA = false
+	g.uptrend = false
+	g.flipped = false
+	g.values = g.values[:0]
+	g.trends = g.trends[:0]
+	g.lastValue = 0
+}
+
+// SetPeriod updates the SMA period and resets the indicator.
+func (g *GannHiLo) SetPeriod(period int) error {
+	if period < 1 {
+		return errors.New("period must be at least 1")
+	}
+	g.period = period
+	g.Reset()
+	return nil
+}
+
+// GetValues returns the activator series (defensive copy).
+func (g *GannHiLo) GetValues() []float64 { return core.CopySlice(g.values) }
+
+// GetPlotData returns the activator line plus a scatter series marking trend
+// flips (+1 to uptrend, -1 to downtrend).
+func (g *GannHiLo) GetPlotData(startTime, interval int64) []core.PlotData {
+	if len(g.values) == 0 {
+		return nil
+	}
+	x := make([]float64, len(g.values))
+	signals := make([]float64, len(g.values))
+	for i := range x {
+		x[i] = float64(i)
+		if i > 0 && g.trends[i] != g.trends[i-1] {
+			if g.trends[i] {
+				signals[i] = 1
+			} else {
+				signals[i] = -1
+			}
+		}
+	}
+	ts := core.GenerateTimestamps(startTime, len(g.values), interval)
+	return []core.PlotData{
+		{
+			Name:      "Gann HiLo",
+			X:         x,
+			Y:         core.CopySlice(g.values),
+			Type:      "line",
+			Timestamp: ts,
+		},
+		{
+			Name:      "Signals",
+			X:         x,
+			Y:         signals,
+			Type:      "scatter",
+			Timestamp: ts,
+		},
+	}
+}
+
+// GetPlotDataRange returns the Gann HiLo activator plot data for bars [from, to).
+func (g *GannHiLo) GetPlotDataRange(startTime, interval int64, from, to int) []core.PlotData {
+	return core.SlicePlotData(g.GetPlotData(startTime, interval), from, to)
+}
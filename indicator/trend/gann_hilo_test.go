@@ -0,0 +1,64 @@
+package trend
+
+import "testing"
+
+func TestGannHiLo_InvalidPeriod(t *testing.T) {
+	if _, err := NewGannHiLoWithParams(0); err == nil {
+		t.Fatal("expected error for period 0")
+	}
+}
+
+func TestGannHiLo_FlipsOnCrossover(t *testing.T) {
+	g, err := NewGannHiLoWithParams(3)
+	if err != nil {
+		t.Fatalf("constructor error: %v", err)
+	}
+
+	rising := []struct{ h, l, c float64 }{
+		{11, 9, 10},
+		{12, 10, 11},
+		{13, 11, 12},
+		{14, 12, 13},
+		{15, 13, 14},
+	}
+	for i, d := range rising {
+		if err := g.Add(d.h, d.l, d.c); err != nil {
+			t.Fatalf("Add failed at idx %d: %v", i, err)
+		}
+	}
+
+	val, up, err := g.Calculate()
+	if err != nil {
+		t.Fatalf("Calculate returned error: %v", err)
+	}
+	if !up || !approxEqual(val, 12) {
+		t.Fatalf("expected uptrend trailing low-SMA 12, got %.4f (up=%v)", val, up)
+	}
+	if g.JustFlipped() {
+		t.Fatal("no flip expected while trend persists")
+	}
+
+	// Close falls below the prior low-SMA (12) -> switch to the high-SMA.
+	if err := g.Add(12, 8, 9); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	val, up, _ = g.Calculate()
+	if up || !g.JustFlipped() {
+		t.Fatalf("expected flip to downtrend, got up=%v flipped=%v", up, g.JustFlipped())
+	}
+	if !approxEqual(val, (14.0+15.0+12.0)/3) {
+		t.Fatalf("expected activator on high-SMA, got %.4f", val)
+	}
+
+	data := g.GetPlotData(0, 60)
+	if len(data) != 2 || len(data[0].Y) != 3 {
+		t.Fatalf("unexpected plot data: %+v", data)
+	}
+	if data[1].Y[2] != -1 {
+		t.Fatalf("expected bearish flip marker, got %v", data[1].Y)
+	}
+	data[0].Y[2] = -999
+	if got := g.GetValues(); got[2] != val {
+		t.Fatalf("plot data must not alias the activator values, got %v", got)
+	}
+}
@@ -24,6 +24,9 @@ type HullMovingAverage struct {
 	rawHMAs   []float64
 	hmaValues []float64
 	lastValue float64
+	bars      int // closes accepted since the last reset (see GetOverlayPlotData)
+
+	transform func(float64) float64 // optional output hook (see SetOutputTransform)
 }
 
 // NewHullMovingAverage initializes with the standard period (9)
@@ -52,6 +55,7 @@ func (hma *HullMovingAverage) Add(close float64) error {
 		return fmt.Errorf("%w: %v", ErrInvalidPrice, close)
 	}
 	hma.closes = append(hma.closes, close)
+	hma.bars++
 
 	// Only start calculations once we have at least `period` closing prices.
 	if len(hma.closes) >= hma.period {
@@ -83,6 +87,7 @@ func (hma *HullMovingAverage) Add(close float64) error {
 		if len(hma.rawHMAs) >= sqrtPeriod {
 			hmaValue, err := core.CalculateWMA(hma.rawHMAs[len(hma.rawHMAs)-sqrtPeriod:], sqrtPeriod)
 			if err == nil {
+				hmaValue = core.ApplyTransform(hma.transform, hmaValue)
 				hma.hmaValues = append(hma.hmaValues, hmaValue)
 				hma.lastValue = hmaValue
 			}
@@ -174,6 +179,32 @@ func (hma *HullMovingAverage) Reset() {
 	hma.rawHMAs = hma.rawHMAs[:0]
 	hma.hmaValues = hma.hmaValues[:0]
 	hma.lastValue = 0
+	hma.bars = 0
+}
+
+// SetOutputTransform passes every final HMA value through fn before it is
+// stored; the intermediate raw HMAs are not transformed. Calculate, the
+// price/HMA crossovers and GetPlotData all use the transformed line. nil
+// restores the identity; the HMA is reset.
+func (hma *HullMovingAverage) SetOutputTransform(fn func(float64) float64) {
+	hma.transform = fn
+	hma.Reset()
+}
+
+// Validate scans the retained closes, raw HMAs and HMA values for NaN or ±Inf
+// and reports the first one found with its index in the retained slice, then
+// checks the latest value.
+func (hma *HullMovingAverage) Validate() error {
+	if err := core.ValidateFinite("closes", hma.closes); err != nil {
+		return err
+	}
+	if err := core.ValidateFinite("rawHMAs", hma.rawHMAs); err != nil {
+		return err
+	}
+	if err := core.ValidateFinite("hmaValues", hma.hmaValues); err != nil {
+		return err
+	}
+	return core.CheckFinite("lastValue", hma.lastValue)
 }
 
 // SetPeriod updates the HMA period and trims buffers accordingly.
@@ -272,3 +303,34 @@ func (hma *HullMovingAverage) GetPlotData(startTime, interval int64) []core.Plot
 	}
 	return plotData
 }
+
+// GetPlotDataRange returns the HMA plot data for bars [from, to).
+func (hma *HullMovingAverage) GetPlotDataRange(startTime, interval int64, from, to int) []core.PlotData {
+	return core.SlicePlotData(hma.GetPlotData(startTime, interval), from, to)
+}
+
+// GetOverlayPlotData returns the HMA line for drawing over a price chart,
+// with X set to the absolute index of the bar each value was computed on
+// (counted from the first close since construction or Reset) and timestamps
+// startTime + X·interval. Unlike GetPlotData it is not re-based to 0, so it
+// lines up with the candles and with other overlays.
+func (hma *HullMovingAverage) GetOverlayPlotData(startTime, interval int64) []core.PlotData {
+	if len(hma.hmaValues) == 0 {
+		return nil
+	}
+	return []core.PlotData{core.OverlayPlotData("Hull Moving Average", hma.hmaValues, hma.bars-1, startTime, interval)}
+}
+
+// Describe reports the HMA period. The first value needs `period` closes for
+// the raw series plus sqrt(period)-1 more for the final WMA.
+func (hma *HullMovingAverage) Describe() core.IndicatorInfo {
+	sqrtPeriod := int(math.Sqrt(float64(hma.period)))
+	if sqrtPeriod < 1 {
+		sqrtPeriod = 1
+	}
+	return core.IndicatorInfo{
+		Name:          "HMA",
+		Params:        map[string]any{"period": hma.period},
+		SamplesNeeded: hma.period + sqrtPeriod - 1,
+	}
+}
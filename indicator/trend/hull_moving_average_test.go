@@ -266,3 +266,92 @@ func TestHullMovingAverage_Errors(t *testing.T) {
 		t.Errorf("expected ErrInsufficientCrossData, got %v", err)
 	}
 }
+
+func TestHullMovingAverage_SetOutputTransform(t *testing.T) {
+	plain, _ := NewHullMovingAverageWithParams(5)
+	neg, _ := NewHullMovingAverageWithParams(5)
+	neg.SetOutputTransform(func(v float64) float64 { return -v })
+
+	flipped := map[string]string{"Bullish": "Bearish", "Bearish": "Bullish", "Neutral": "Neutral"}
+	plainCrosses := 0
+	for i := 0; i < 80; i++ {
+		p := 100 + 10*math.Sin(float64(i)/4)
+		if err := plain.Add(p); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+		if err := neg.Add(p); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+		want, err := plain.Calculate()
+		if err != nil {
+			continue
+		}
+		if got, _ := neg.Calculate(); !approxEqual(got, -want) {
+			t.Fatalf("bar %d: transformed HMA = %v, want %v", i, got, -want)
+		}
+		dir, err := plain.GetTrendDirection()
+		if err != nil {
+			continue
+		}
+		if got, _ := neg.GetTrendDirection(); got != flipped[dir] {
+			t.Fatalf("bar %d: transformed trend %q, plain %q", i, got, dir)
+		}
+		// Prices near 100 sit far above a line near -100, so the price
+		// never crosses the transformed HMA.
+		if bull, _ := plain.IsBullishCrossover(); bull {
+			plainCrosses++
+		}
+		if bull, _ := neg.IsBullishCrossover(); bull {
+			t.Fatalf("bar %d: unexpected crossover of the negated HMA", i)
+		}
+		if bear, _ := neg.IsBearishCrossover(); bear {
+			t.Fatalf("bar %d: unexpected crossover of the negated HMA", i)
+		}
+	}
+	if plainCrosses == 0 {
+		t.Fatal("expected the price to cross the untransformed HMA")
+	}
+
+	want := plain.GetPlotData(0, 60)[0].Y
+	got := neg.GetPlotData(0, 60)[0].Y
+	if len(got) != len(want) {
+		t.Fatalf("plot length %d, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !approxEqual(got[i], -want[i]) {
+			t.Fatalf("plot[%d] = %v, want %v", i, got[i], -want[i])
+		}
+	}
+}
+
+func TestHullMovingAverage_EstimatedLag(t *testing.T) {
+	input := make([]float64, 60)
+	for i := range input {
+		input[i] = 100
+		if i >= 30 {
+			input[i] = 110
+		}
+	}
+	hma, _ := NewHullMovingAverageWithParams(10)
+	output := make([]float64, len(input))
+	for i, v := range input {
+		if err := hma.Add(v); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+		output[i] = math.NaN()
+		if v, err := hma.Calculate(); err == nil {
+			output[i] = v
+		}
+	}
+	// HMA(10) = WMA3(2·WMA5 − WMA10) covers 8/33 of the step on the step bar
+	// and 53/90 on the next, so it crosses halfway (17/66)/(1029/2970) =
+	// 765/1029 bars in – well ahead of the 4 bars of an SMA(10).
+	lag := core.EstimateSeriesLag(input, output)
+	if math.Abs(lag-765.0/1029) > 1e-9 {
+		t.Fatalf("expected HMA(10) lag of %v bars, got %v", 765.0/1029, lag)
+	}
+	sma, _ := core.NewMovingAverage(core.SMAMovingAverage, 10)
+	if smaLag := core.EstimateLag(sma, input); !(lag < smaLag) {
+		t.Fatalf("expected the HMA to lag less than SMA(10), got HMA %v SMA %v", lag, smaLag)
+	}
+}
@@ -0,0 +1,208 @@
+package trend
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/evdnx/goti/indicator/core"
+)
+
+// maRibbonMaxValues bounds the retained history of every ribbon line.
+const maRibbonMaxValues = 256
+
+// MARibbon tracks several moving averages of the same type with increasing
+// periods (e.g. an EMA ribbon). Lines are recorded only once the slowest
+// average is ready, so every line has the same length and index i refers to
+// the same bar across all of them.
+type MARibbon struct {
+	maType  core.MovingAverageType
+	periods []int
+	mas     []*core.MovingAverage
+	lines   [][]float64
+}
+
+// NewMARibbon creates a ribbon of maType averages. Periods are sorted from
+// fastest to slowest; at least two distinct periods are required.
+func NewMARibbon(maType core.MovingAverageType, periods []int) (*MARibbon, error) {
+	if len(periods) < 2 {
+		return nil, errors.New("ribbon needs at least two periods")
+	}
+	sorted := append([]int(nil), periods...)
+	sort.Ints(sorted)
+	mas := make([]*core.MovingAverage, len(sorted))
+	for i, p := range sorted {
+		if i > 0 && p == sorted[i-1] {
+			return nil, fmt.Errorf("duplicate ribbon period %d", p)
+		}
+		ma, err := core.NewMovingAverage(maType, p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s(%d): %w", maType, p, err)
+		}
+		mas[i] = ma
+	}
+	lines := make([][]float64, len(sorted))
+	for i := range lines {
+		lines[i] = make([]float64, 0, 16)
+	}
+	return &MARibbon{
+		maType:  maType,
+		periods: sorted,
+		mas:     mas,
+		lines:   lines,
+	}, nil
+}
+
+// Add feeds a closing price to every line of the ribbon.
+func (r *MARibbon) Add(close float64) error {
+	if !core.IsValidPrice(close) {
+		return fmt.Errorf("%w: %v", ErrInvalidPrice, close)
+	}
+	values := make([]float64, len(r.mas))
+	ready := true
+	for i, ma := range r.mas {
+		if err := ma.Add(close); err != nil {
+			return err
+		}
+		v, err := ma.Calculate()
+		if err != nil {
+			ready = false
+			continue
+		}
+		values[i] = v
+	}
+	if !ready {
+		return nil
+	}
+	for i, v := range values {
+		r.lines[i] = core.KeepLast(append(r.lines[i], v), maRibbonMaxValues)
+	}
+	return nil
+}
+
+// Periods returns the ribbon periods ordered from fastest to slowest.
+func (r *MARibbon) Periods() []int { return append([]int(nil), r.periods...) }
+
+// GetLines returns a defensive copy of every line, ordered like Periods.
+func (r *MARibbon) GetLines() [][]float64 {
+	out := make([][]float64, len(r.lines))
+	for i, line := range r.lines {
+		out[i] = core.CopySlice(line)
+	}
+	return out
+}
+
+// ribbonStableFraction is the relative spread change below which
+// CompressionTrend reports "Stable".
+const ribbonStableFraction = 0.05
+
+// Compression returns the spread between the fastest and slowest line,
+// normalised by the slowest line. Small values mean the ribbon is converging.
+func (r *MARibbon) Compression() (float64, error) {
+	if len(r.lines[0]) == 0 {
+		return 0, errors.New("no MA ribbon data")
+	}
+	return r.spreadAt(len(r.lines[0]) - 1)
+}
+
+// CompressionTrend compares the normalised spread (see Compression) now and n
+// bars ago. "Compressing" means the lines are converging – the trend is
+// losing strength, which often precedes a reversal – and "Expanding" that
+// they fan out. A change of at most 5% of the average spread of the two bars
+// is "Stable". It errors when n < 1 or fewer than n+1 bars are retained.
+func (r *MARibbon) CompressionTrend(n int) (string, error) {
+	if n < 1 {
+		return "", errors.New("n must be at least 1")
+	}
+	last := len(r.lines[0]) - 1
+	if last < n {
+		return "", fmt.Errorf("need %d ribbon bars for a %d-bar trend, have %d", n+1, n, last+1)
+	}
+	then, err := r.spreadAt(last - n)
+	if err != nil {
+		return "", err
+	}
+	now, err := r.spreadAt(last)
+	if err != nil {
+		return "", err
+	}
+	switch change := now - then; {
+	case math.Abs(change) <= ribbonStableFraction*(now+then)/2:
+		return "Stable", nil
+	case change < 0:
+		return "Compressing", nil
+	default:
+		return "Expanding", nil
+	}
+}
+
+// spreadAt returns the normalised fast/slow spread at retained bar i.
+func (r *MARibbon) spreadAt(i int) (float64, error) {
+	fast := r.lines[0][i]
+	slow := r.lines[len(r.lines)-1][i]
+	if slow == 0 {
+		return 0, errors.New("slowest line is zero")
+	}
+	return math.Abs(fast-slow) / slow, nil
+}
+
+// IsBullishStack reports whether every line sits above the next slower one.
+func (r *MARibbon) IsBullishStack() (bool, error) {
+	return r.isStacked(func(faster, slower float64) bool { return faster > slower })
+}
+
+// IsBearishStack reports whether every line sits below the next slower one.
+func (r *MARibbon) IsBearishStack() (bool, error) {
+	return r.isStacked(func(faster, slower float64) bool { return faster < slower })
+}
+
+func (r *MARibbon) isStacked(ordered func(faster, slower float64) bool) (bool, error) {
+	if len(r.lines[0]) == 0 {
+		return false, errors.New("no MA ribbon data")
+	}
+	last := len(r.lines[0]) - 1
+	for i := 0; i < len(r.lines)-1; i++ {
+		if !ordered(r.lines[i][last], r.lines[i+1][last]) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Reset clears every line while preserving the periods.
+func (r *MARibbon) Reset() {
+	for i, ma := range r.mas {
+		ma.Reset()
+		r.lines[i] = r.lines[i][:0]
+	}
+}
+
+// GetPlotData emits one line series per period.
+func (r *MARibbon) GetPlotData(startTime, interval int64) []core.PlotData {
+	n := len(r.lines[0])
+	if n == 0 {
+		return nil
+	}
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = float64(i)
+	}
+	ts := core.GenerateTimestamps(startTime, n, interval)
+	plots := make([]core.PlotData, 0, len(r.lines))
+	for i, line := range r.lines {
+		plots = append(plots, core.PlotData{
+			Name:      fmt.Sprintf("%s %d", r.maType, r.periods[i]),
+			X:         x,
+			Y:         line,
+			Type:      "line",
+			Timestamp: ts,
+		})
+	}
+	return plots
+}
+
+// GetPlotDataRange returns the ribbon plot data for bars [from, to).
+func (r *MARibbon) GetPlotDataRange(startTime, interval int64, from, to int) []core.PlotData {
+	return core.SlicePlotData(r.GetPlotData(startTime, interval), from, to)
+}
@@ -0,0 +1,104 @@
+package trend
+
+import (
+	"testing"
+
+	"github.com/evdnx/goti/indicator/core"
+)
+
+func TestMARibbon_InvalidPeriods(t *testing.T) {
+	if _, err := NewMARibbon(core.EMAMovingAverage, []int{5}); err == nil {
+		t.Fatal("expected error for a single period")
+	}
+	if _, err := NewMARibbon(core.EMAMovingAverage, []int{5, 5}); err == nil {
+		t.Fatal("expected error for duplicate periods")
+	}
+	if _, err := NewMARibbon(core.EMAMovingAverage, []int{0, 5}); err == nil {
+		t.Fatal("expected error for zero period")
+	}
+}
+
+func TestMARibbon_BullishStackInUptrend(t *testing.T) {
+	r, err := NewMARibbon(core.EMAMovingAverage, []int{13, 5, 8})
+	if err != nil {
+		t.Fatalf("constructor error: %v", err)
+	}
+	if _, err := r.IsBullishStack(); err == nil {
+		t.Fatal("expected error before the slowest line is ready")
+	}
+
+	price := 100.0
+	for i := 0; i < 40; i++ {
+		price += 1
+		if err := r.Add(price); err != nil {
+			t.Fatalf("Add failed at idx %d: %v", i, err)
+		}
+	}
+
+	bull, err := r.IsBullishStack()
+	if err != nil || !bull {
+		t.Fatalf("expected bullish stack, got %v (err %v)", bull, err)
+	}
+	if bear, _ := r.IsBearishStack(); bear {
+		t.Fatal("uptrend must not be a bearish stack")
+	}
+
+	lines := r.GetLines()
+	if len(lines) != 3 || len(lines[0]) != len(lines[2]) || len(lines[0]) != 40-13+1 {
+		t.Fatalf("unexpected line lengths: %d/%d", len(lines[0]), len(lines[2]))
+	}
+	comp, err := r.Compression()
+	if err != nil || comp <= 0 {
+		t.Fatalf("expected positive compression, got %v (err %v)", comp, err)
+	}
+
+	plots := r.GetPlotData(0, 60)
+	if len(plots) != 3 || plots[0].Name != "EMA 5" || plots[2].Name != "EMA 13" {
+		t.Fatalf("unexpected plot series: %+v", plots)
+	}
+}
+
+func TestMARibbon_CompressionTrend(t *testing.T) {
+	r, err := NewMARibbon(core.EMAMovingAverage, []int{5, 8, 13})
+	if err != nil {
+		t.Fatalf("constructor error: %v", err)
+	}
+	if _, err := r.CompressionTrend(5); err == nil {
+		t.Fatal("expected error without enough ribbon bars")
+	}
+
+	// A flat market leaves the lines on top of each other.
+	price := 100.0
+	for i := 0; i < 20; i++ {
+		if err := r.Add(price); err != nil {
+			t.Fatalf("Add failed at idx %d: %v", i, err)
+		}
+	}
+	if _, err := r.CompressionTrend(0); err == nil {
+		t.Fatal("expected error for n = 0")
+	}
+	if trend, err := r.CompressionTrend(5); err != nil || trend != "Stable" {
+		t.Fatalf("expected Stable while flat, got %q (err %v)", trend, err)
+	}
+
+	// A new trend fans the ribbon out.
+	for i := 0; i < 10; i++ {
+		price += 2
+		if err := r.Add(price); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	if trend, err := r.CompressionTrend(5); err != nil || trend != "Expanding" {
+		t.Fatalf("expected Expanding during the trend, got %q (err %v)", trend, err)
+	}
+
+	// The trend stalls: the fast line catches up with the slow ones.
+	for i := 0; i < 6; i++ {
+		if err := r.Add(price); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	if trend, err := r.CompressionTrend(5); err != nil || trend != "Compressing" {
+		t.Fatalf("expected Compressing as the trend stalls, got %q (err %v)", trend, err)
+	}
+}
@@ -0,0 +1,122 @@
+package trend
+
+import (
+	"errors"
+	"math"
+
+	"github.com/evdnx/goti/indicator/core"
+)
+
+const (
+	DefaultMcGinleyPeriod = 14
+	// mcGinleyK is the constant from McGinley's original formulation; it
+	// makes the line roughly as fast as an EMA of 60% of the nominal period.
+	mcGinleyK = 0.6
+	// mcGinleyMaxValues bounds the retained line history.
+	mcGinleyMaxValues = 256
+)
+
+// McGinleyDynamic implements John McGinley's self-adjusting moving average:
+//
+//	md = prevMd + (close - prevMd) / (k * period * (close/prevMd)^4)
+//
+// The fourth-power ratio slows the line when price runs above it and speeds
+// it up when price falls below, so it hugs price more closely than an EMA of
+// the same period and is less prone to whipsaw. The first close seeds the
+// line.
+type McGinleyDynamic struct {
+	period    int
+	values    []float64
+	lastValue float64
+	seeded    bool
+}
+
+// NewMcGinley creates a McGinley Dynamic with the default period (14).
+func NewMcGinley() (*McGinleyDynamic, error) {
+	return NewMcGinleyWithParams(DefaultMcGinleyPeriod)
+}
+
+// NewMcGinleyWithParams creates a McGinley Dynamic with a custom period.
+func NewMcGinleyWithParams(period int) (*McGinleyDynamic, error) {
+	if period < 1 {
+		return nil, errors.New("period must be at least 1")
+	}
+	return &McGinleyDynamic{
+		period: period,
+		values: make([]float64, 0, 16),
+	}, nil
+}
+
+// Add ingests a closing price and updates the line.
+func (m *McGinleyDynamic) Add(close float64) error {
+	if !core.IsValidPrice(close) {
+		return errors.New("invalid price: close must be positive")
+	}
+	if !m.seeded || m.lastValue == 0 {
+		// A zero previous value would make the ratio undefined; reseed.
+		m.lastValue = close
+		m.seeded = true
+	} else {
+		ratio := close / m.lastValue
+		denom := mcGinleyK * float64(m.period) * math.Pow(ratio, 4)
+		if denom < 1 {
+			// Never step past the close, even on violent moves.
+			denom = 1
+		}
+		m.lastValue += (close - m.lastValue) / denom
+	}
+	m.values = append(m.values, m.lastValue)
+	m.values = core.KeepLast(m.values, mcGinleyMaxValues)
+	return nil
+}
+
+// Calculate returns the latest McGinley Dynamic value.
+func (m *McGinleyDynamic) Calculate() (float64, error) {
+	if !m.seeded {
+		return 0, errors.New("no McGinley Dynamic data")
+	}
+	return m.lastValue, nil
+}
+
+// Reset clears all state while preserving the period.
+func (m *McGinleyDynamic) Reset() {
+	m.values = m.values[:0]
+	m.lastValue = 0
+	m.seeded = false
+}
+
+// SetPeriod updates the period and resets the line.
+func (m *McGinleyDynamic) SetPeriod(period int) error {
+	if period < 1 {
+		return errors.New("period must be at least 1")
+	}
+	m.period = period
+	m.Reset()
+	return nil
+}
+
+// GetValues returns the line history (defensive copy).
+func (m *McGinleyDynamic) GetValues() []float64 { return core.CopySlice(m.values) }
+
+// GetPlotData returns the McGinley Dynamic as a single line.
+func (m *McGinleyDynamic) GetPlotData(startTime, interval int64) []core.PlotData {
+	if len(m.values) == 0 {
+		return nil
+	}
+	x := make([]float64, len(m.values))
+	for i := range x {
+		x[i] = float64(i)
+	}
+	return []core.PlotData{{
+		Name:      "McGinley Dynamic",
+		X:         x,
+		Y:         core.CopySlice(m.values),
+		Type:      "line",
+		Timestamp: core.GenerateTimestamps(startTime, len(m.values), interval),
+	}}
+}
+
+// GetPlotDataRange returns the McGinley Dynamic plot data for bars [from, to).
+func (m *McGinleyDynamic) GetPlotDataRange(startTime, interval int64, from, to int) []core.PlotData {
+	return core.SlicePlotData(m.GetPlotData(startTime, interval), from, to)
+}
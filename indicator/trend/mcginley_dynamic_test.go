@@ -0,0 +1,67 @@
+package trend
+
+import (
+	"testing"
+
+	"github.com/evdnx/goti/indicator/core"
+)
+
+func TestMcGinley_InvalidInput(t *testing.T) {
+	if _, err := NewMcGinleyWithParams(0); err == nil {
+		t.Fatal("expected error for period 0")
+	}
+	m, _ := NewMcGinley()
+	if _, err := m.Calculate(); err == nil {
+		t.Fatal("expected error before any data")
+	}
+	if err := m.Add(0); err == nil {
+		t.Fatal("expected error for a zero close")
+	}
+}
+
+func TestMcGinley_SeedAndFormula(t *testing.T) {
+	m, _ := NewMcGinleyWithParams(10)
+	_ = m.Add(100)
+	if v, _ := m.Calculate(); v != 100 {
+		t.Fatalf("expected seed 100, got %v", v)
+	}
+	_ = m.Add(110)
+	// 100 + 10 / (0.6 * 10 * 1.1^4)
+	want := 100 + 10/(0.6*10*1.1*1.1*1.1*1.1)
+	if v, _ := m.Calculate(); !approxEqual(v, want) {
+		t.Fatalf("expected %v, got %v", want, v)
+	}
+}
+
+func TestMcGinley_LessOvershootThanEMA(t *testing.T) {
+	const period = 10
+	m, _ := NewMcGinleyWithParams(period)
+	ema, _ := core.NewMovingAverage(core.EMAMovingAverage, period, core.WithEMASeed(core.SeedFirstValue))
+
+	// A steady rally that rolls over into a decline: after the top both
+	// lines sit above price, and the McGinley line should come back faster.
+	price := 100.0
+	var mgOver, emaOver float64
+	for i := 0; i < 60; i++ {
+		if i < 30 {
+			price++
+		} else {
+			price--
+		}
+		_ = m.Add(price)
+		_ = ema.Add(price)
+		if i < 30 {
+			continue
+		}
+		mv, _ := m.Calculate()
+		ev, _ := ema.Calculate()
+		mgOver = max(mgOver, mv-price)
+		emaOver = max(emaOver, ev-price)
+	}
+	if mgOver >= emaOver {
+		t.Fatalf("expected McGinley overshoot %.3f below EMA overshoot %.3f", mgOver, emaOver)
+	}
+	if len(m.GetPlotData(0, 60)) != 1 || len(m.GetValues()) != 60 {
+		t.Fatal("unexpected plot/series length")
+	}
+}
@@ -30,6 +30,10 @@ type ParabolicSAR struct {
 	values []float64
 
 	lastValue float64
+
+	// Flip statistics over the whole fed history (see ReversalCount).
+	bars      int // SAR values produced
+	reversals int
 }
 
 // NewParabolicSAR creates a SAR calculator with default step (0.02) and
@@ -89,6 +93,34 @@ func (p *ParabolicSAR) Calculate() (float64, error) {
 	return p.lastValue, nil
 }
 
+// StopDistancePercent returns the distance between price and the current SAR
+// as a percentage of price, i.e. the implied trailing-stop distance.
+func (p *ParabolicSAR) StopDistancePercent(price float64) (float64, error) {
+	if len(p.values) == 0 {
+		return 0, errors.New("no SAR data")
+	}
+	if price == 0 || !core.IsValidPrice(price) {
+		return 0, errors.New("price must be positive")
+	}
+	return math.Abs(price-p.lastValue) / price * 100, nil
+}
+
+// ReversalCount returns how many times the SAR has flipped sides since the
+// last reset. Many flips relative to the bars fed suggest the step is too
+// sensitive for the instrument.
+func (p *ParabolicSAR) ReversalCount() int { return p.reversals }
+
+// AverageTrendBars returns the mean length, in bars, of the trends the SAR
+// has tracked: the SAR bars produced divided by the number of trends (flips
+// plus the initial one). The trend still in progress counts at its current
+// length. It is 0 before the first SAR value.
+func (p *ParabolicSAR) AverageTrendBars() float64 {
+	if p.bars == 0 {
+		return 0
+	}
+	return float64(p.bars) / float64(p.reversals+1)
+}
+
 // IsUptrend reports the current trend direction.
 func (p *ParabolicSAR) IsUptrend() bool { return p.uptrend }
 
@@ -103,6 +135,8 @@ func (p *ParabolicSAR) Reset() {
 	p.lows = p.lows[:0]
 	p.values = p.values[:0]
 	p.lastValue = 0
+	p.bars = 0
+	p.reversals = 0
 }
 
 // SetParams updates step parameters and resets the indicator.
@@ -141,6 +175,25 @@ func (p *ParabolicSAR) GetPlotData(startTime, interval int64) []core.PlotData {
 	}}
 }
 
+// GetPlotDataRange returns the SAR plot data for bars [from, to).
+func (p *ParabolicSAR) GetPlotDataRange(startTime, interval int64, from, to int) []core.PlotData {
+	return core.SlicePlotData(p.GetPlotData(startTime, interval), from, to)
+}
+
+// GetOverlayPlotData returns the SAR for drawing over a price chart, with X
+// set to the absolute index of the candle each value belongs to (counted from
+// the first candle since construction or Reset, which has no SAR) and
+// timestamps startTime + X·interval, so it lines up with the candles and
+// with other overlays.
+func (p *ParabolicSAR) GetOverlayPlotData(startTime, interval int64) []core.PlotData {
+	if len(p.values) == 0 {
+		return nil
+	}
+	// Every candle after the first produces a value, so the latest one
+	// belongs to candle index p.bars.
+	return []core.PlotData{core.OverlayPlotData("Parabolic SAR", p.values, p.bars, startTime, interval)}
+}
+
 func (p *ParabolicSAR) initializeTrend() {
 	if len(p.highs) < 2 {
 		return
@@ -157,6 +210,7 @@ func (p *ParabolicSAR) initializeTrend() {
 	}
 	p.af = p.step
 	p.initialized = true
+	p.bars++
 	p.values = append(p.values, p.sar)
 	p.lastValue = p.sar
 }
@@ -180,6 +234,7 @@ func (p *ParabolicSAR) updateSAR() {
 		if p.lows[len(p.lows)-1] < newSAR {
 			// Reversal to downtrend.
 			p.uptrend = false
+			p.reversals++
 			newSAR = p.ep
 			p.ep = p.lows[len(p.lows)-1]
 			p.af = p.step
@@ -194,6 +249,7 @@ func (p *ParabolicSAR) updateSAR() {
 		if p.highs[len(p.highs)-1] > newSAR {
 			// Reversal to uptrend.
 			p.uptrend = true
+			p.reversals++
 			newSAR = p.ep
 			p.ep = p.highs[len(p.highs)-1]
 			p.af = p.step
@@ -206,6 +262,7 @@ func (p *ParabolicSAR) updateSAR() {
 	}
 
 	p.sar = newSAR
+	p.bars++
 	p.values = append(p.values, newSAR)
 	p.lastValue = newSAR
 }
@@ -216,3 +273,15 @@ func (p *ParabolicSAR) trimSlices() {
 	p.lows = core.KeepLast(p.lows, 4)
 	p.values = core.KeepLast(p.values, 256)
 }
+
+// Describe reports the acceleration step and its cap.
+func (p *ParabolicSAR) Describe() core.IndicatorInfo {
+	return core.IndicatorInfo{
+		Name: "Parabolic SAR",
+		Params: map[string]any{
+			"step":    p.step,
+			"maxStep": p.maxStep,
+		},
+		SamplesNeeded: 2,
+	}
+}
@@ -65,3 +65,67 @@ func TestParabolicSAR_ReversalToDowntrend(t *testing.T) {
 		t.Fatal("expected downtrend after reversal")
 	}
 }
+
+func TestParabolicSAR_StopDistancePercent(t *testing.T) {
+	sar, _ := NewParabolicSAR()
+	if _, err := sar.StopDistancePercent(100); err == nil {
+		t.Fatal("expected error before any SAR value")
+	}
+
+	// Two rising candles seed an uptrend with SAR at the first low (9).
+	_ = sar.Add(10, 9)
+	_ = sar.Add(11, 10)
+	val, _ := sar.Calculate()
+	if !approxEqual(val, 9) {
+		t.Fatalf("unexpected seed SAR: %.4f", val)
+	}
+
+	pct, err := sar.StopDistancePercent(10)
+	if err != nil {
+		t.Fatalf("StopDistancePercent returned error: %v", err)
+	}
+	if !approxEqual(pct, 10) {
+		t.Fatalf("expected 10%% stop distance, got %.4f", pct)
+	}
+	if _, err := sar.StopDistancePercent(0); err == nil {
+		t.Fatal("expected error for zero price")
+	}
+}
+
+func TestParabolicSARReversalStats(t *testing.T) {
+	choppy, _ := NewParabolicSAR()
+	trending, _ := NewParabolicSAR()
+	if choppy.AverageTrendBars() != 0 {
+		t.Fatal("expected 0 average before any data")
+	}
+	for i := 0; i < 60; i++ {
+		// Alternate between two price levels every two bars.
+		base := 100.0
+		if (i/2)%2 == 1 {
+			base = 104
+		}
+		_ = choppy.Add(base+1, base-1)
+
+		trendBase := 100 + float64(i)
+		_ = trending.Add(trendBase+1, trendBase-1)
+	}
+
+	if choppy.ReversalCount() < 10 {
+		t.Fatalf("expected many flips on a choppy series, got %d", choppy.ReversalCount())
+	}
+	if trending.ReversalCount() > 1 {
+		t.Fatalf("expected at most one flip on a trend, got %d", trending.ReversalCount())
+	}
+	if choppy.AverageTrendBars() >= trending.AverageTrendBars() {
+		t.Fatalf("expected shorter trends when choppy: %.2f vs %.2f",
+			choppy.AverageTrendBars(), trending.AverageTrendBars())
+	}
+	if got := trending.AverageTrendBars(); got != float64(59)/float64(trending.ReversalCount()+1) {
+		t.Fatalf("unexpected average trend length %v", got)
+	}
+
+	choppy.Reset()
+	if choppy.ReversalCount() != 0 || choppy.AverageTrendBars() != 0 {
+		t.Fatal("Reset should clear flip statistics")
+	}
+}
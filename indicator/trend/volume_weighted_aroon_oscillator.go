@@ -18,8 +18,23 @@ type VolumeWeightedAroonOscillator struct {
 	vwaoValues []float64
 	lastValue  float64
 	config     config.IndicatorConfig
+
+	transform func(float64) float64 // optional output hook (see SetOutputTransform)
+	extremes  core.Extremes         // output range since the last reset (see ObservedMin)
+
+	// Signal line: a moving average of the VWAO (see SetSignalPeriod)
+	signalPeriod int
+	signalMA     *core.MovingAverage
+	signalValues []float64
+	plotSignal   bool // export the signal line in GetPlotData
+
+	inclusivity core.CrossoverInclusivity // boundary rule of the crossover helpers
+	crossDelta  float64                   // minimum move beyond the level (see SetCrossoverThreshold)
 }
 
+// DefaultVWAOSignalPeriod is the default length of the VWAO signal line.
+const DefaultVWAOSignalPeriod = 9
+
 // NewVolumeWeightedAroonOscillator creates a VWAO with the default period (14)
 // and the library’s default configuration.
 func NewVolumeWeightedAroonOscillator() (*VolumeWeightedAroonOscillator, error) {
@@ -35,14 +50,20 @@ func NewVolumeWeightedAroonOscillatorWithParams(period int, cfg config.Indicator
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
+	signalMA, err := core.NewMovingAverage(core.EMAMovingAverage, DefaultVWAOSignalPeriod)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signal EMA: %w", err)
+	}
 	return &VolumeWeightedAroonOscillator{
-		period:     period,
-		highs:      make([]float64, 0, period+1),
-		lows:       make([]float64, 0, period+1),
-		closes:     make([]float64, 0, period+1),
-		volumes:    make([]float64, 0, period+1),
-		vwaoValues: make([]float64, 0, period),
-		config:     cfg,
+		period:       period,
+		highs:        make([]float64, 0, period+1),
+		lows:         make([]float64, 0, period+1),
+		closes:       make([]float64, 0, period+1),
+		volumes:      make([]float64, 0, period+1),
+		vwaoValues:   make([]float64, 0, period),
+		config:       cfg,
+		signalPeriod: DefaultVWAOSignalPeriod,
+		signalMA:     signalMA,
 	}, nil
 }
 
@@ -70,8 +91,16 @@ func (v *VolumeWeightedAroonOscillator) Add(high, low, close, volume float64) er
 		if err != nil {
 			return fmt.Errorf("computeVWAO failed: %w", err)
 		}
+		val = core.ApplyTransform(v.transform, val)
 		v.vwaoValues = append(v.vwaoValues, val)
 		v.lastValue = val
+		v.extremes.Observe(val)
+		if err := v.signalMA.AddValue(val); err != nil {
+			return fmt.Errorf("signal line update failed: %w", err)
+		}
+		if sig, err := v.signalMA.Calculate(); err == nil {
+			v.signalValues = append(v.signalValues, sig)
+		}
 	}
 	v.trimSlices()
 	return nil
@@ -89,6 +118,7 @@ func (v *VolumeWeightedAroonOscillator) trimSlices() {
 	if len(v.vwaoValues) > v.period {
 		v.vwaoValues = v.vwaoValues[len(v.vwaoValues)-v.period:]
 	}
+	v.signalValues = core.KeepLast(v.signalValues, v.period)
 }
 
 // computeVWAO performs the genuine volume‑weighted Aroon‑Oscillator
@@ -97,23 +127,39 @@ func (v *VolumeWeightedAroonOscillator) trimSlices() {
 // Algorithm:
 //  1. Look at the last (period+1) bars.
 //  2. Identify the most recent highest high and lowest low and their indices.
-//  3. Compute the total volume‑weighted “age” of the window:
-//     Σ (period‑i) * volume[i]   for i = 0 … period
-//  4. Weight the age of the high and low by the volume that occurred on the
-//     bar where the extreme price was observed.
-//     weightedHighAge = (period‑highIdx) * volume[highIdx]
-//     weightedLowAge  = (period‑lowIdx)  * volume[lowIdx]
+//  3. Compute the total volume‑weighted recency of the window, where bar i
+//     (0 = oldest, period = newest) has recency i:
+//     Σ i * volume[i]   for i = 0 … period
+//  4. Weight the recency of the high and low by the volume that occurred on
+//     the bar where the extreme price was observed.
+//     weightedHighRecency = highIdx * volume[highIdx]
+//     weightedLowRecency  = lowIdx  * volume[lowIdx]
 //  5. Derive volume‑weighted Aroon percentages:
-//     aroonUp   = (weightedHighAge / totalWeightedAge) * 100
-//     aroonDown = (weightedLowAge  / totalWeightedAge) * 100
+//     aroonUp   = (weightedHighRecency / totalWeightedRecency) * 100
+//     aroonDown = (weightedLowRecency  / totalWeightedRecency) * 100
 //  6. Oscillator = aroonUp – aroonDown, clamped to [-100, 100].
 //
-// This yields a metric that rises when a strong high appears on heavy volume
-// (and falls when a strong low appears on heavy volume), while still respecting
-// the classic Aroon time‑decay intuition.
+// Like the classic Aroon, a fresh extreme scores highest and decays as it
+// ages out of the window, so the metric rises when a new high prints on heavy
+// volume and falls when a new low does.
+//
+// The clamp never binds: the weighted recencies of the high and low are each
+// one of the non-negative terms summed into the total, so both percentages
+// lie in [0, 100] by construction and their difference in [-100, 100]. It
+// stays as a guard only; AroonComponents exposes the unclamped inputs.
 func (v *VolumeWeightedAroonOscillator) computeVWAO() (float64, error) {
+	aroonUp, aroonDown, err := v.aroonComponents()
+	if err != nil {
+		return 0, err
+	}
+	return core.Clamp(aroonUp-aroonDown, -100, 100), nil
+}
+
+// aroonComponents computes the volume-weighted Aroon Up and Aroon Down of the
+// current window (steps 1–5 of computeVWAO).
+func (v *VolumeWeightedAroonOscillator) aroonComponents() (aroonUp, aroonDown float64, err error) {
 	if len(v.closes) < v.period+1 {
-		return 0, fmt.Errorf("insufficient data: need %d, have %d", v.period+1, len(v.closes))
+		return 0, 0, fmt.Errorf("insufficient data: need %d, have %d", v.period+1, len(v.closes))
 	}
 
 	// Slice the window that will be examined.
@@ -125,34 +171,40 @@ func (v *VolumeWeightedAroonOscillator) computeVWAO() (float64, error) {
 	// Locate the most recent highest high and lowest low.
 	maxHighIdx, minLowIdx := 0, 0
 	maxHigh, minLow := highs[0], lows[0]
-	var totalWeightedAge float64
+	var totalWeightedRecency float64
 
 	for i := 0; i <= v.period; i++ {
-		if highs[i] > maxHigh {
+		if highs[i] >= maxHigh {
 			maxHigh = highs[i]
 			maxHighIdx = i
 		}
-		if lows[i] < minLow {
+		if lows[i] <= minLow {
 			minLow = lows[i]
 			minLowIdx = i
 		}
-		// Age weighting: newer bars have larger (period‑i) factor.
-		totalWeightedAge += float64(v.period-i) * vols[i]
+		// Recency weighting: i is period minus the bar's age, so the newest
+		// bar weighs most and the oldest, about to leave the window, nothing.
+		totalWeightedRecency += float64(i) * vols[i]
 	}
-	if totalWeightedAge == 0 {
-		return 0, errors.New("total weighted volume is zero")
+	if totalWeightedRecency == 0 {
+		return 0, 0, errors.New("total weighted volume is zero")
 	}
 
-	// Volume‑weighted ages for the extremes.
-	weightedHighAge := float64(v.period-maxHighIdx) * vols[maxHighIdx]
-	weightedLowAge := float64(v.period-minLowIdx) * vols[minLowIdx]
+	// Volume‑weighted recencies for the extremes.
+	weightedHighRecency := float64(maxHighIdx) * vols[maxHighIdx]
+	weightedLowRecency := float64(minLowIdx) * vols[minLowIdx]
 
-	// Convert to classic Aroon percentages, but using volume‑weighted ages.
-	aroonUp := (weightedHighAge / totalWeightedAge) * 100
-	aroonDown := (weightedLowAge / totalWeightedAge) * 100
+	// Convert to classic Aroon percentages, but using volume‑weighted recency.
+	aroonUp = (weightedHighRecency / totalWeightedRecency) * 100
+	aroonDown = (weightedLowRecency / totalWeightedRecency) * 100
+	return aroonUp, aroonDown, nil
+}
 
-	osc := aroonUp - aroonDown
-	return core.Clamp(osc, -100, 100), nil
+// AroonComponents returns the volume-weighted Aroon Up and Aroon Down of the
+// current window – the values behind the latest VWAO before the range clamp
+// and any output transform – so the [0, 100] bound can be checked directly.
+func (v *VolumeWeightedAroonOscillator) AroonComponents() (aroonUp, aroonDown float64, err error) {
+	return v.aroonComponents()
 }
 
 // Calculate returns the most recent VWAO value (or an error if none have been computed).
@@ -172,7 +224,8 @@ func (v *VolumeWeightedAroonOscillator) IsBullishCrossover() (bool, error) {
 		return false, errors.New("insufficient data for crossover")
 	}
 	prev, cur := v.vwaoValues[len(v.vwaoValues)-2], v.vwaoValues[len(v.vwaoValues)-1]
-	return prev <= v.config.VWAOStrongTrend && cur > v.config.VWAOStrongTrend, nil
+	level := v.config.VWAOStrongTrend
+	return core.CrossedAbove(prev, cur, level, v.inclusivity.AllowsEqual(true)) && cur-level >= v.crossDelta, nil
 }
 
 func (v *VolumeWeightedAroonOscillator) IsBearishCrossover() (bool, error) {
@@ -180,7 +233,8 @@ func (v *VolumeWeightedAroonOscillator) IsBearishCrossover() (bool, error) {
 		return false, errors.New("insufficient data for crossover")
 	}
 	prev, cur := v.vwaoValues[len(v.vwaoValues)-2], v.vwaoValues[len(v.vwaoValues)-1]
-	return prev >= -v.config.VWAOStrongTrend && cur < -v.config.VWAOStrongTrend, nil
+	level := -v.config.VWAOStrongTrend
+	return core.CrossedBelow(prev, cur, level, v.inclusivity.AllowsEqual(true)) && level-cur >= v.crossDelta, nil
 }
 
 func (v *VolumeWeightedAroonOscillator) IsStrongTrend() (bool, error) {
@@ -215,6 +269,130 @@ func (v *VolumeWeightedAroonOscillator) Reset() {
 	v.volumes = v.volumes[:0]
 	v.vwaoValues = v.vwaoValues[:0]
 	v.lastValue = 0
+	v.extremes.Reset()
+	v.signalMA.Reset()
+	v.signalValues = v.signalValues[:0]
+}
+
+// SetCrossoverInclusivity sets whether IsBullishCrossover and
+// IsBearishCrossover accept a previous VWAO exactly on the ±VWAOStrongTrend
+// level. The default is inclusive; the stored values are kept.
+func (v *VolumeWeightedAroonOscillator) SetCrossoverInclusivity(mode core.CrossoverInclusivity) {
+	v.inclusivity = mode
+}
+
+// SetCrossoverThreshold requires the VWAO to end at least delta beyond the
+// ±VWAOStrongTrend level for a crossover to count, filtering marginal pokes
+// through the level. 0 keeps the default behaviour. GetPlotData still marks
+// every cross; only the crossover helpers apply the threshold.
+func (v *VolumeWeightedAroonOscillator) SetCrossoverThreshold(delta float64) error {
+	if err := core.ValidateCrossoverThreshold(delta); err != nil {
+		return err
+	}
+	v.crossDelta = delta
+	return nil
+}
+
+// SetSignalPeriod changes the length of the signal line and rebuilds it from
+// the next VWAO value on; the oscillator itself is kept. Configuring the
+// signal line here or with SetSignalMAType also adds it to GetPlotData.
+func (v *VolumeWeightedAroonOscillator) SetSignalPeriod(n int) error {
+	if n < 1 {
+		return errors.New("period must be at least 1")
+	}
+	if err := v.signalMA.SetPeriod(n); err != nil {
+		return err
+	}
+	v.signalPeriod = n
+	v.signalValues = v.signalValues[:0]
+	v.plotSignal = true
+	return nil
+}
+
+// SetSignalMAType switches the signal line between an EMA (the default) and
+// an SMA of the VWAO. The signal line restarts from the next VWAO value.
+func (v *VolumeWeightedAroonOscillator) SetSignalMAType(maType core.MovingAverageType) error {
+	if maType != core.EMAMovingAverage && maType != core.SMAMovingAverage {
+		return fmt.Errorf("unsupported signal moving average %s", maType)
+	}
+	ma, err := core.NewMovingAverage(maType, v.signalPeriod)
+	if err != nil {
+		return err
+	}
+	v.signalMA = ma
+	v.signalValues = v.signalValues[:0]
+	v.plotSignal = true
+	return nil
+}
+
+// GetSignal returns the latest signal-line value.
+func (v *VolumeWeightedAroonOscillator) GetSignal() (float64, error) {
+	if len(v.signalValues) == 0 {
+		return 0, errors.New("no VWAO signal data")
+	}
+	return v.signalValues[len(v.signalValues)-1], nil
+}
+
+// GetSignalValues returns a defensive copy of the signal line.
+func (v *VolumeWeightedAroonOscillator) GetSignalValues() []float64 {
+	return core.CopySlice(v.signalValues)
+}
+
+// IsSignalCrossover reports a VWAO/signal crossover on the latest bar, the
+// MACD-style entry: 1 when the VWAO crossed above its signal line, -1 when it
+// crossed below, 0 otherwise.
+func (v *VolumeWeightedAroonOscillator) IsSignalCrossover() (int, error) {
+	n := len(v.signalValues)
+	if n < 2 {
+		return 0, errors.New("insufficient data for signal crossover")
+	}
+	prevDiff := v.vwaoValues[len(v.vwaoValues)-2] - v.signalValues[n-2]
+	curDiff := v.vwaoValues[len(v.vwaoValues)-1] - v.signalValues[n-1]
+	switch {
+	case prevDiff <= 0 && curDiff > 0:
+		return 1, nil
+	case prevDiff >= 0 && curDiff < 0:
+		return -1, nil
+	}
+	return 0, nil
+}
+
+// ObservedMin returns the lowest VWAO value produced since construction or
+// the last Reset, or 0 before the first value.
+func (v *VolumeWeightedAroonOscillator) ObservedMin() float64 { return v.extremes.Min() }
+
+// ObservedMax returns the highest VWAO value produced since construction or
+// the last Reset, or 0 before the first value.
+func (v *VolumeWeightedAroonOscillator) ObservedMax() float64 { return v.extremes.Max() }
+
+// SetOutputTransform passes every VWAO value through fn before it is stored,
+// so Calculate, the strong-trend crossovers, IsStrongTrend and GetPlotData
+// all see the transformed series. nil restores the identity; the VWAO is reset.
+func (v *VolumeWeightedAroonOscillator) SetOutputTransform(fn func(float64) float64) {
+	v.transform = fn
+	v.Reset()
+}
+
+// Validate scans the retained candles, VWAO values and signal line for NaN or
+// ±Inf and reports the first one found with its index in the retained slice,
+// then checks the latest value.
+func (v *VolumeWeightedAroonOscillator) Validate() error {
+	for _, s := range []struct {
+		name   string
+		values []float64
+	}{
+		{"highs", v.highs},
+		{"lows", v.lows},
+		{"closes", v.closes},
+		{"volumes", v.volumes},
+		{"vwaoValues", v.vwaoValues},
+		{"signalValues", v.signalValues},
+	} {
+		if err := core.ValidateFinite(s.name, s.values); err != nil {
+			return err
+		}
+	}
+	return core.CheckFinite("lastValue", v.lastValue)
 }
 
 // SetPeriod changes the look‑back window and trims any excess data.
@@ -236,6 +414,11 @@ func (v *VolumeWeightedAroonOscillator) GetVWAOValues() []float64 {
 	return core.CopySlice(v.vwaoValues)
 }
 
+// OutputSlope returns the change in VWAO over the last n bars.
+func (v *VolumeWeightedAroonOscillator) OutputSlope(n int) (float64, error) {
+	return core.OutputSlope(v.vwaoValues, n)
+}
+
 // ---------- Plotting helper ----------
 func (v *VolumeWeightedAroonOscillator) GetPlotData(startTime, interval int64) []core.PlotData {
 	if len(v.vwaoValues) == 0 {
@@ -261,7 +444,7 @@ func (v *VolumeWeightedAroonOscillator) GetPlotData(startTime, interval int64) [
 			signals[i] = -2
 		}
 	}
-	return []core.PlotData{
+	plots := []core.PlotData{
 		{
 			Name:      "Volume Weighted Aroon Oscillator",
 			X:         x,
@@ -277,4 +460,38 @@ func (v *VolumeWeightedAroonOscillator) GetPlotData(startTime, interval int64) [
 			Timestamp: ts,
 		},
 	}
+	if n := len(v.signalValues); v.plotSignal && n > 0 {
+		plots = append(plots, core.PlotData{
+			Name:      "Signal Line",
+			X:         x[len(x)-n:],
+			Y:         core.CopySlice(v.signalValues),
+			Type:      "line",
+			Timestamp: ts[len(ts)-n:],
+		})
+	}
+	return plots
+}
+
+// GetPlotDataRange returns the VWAO plot data for bars [from, to).
+func (v *VolumeWeightedAroonOscillator) GetPlotDataRange(startTime, interval int64, from, to int) []core.PlotData {
+	return core.SlicePlotData(v.GetPlotData(startTime, interval), from, to)
+}
+
+// GetPlotDataV2 returns the VWAO line with its signals as typed markers; the
+// ±2 codes become strong_uptrend/strong_downtrend zones.
+func (v *VolumeWeightedAroonOscillator) GetPlotDataV2(startTime, interval int64) core.PlotDataWithMarkers {
+	return core.ExtractMarkers(v.GetPlotData(startTime, interval), core.TrendMarkerKinds)
+}
+
+// Describe reports the VWAO period and strong-trend threshold.
+func (v *VolumeWeightedAroonOscillator) Describe() core.IndicatorInfo {
+	return core.IndicatorInfo{
+		Name: "VWAO",
+		Params: map[string]any{
+			"period":       v.period,
+			"strongTrend":  v.config.VWAOStrongTrend,
+			"signalPeriod": v.signalPeriod,
+		},
+		SamplesNeeded: v.period + 1,
+	}
 }
@@ -2,6 +2,7 @@ package trend
 
 import (
 	"math"
+	"reflect"
 	"testing"
 
 	"github.com/evdnx/goti/config"
@@ -147,6 +148,34 @@ func TestVWAO_GetPlotData(t *testing.T) {
 	}
 }
 
+func TestVWAO_GetPlotDataSignalLineOptIn(t *testing.T) {
+	osc, _ := NewVolumeWeightedAroonOscillatorWithParams(5, config.DefaultConfig())
+	feed := func(from, to int) {
+		for i := from; i < to; i++ {
+			p := 100 + float64(i%7)
+			if err := osc.Add(p+1, p-1, p, 1000); err != nil {
+				t.Fatalf("Add failed: %v", err)
+			}
+		}
+	}
+	feed(0, 30)
+	if _, err := osc.GetSignal(); err != nil {
+		t.Fatalf("signal line should be computed by default: %v", err)
+	}
+	if plot := osc.GetPlotData(0, 60); len(plot) != 2 {
+		t.Fatalf("expected the default 2 series, got %d", len(plot))
+	}
+
+	if err := osc.SetSignalPeriod(3); err != nil {
+		t.Fatalf("SetSignalPeriod failed: %v", err)
+	}
+	feed(30, 40)
+	plot := osc.GetPlotData(0, 60)
+	if len(plot) != 3 || plot[2].Name != "Signal Line" {
+		t.Fatalf("expected a Signal Line series after SetSignalPeriod, got %d series", len(plot))
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Getters must return copies (mutating the returned slice must not affect the
 // internal state).
@@ -185,9 +214,50 @@ func TestVWAO_Clamping(t *testing.T) {
 	}
 }
 
+func TestVWAO_AroonComponentsBounded(t *testing.T) {
+	// The TestVWAO_Clamping scenario, plus windows where the extreme bars carry
+	// nearly all of the age-weighted volume: the components must already lie
+	// in [0, 100] and reproduce the oscillator without the clamp.
+	osc, _ := NewVolumeWeightedAroonOscillatorWithParams(1, config.DefaultConfig())
+	_ = osc.Add(100, 90, 95, 1)
+	_ = osc.Add(200, 80, 150, 1000)
+	checkVWAOComponents(t, osc)
+
+	wide, _ := NewVolumeWeightedAroonOscillatorWithParams(4, config.DefaultConfig())
+	bars := [][4]float64{
+		{300, 50, 100, 1e9}, // oldest bar: both extremes on huge volume
+		{110, 90, 100, 1},
+		{110, 90, 100, 1},
+		{110, 90, 100, 1},
+		{110, 90, 100, 1},
+		{400, 95, 105, 1e12}, // new high on extreme volume as the window rolls
+	}
+	for _, b := range bars {
+		_ = wide.Add(b[0], b[1], b[2], b[3])
+		if _, err := wide.Calculate(); err == nil {
+			checkVWAOComponents(t, wide)
+		}
+	}
+}
+
+func checkVWAOComponents(t *testing.T, osc *VolumeWeightedAroonOscillator) {
+	t.Helper()
+	up, down, err := osc.AroonComponents()
+	if err != nil {
+		t.Fatalf("AroonComponents failed: %v", err)
+	}
+	if up < 0 || up > 100 || down < 0 || down > 100 {
+		t.Fatalf("components outside [0, 100]: up %v down %v", up, down)
+	}
+	val, _ := osc.Calculate()
+	if up-down != val {
+		t.Fatalf("clamp changed the value: up-down %v, VWAO %v", up-down, val)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Simple calculation – now uses the data pattern above so the expected value
-// (-33.33…) is produced.
+// (45) is produced.
 // ---------------------------------------------------------------------------
 func TestVWAO_CalculationSimple(t *testing.T) {
 	period := 4
@@ -211,19 +281,51 @@ func TestVWAO_CalculationSimple(t *testing.T) {
 	   Manual calc (period=4)
 	     highest high = 104 (newest bar, idx 4)
 	     lowest low   = 80  (oldest bar, idx 0)
-	     totalWeightedAge = Σ (4‑i)*vol[i] = 40+36+28+16+0 = 120
-	     weightedHighAge = (4‑4)*vol[4] = 0
-	     weightedLowAge  = (4‑0)*vol[0] = 40
-	     aroonUp   = 0/120*100 = 0
-	     aroonDown = 40/120*100 ≈ 33.3333
-	     oscillator = -33.3333
+	     totalWeightedRecency = Σ i*vol[i] = 0+12+28+48+72 = 160
+	     weightedHighRecency = 4*vol[4] = 72
+	     weightedLowRecency  = 0*vol[0] = 0
+	     aroonUp   = 72/160*100 = 45
+	     aroonDown = 0/160*100  = 0
+	     oscillator = 45
 	*/
-	expected := -33.333333333333336
+	expected := 45.0
 	if math.Abs(val-expected) > 1e-9 {
 		t.Fatalf("unexpected VWAO: got %v want %v", val, expected)
 	}
 }
 
+func TestVWAO_FreshHeavyHighScoresHighest(t *testing.T) {
+	// Flat bars, then a new high on five times the volume. While it is the
+	// newest bar it should dominate Aroon Up; as it ages the reading decays.
+	osc, _ := NewVolumeWeightedAroonOscillatorWithParams(5, config.DefaultConfig())
+	for i := 0; i < 6; i++ {
+		_ = osc.Add(101, 99, 100, 1000)
+	}
+	if err := osc.Add(106, 100, 105, 5000); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	up, down, err := osc.AroonComponents()
+	if err != nil {
+		t.Fatalf("AroonComponents failed: %v", err)
+	}
+	// 5·5000 / (1000·(1+2+3+4) + 5·5000) = 25000 / 35000.
+	if !approxEqual(up, 25000.0/35000*100) || up < 70 {
+		t.Fatalf("fresh heavy high: aroonUp = %v, want %v", up, 25000.0/35000*100)
+	}
+	if down >= up {
+		t.Fatalf("fresh heavy high: aroonDown %v should trail aroonUp %v", down, up)
+	}
+	prev := up
+	for i := 0; i < 4; i++ {
+		_ = osc.Add(104, 100, 102, 1000)
+		up, _, _ = osc.AroonComponents()
+		if up >= prev {
+			t.Fatalf("ageing high %d: aroonUp %v did not decay from %v", i, up, prev)
+		}
+		prev = up
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Zero‑volume error – all three candles have volume 0, so the total weighted
 // volume is zero and the third Add must return an error.
@@ -303,3 +405,177 @@ func TestVWAO_SignalHelpers(t *testing.T) {
 		t.Fatalf("expected bullish divergence, got %v %s", div, dir)
 	}
 }
+
+func TestVWAO_OutputSlope(t *testing.T) {
+	osc, _ := NewVolumeWeightedAroonOscillator()
+	if _, err := osc.OutputSlope(1); err == nil {
+		t.Fatal("expected error without VWAO values")
+	}
+	osc.vwaoValues = []float64{-20, -15, -10, -5}
+	if got, err := osc.OutputSlope(1); err != nil || !approxEqual(got, 5) {
+		t.Fatalf("expected slope 5, got %v (%v)", got, err)
+	}
+}
+
+func TestVWAO_SignalLineCrossover(t *testing.T) {
+	osc, _ := NewVolumeWeightedAroonOscillatorWithParams(5, config.DefaultConfig())
+	if err := osc.SetSignalPeriod(0); err == nil {
+		t.Fatal("expected error for signal period 0")
+	}
+	if err := osc.SetSignalPeriod(3); err != nil {
+		t.Fatalf("SetSignalPeriod failed: %v", err)
+	}
+	if _, err := osc.GetSignal(); err == nil {
+		t.Fatal("expected error before the signal line is ready")
+	}
+
+	// Rally, decline, rally: each turn pushes the VWAO through its lagging
+	// signal line, once in each direction.
+	price := 100.0
+	bearish, bullish := false, false
+	for i := 0; i < 40; i++ {
+		switch {
+		case i < 15:
+			price += 1
+		case i < 28:
+			price -= 1
+		default:
+			price += 1
+		}
+		if err := osc.Add(price+0.5, price-0.5, price, 1000); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+		cross, err := osc.IsSignalCrossover()
+		if err != nil {
+			continue
+		}
+		if cross == -1 && i >= 15 && i < 28 {
+			bearish = true
+		}
+		if cross == 1 && i >= 28 {
+			bullish = true
+		}
+	}
+	if !bearish || !bullish {
+		t.Fatalf("expected a signal crossover at each turn (bullish=%v bearish=%v)", bullish, bearish)
+	}
+	if _, err := osc.GetSignal(); err != nil {
+		t.Fatalf("GetSignal failed: %v", err)
+	}
+	plot := osc.GetPlotData(0, 60)
+	if len(plot) != 3 || plot[2].Name != "Signal Line" || len(plot[2].X) != len(osc.GetSignalValues()) {
+		t.Fatalf("expected a Signal Line series, got %+v", plot)
+	}
+}
+
+func TestVWAO_SetCrossoverThreshold(t *testing.T) {
+	v, err := NewVolumeWeightedAroonOscillatorWithParams(5, config.DefaultConfig())
+	if err != nil {
+		t.Fatalf("constructor error: %v", err)
+	}
+	if err := v.SetCrossoverThreshold(math.NaN()); err == nil {
+		t.Fatal("expected error for a NaN threshold")
+	}
+	if err := v.SetCrossoverThreshold(5); err != nil {
+		t.Fatalf("SetCrossoverThreshold failed: %v", err)
+	}
+	plain, _ := NewVolumeWeightedAroonOscillatorWithParams(5, config.DefaultConfig())
+	// A fresh high on heavy volume lifts the VWAO past 70, and a fresh low on
+	// heavy volume pushes it past -70. At 5000 the crosses reach 71.4 and
+	// -71.4; at 6200 they reach 75.6 and -75.6.
+	closes := []float64{
+		93, 94, 95, 96, 97, 98, 99, 100, 99, 98, 97, 96, 95, 94, 93, 94,
+		95, 96, 97, 98, 99, 100, 99, 98, 97, 96, 95, 94, 93, 94, 95, 96,
+	}
+	heavy := map[int]float64{7: 5000, 14: 5000, 21: 6200, 28: 6200}
+	var plainBull, plainBear, bull, bear []int
+	for i, c := range closes {
+		vol := 1000.0
+		if h, ok := heavy[i]; ok {
+			vol = h
+		}
+		if err := plain.Add(c+1, c-1, c, vol); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+		_ = v.Add(c+1, c-1, c, vol)
+		if ok, _ := plain.IsBullishCrossover(); ok {
+			plainBull = append(plainBull, i)
+		}
+		if ok, _ := plain.IsBearishCrossover(); ok {
+			plainBear = append(plainBear, i)
+		}
+		if ok, _ := v.IsBullishCrossover(); ok {
+			bull = append(bull, i)
+		}
+		if ok, _ := v.IsBearishCrossover(); ok {
+			bear = append(bear, i)
+		}
+	}
+	if !reflect.DeepEqual(plainBull, []int{7, 21}) || !reflect.DeepEqual(plainBear, []int{14, 28}) {
+		t.Fatalf("unfiltered crosses: bullish %v, bearish %v", plainBull, plainBear)
+	}
+	if !reflect.DeepEqual(bull, []int{21}) || !reflect.DeepEqual(bear, []int{28}) {
+		t.Fatalf("filtered crosses: bullish %v, bearish %v; want [21] and [28]", bull, bear)
+	}
+}
+
+func TestVWAO_SetOutputTransform(t *testing.T) {
+	// The sine path below swings the VWAO between about ±36.
+	cfg := config.DefaultConfig()
+	cfg.VWAOStrongTrend = 25
+	plain, _ := NewVolumeWeightedAroonOscillatorWithParams(5, cfg)
+	neg, _ := NewVolumeWeightedAroonOscillatorWithParams(5, cfg)
+	neg.SetOutputTransform(func(v float64) float64 { return -v })
+
+	bulls, bears := 0, 0
+	for i := 0; i < 80; i++ {
+		p := 100 + 10*math.Sin(float64(i)/4)
+		vol := 1000 + 100*float64(i%3)
+		for _, v := range []*VolumeWeightedAroonOscillator{plain, neg} {
+			if err := v.Add(p+1, p-1, p, vol); err != nil {
+				t.Fatalf("Add failed: %v", err)
+			}
+		}
+		want, err := plain.Calculate()
+		if err != nil {
+			continue
+		}
+		if got, _ := neg.Calculate(); !approxEqual(got, -want) {
+			t.Fatalf("bar %d: transformed VWAO = %v, want %v", i, got, -want)
+		}
+		strong, _ := plain.IsStrongTrend()
+		if got, _ := neg.IsStrongTrend(); got != strong {
+			t.Fatalf("bar %d: strong trend %v, plain %v", i, got, strong)
+		}
+		bull, err := plain.IsBullishCrossover()
+		if err != nil {
+			continue
+		}
+		bear, _ := plain.IsBearishCrossover()
+		negBull, _ := neg.IsBullishCrossover()
+		negBear, _ := neg.IsBearishCrossover()
+		if negBull != bear || negBear != bull {
+			t.Fatalf("bar %d: crossovers not mirrored (plain %v/%v, negated %v/%v)", i, bull, bear, negBull, negBear)
+		}
+		if bull {
+			bulls++
+		}
+		if bear {
+			bears++
+		}
+	}
+	if bulls == 0 || bears == 0 {
+		t.Fatalf("expected crossovers both ways on the sine path (bullish=%d bearish=%d)", bulls, bears)
+	}
+
+	want := plain.GetPlotData(0, 60)[0].Y
+	got := neg.GetPlotData(0, 60)[0].Y
+	if len(got) != len(want) {
+		t.Fatalf("plot length %d, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !approxEqual(got[i], -want[i]) {
+			t.Fatalf("plot[%d] = %v, want %v", i, got[i], -want[i])
+		}
+	}
+}
@@ -8,7 +8,27 @@ import (
 	"github.com/evdnx/goti/indicator/core"
 )
 
+// trueRangeHistory bounds the true ranges retained for GetTrueRanges.
+const trueRangeHistory = 256
+
+// GapMode selects whether the true range spans gaps from the prior close.
+type GapMode int
+
+const (
+	// IncludeGaps uses the classic true range, max(high, prevClose) −
+	// min(low, prevClose) (the default).
+	IncludeGaps GapMode = iota
+	// ExcludeGaps uses only the bar's own high − low, so overnight or
+	// session gaps do not inflate an intraday ATR.
+	ExcludeGaps
+)
+
 // AverageTrueRange calculates the Average True Range (ATR).
+//
+// The true range of a candle needs the previous close, so the first candle
+// only seeds it and the first ATR needs period+1 candles. With period 1 the
+// ATR is therefore simply the latest true range, available from the second
+// candle on.
 type AverageTrueRange struct {
 	period        int
 	highs         []float64
@@ -16,11 +36,28 @@ type AverageTrueRange struct {
 	closes        []float64
 	atrValues     []float64
 	lastValue     float64
+	emitted       int  // values produced since the last reset (see CalculateWithConfidence)
 	validateClose bool // optional validation of close price against high/low
+	autoCorrect   bool // repair inverted/out-of-range candles instead of rejecting
+	corrections   int  // number of candles repaired by autoCorrect
+	earlyValues   bool // return best-effort values before the period is filled
+	strictOutput  bool // AddCandle fails on a non-finite ATR (see WithStrictOutputCheck)
+	retention     int  // ATR values kept in atrValues; 0 = period (see SetRetentionLength)
+	gapMode       GapMode
+
+	smoothing core.MovingAverageType // how true ranges are averaged (RMA by default)
+
+	minPeriods int  // true ranges required before the first (approximate) value; 0 = period
+	warm       bool // the first full-period value has been produced
 
 	// Rolling true range state (for O(1) ATR updates)
 	trQueue []float64
 	trSum   float64
+
+	trueRanges []float64 // unsmoothed true ranges (see GetTrueRanges)
+
+	// ATR-percent boundaries of VolatilityRegime (see SetRegimeThresholds)
+	regimeLow, regimeHigh, regimeExtreme float64
 }
 
 /*
@@ -53,6 +90,10 @@ func NewAverageTrueRangeWithParams(period int, opts ...ATROption) (*AverageTrueR
 		atrValues:     make([]float64, 0, period),
 		trQueue:       make([]float64, 0, period),
 		validateClose: true, // enabled by default
+		smoothing:     core.RMAMovingAverage,
+		regimeLow:     DefaultRegimeLowPct,
+		regimeHigh:    DefaultRegimeHighPct,
+		regimeExtreme: DefaultRegimeExtremePct,
 	}
 	for _, opt := range opts {
 		opt(atr)
@@ -71,11 +112,38 @@ func WithCloseValidation(enabled bool) ATROption {
 	return func(a *AverageTrueRange) { a.validateClose = enabled }
 }
 
+// WithAutoCorrect repairs dirty candles instead of rejecting them: an inverted
+// high/low pair is swapped and the close is clamped into [low, high].
+func WithAutoCorrect(enabled bool) ATROption {
+	return func(a *AverageTrueRange) { a.autoCorrect = enabled }
+}
+
+// WithEarlyValues lets Calculate return an approximation before period+1
+// candles exist: the mean of the true ranges seen so far, with the first
+// candle's high-low range standing in when no previous close is available.
+// CalculateWithWarmup reports whether the value is fully warm.
+func WithEarlyValues(enabled bool) ATROption {
+	return func(a *AverageTrueRange) { a.earlyValues = enabled }
+}
+
+// WithStrictOutputCheck makes AddCandle return an error wrapping
+// core.ErrNonFinite as soon as it computes a NaN or ±Inf ATR. The value is
+// still recorded, so Validate keeps reporting it until Reset.
+func WithStrictOutputCheck(enabled bool) ATROption {
+	return func(a *AverageTrueRange) { a.strictOutput = enabled }
+}
+
 /* ---------- Public API ---------- */
 
 // AddCandle appends a new OHLC data point.
 // It validates the inputs and, when enough data is present, updates the ATR series.
 func (atr *AverageTrueRange) AddCandle(high, low, close float64) error {
+	if atr.autoCorrect {
+		var corrected bool
+		if high, low, close, corrected = core.CorrectCandle(high, low, close); corrected {
+			atr.corrections++
+		}
+	}
 	if high < low {
 		return errors.New("high must be >= low")
 	}
@@ -93,22 +161,123 @@ func (atr *AverageTrueRange) AddCandle(high, low, close float64) error {
 	atr.lows = append(atr.lows, low)
 	atr.closes = append(atr.closes, close)
 
+	var outErr error
 	// Compute ATR once we have period+1 closing prices.
 	if len(atr.closes) >= 2 {
 		currentTR := atr.trueRange(len(atr.closes) - 1)
+		atr.trueRanges = core.KeepLast(append(atr.trueRanges, currentTR), trueRangeHistory)
+		produced := len(atr.atrValues)
 		atr.pushTrueRange(currentTR)
+		if atr.strictOutput && len(atr.atrValues) > produced {
+			outErr = core.CheckFinite("atr", atr.lastValue)
+		}
 	}
 	atr.trimSlices()
-	return nil
+	return outErr
+}
+
+// Validate scans the retained candles, true ranges and ATR values for NaN or
+// ±Inf and reports the first one found with its index in the retained slice,
+// then checks the running true-range sum and the latest value.
+func (atr *AverageTrueRange) Validate() error {
+	for _, s := range []struct {
+		name   string
+		values []float64
+	}{
+		{"highs", atr.highs},
+		{"lows", atr.lows},
+		{"closes", atr.closes},
+		{"trQueue", atr.trQueue},
+		{"trueRanges", atr.trueRanges},
+		{"atrValues", atr.atrValues},
+	} {
+		if err := core.ValidateFinite(s.name, s.values); err != nil {
+			return err
+		}
+	}
+	if err := core.CheckFinite("trSum", atr.trSum); err != nil {
+		return err
+	}
+	return core.CheckFinite("lastValue", atr.lastValue)
 }
 
 // Calculate returns the most recent ATR value.
 // An error is returned if the series has not yet produced any output.
 func (atr *AverageTrueRange) Calculate() (float64, error) {
-	if len(atr.atrValues) == 0 {
-		return 0, fmt.Errorf("ATR not ready – need at least %d data points", atr.period+1)
+	v, _, err := atr.CalculateWithWarmup()
+	return v, err
+}
+
+// CalculateWithWarmup behaves like Calculate but also reports whether the
+// value is based on a full period. With WithEarlyValues enabled it returns an
+// approximate value (warm == false) as soon as one candle exists.
+func (atr *AverageTrueRange) CalculateWithWarmup() (float64, bool, error) {
+	if len(atr.atrValues) > 0 {
+		return atr.lastValue, atr.warm, nil
+	}
+	if atr.earlyValues && len(atr.closes) > 0 {
+		if len(atr.trQueue) > 0 {
+			return atr.trSum / float64(len(atr.trQueue)), false, nil
+		}
+		return atr.highs[0] - atr.lows[0], false, nil
+	}
+	return 0, false, fmt.Errorf("ATR not ready – need at least %d data points", atr.period+1)
+}
+
+// CalculateWithConfidence returns the latest ATR with a warm-up confidence
+// from WarmupConfidence: 0 on the first emitted value, rising to 1 three
+// periods later as the smoothing forgets its seed. Early values returned by
+// WithEarlyValues before any ATR is emitted have confidence 0.
+func (atr *AverageTrueRange) CalculateWithConfidence() (value, confidence float64, err error) {
+	value, err = atr.Calculate()
+	if err != nil {
+		return 0, 0, err
+	}
+	return value, core.WarmupConfidence(atr.emitted, atr.period), nil
+}
+
+// CorrectionCount returns how many candles WithAutoCorrect has repaired.
+func (atr *AverageTrueRange) CorrectionCount() int { return atr.corrections }
+
+// Peek returns the ATR that AddCandle followed by Calculate would produce for
+// the given candle, without committing it. The true-range window is updated
+// on a copy and discarded.
+func (atr *AverageTrueRange) Peek(high, low, close float64) (float64, error) {
+	c := atr.clone()
+	if err := c.AddCandle(high, low, close); err != nil {
+		return 0, err
+	}
+	return c.Calculate()
+}
+
+// Compute feeds the candles through AddCandle and returns the ATR emitted for
+// each one, skipping warm-up candles and candles AddCandle rejects. Only the
+// first min(len(...)) candles of the three slices are used. The indicator
+// stays warmed with the whole series.
+func (atr *AverageTrueRange) Compute(highs, lows, closes []float64) []float64 {
+	n := min(len(highs), len(lows), len(closes))
+	out := make([]float64, 0, n)
+	for i := 0; i < n; i++ {
+		if atr.AddCandle(highs[i], lows[i], closes[i]) != nil {
+			continue
+		}
+		if v, err := atr.Calculate(); err == nil {
+			out = append(out, v)
+		}
 	}
-	return atr.lastValue, nil
+	return out
+}
+
+// clone returns a copy whose slices do not share backing arrays with atr.
+func (atr *AverageTrueRange) clone() *AverageTrueRange {
+	c := *atr
+	c.highs = core.CopySlice(atr.highs)
+	c.lows = core.CopySlice(atr.lows)
+	c.closes = core.CopySlice(atr.closes)
+	c.atrValues = core.CopySlice(atr.atrValues)
+	c.trQueue = core.CopySlice(atr.trQueue)
+	c.trueRanges = core.CopySlice(atr.trueRanges)
+	return &c
 }
 
 // Reset clears all stored data and starts fresh.
@@ -118,8 +287,83 @@ func (atr *AverageTrueRange) Reset() {
 	atr.closes = atr.closes[:0]
 	atr.atrValues = atr.atrValues[:0]
 	atr.lastValue = 0
+	atr.emitted = 0
 	atr.trQueue = atr.trQueue[:0]
 	atr.trSum = 0
+	atr.trueRanges = atr.trueRanges[:0]
+	atr.corrections = 0
+	atr.warm = false
+}
+
+// SetMinPeriods lets the ATR emit values once n true ranges (n+1 candles)
+// exist instead of a full period. Until the period fills, each value is the
+// plain mean of the true ranges seen so far and CalculateWithWarmup reports
+// it as not warm; smoothing takes over from the first full-period value.
+// n must lie in [1, period].
+func (atr *AverageTrueRange) SetMinPeriods(n int) error {
+	if n < 1 || n > atr.period {
+		return fmt.Errorf("min periods must be within [1, %d], got %d", atr.period, n)
+	}
+	atr.minPeriods = n
+	return nil
+}
+
+// emitAfter returns the number of true ranges needed before a value is
+// recorded. A min-periods setting above a since-lowered period is capped.
+func (atr *AverageTrueRange) emitAfter() int {
+	if atr.minPeriods > 0 && atr.minPeriods < atr.period {
+		return atr.minPeriods
+	}
+	return atr.period
+}
+
+// SetSmoothing selects how the true-range series is averaged:
+// core.RMAMovingAverage (Wilder, the default), core.SMAMovingAverage (plain
+// mean of the last `period` true ranges) or core.EMAMovingAverage (alpha =
+// 2/(period+1)). All modes seed from the mean of the first `period` true
+// ranges; the true range itself is unchanged. The ATR is reset because the
+// existing series was produced with the previous smoothing.
+func (atr *AverageTrueRange) SetSmoothing(mode core.MovingAverageType) error {
+	switch mode {
+	case core.RMAMovingAverage, core.SMAMovingAverage, core.EMAMovingAverage:
+	default:
+		return fmt.Errorf("unsupported ATR smoothing %q", mode)
+	}
+	atr.smoothing = mode
+	atr.Reset()
+	return nil
+}
+
+// SetGapMode chooses between the gap-inclusive true range (IncludeGaps, the
+// default) and the intrabar range high − low (ExcludeGaps). The first
+// candle still only seeds the series in both modes, so warm-up is unchanged.
+// The ATR is reset because the existing series used the previous mode.
+func (atr *AverageTrueRange) SetGapMode(mode GapMode) error {
+	if mode != IncludeGaps && mode != ExcludeGaps {
+		return fmt.Errorf("unsupported ATR gap mode %d", int(mode))
+	}
+	atr.gapMode = mode
+	atr.Reset()
+	return nil
+}
+
+// GapMode returns the active gap handling.
+func (atr *AverageTrueRange) GapMode() GapMode { return atr.gapMode }
+
+// Smoothing returns the active true-range smoothing mode.
+func (atr *AverageTrueRange) Smoothing() core.MovingAverageType { return atr.smoothing }
+
+// SetRetentionLength sets how many ATR values GetATRValues and the plot
+// export keep, independently of the smoothing period: the candle buffers
+// still hold only period+1 bars. 0 restores the default of one period;
+// shrinking trims the retained values at once.
+func (atr *AverageTrueRange) SetRetentionLength(n int) error {
+	if n < 0 {
+		return errors.New("retention length must be non-negative")
+	}
+	atr.retention = n
+	atr.trimSlices()
+	return nil
 }
 
 // SetPeriod changes the look‑back period. All historic data is discarded because
@@ -135,22 +379,29 @@ func (atr *AverageTrueRange) SetPeriod(period int) error {
 
 /* ---------- Internal helpers ---------- */
 
-// trimSlices ensures the internal slices never exceed the configured window.
+// trimSlices ensures the candle slices never exceed the configured window
+// and the ATR values the retention length.
 func (atr *AverageTrueRange) trimSlices() {
 	if len(atr.closes) > atr.period+1 {
 		atr.highs = atr.highs[len(atr.highs)-atr.period-1:]
 		atr.lows = atr.lows[len(atr.lows)-atr.period-1:]
 		atr.closes = atr.closes[len(atr.closes)-atr.period-1:]
 	}
-	if len(atr.atrValues) > atr.period {
-		atr.atrValues = atr.atrValues[len(atr.atrValues)-atr.period:]
+	keep := atr.period
+	if atr.retention > 0 {
+		keep = atr.retention
 	}
+	atr.atrValues = core.KeepLast(atr.atrValues, keep)
 }
 
 // trueRange computes the true‑range for a given index (index refers to the
-// position inside the internal slices, not the original data stream).
+// position inside the internal slices, not the original data stream). With
+// ExcludeGaps it is just the bar's high − low.
 func (atr *AverageTrueRange) trueRange(idx int) float64 {
 	highLow := atr.highs[idx] - atr.lows[idx]
+	if atr.gapMode == ExcludeGaps {
+		return highLow
+	}
 	highPrevClose := math.Abs(atr.highs[idx] - atr.closes[idx-1])
 	lowPrevClose := math.Abs(atr.lows[idx] - atr.closes[idx-1])
 	return math.Max(highLow, math.Max(highPrevClose, lowPrevClose))
@@ -182,14 +433,29 @@ func (atr *AverageTrueRange) pushTrueRange(tr float64) {
 		atr.trSum -= removed
 	}
 
-	// Only produce an ATR value when the window is full (requires period+1 closes).
+	// Produce a partial-window mean while warming up when SetMinPeriods allows
+	// it; otherwise values start once the window is full (period+1 closes).
+	if !atr.warm && len(atr.trQueue) < atr.period {
+		if len(atr.trQueue) >= atr.emitAfter() {
+			atr.lastValue = atr.trSum / float64(len(atr.trQueue))
+			atr.atrValues = append(atr.atrValues, atr.lastValue)
+			atr.emitted++
+		}
+		return
+	}
 	if len(atr.trQueue) == atr.period {
-		if len(atr.atrValues) == 0 {
+		switch {
+		case !atr.warm || atr.smoothing == core.SMAMovingAverage:
 			atr.lastValue = atr.trSum / float64(atr.period)
-		} else {
+		case atr.smoothing == core.EMAMovingAverage:
+			alpha := 2.0 / float64(atr.period+1)
+			atr.lastValue = alpha*tr + (1-alpha)*atr.lastValue
+		default:
 			atr.lastValue = ((atr.lastValue * float64(atr.period-1)) + tr) / float64(atr.period)
 		}
+		atr.warm = true
 		atr.atrValues = append(atr.atrValues, atr.lastValue)
+		atr.emitted++
 	}
 }
 
@@ -199,3 +465,25 @@ func (atr *AverageTrueRange) GetATRValues() []float64 { return core.CopySlice(at
 func (atr *AverageTrueRange) GetHighs() []float64     { return core.CopySlice(atr.highs) }
 func (atr *AverageTrueRange) GetLows() []float64      { return core.CopySlice(atr.lows) }
 func (atr *AverageTrueRange) GetCloses() []float64    { return core.CopySlice(atr.closes) }
+
+// GetTrueRanges returns the per-candle true ranges before any smoothing, one
+// for every candle after the first, oldest first. The latest 256 are kept
+// regardless of the period, so the series outlives the ATR's own window.
+func (atr *AverageTrueRange) GetTrueRanges() []float64 { return core.CopySlice(atr.trueRanges) }
+
+// Describe reports the ATR's period and candle-handling options.
+func (atr *AverageTrueRange) Describe() core.IndicatorInfo {
+	return core.IndicatorInfo{
+		Name: "ATR",
+		Params: map[string]any{
+			"period":        atr.period,
+			"validateClose": atr.validateClose,
+			"autoCorrect":   atr.autoCorrect,
+			"earlyValues":   atr.earlyValues,
+			"smoothing":     string(atr.smoothing),
+			"excludeGaps":   atr.gapMode == ExcludeGaps,
+			"minPeriods":    atr.emitAfter(),
+		},
+		SamplesNeeded: atr.emitAfter() + 1,
+	}
+}
@@ -1,8 +1,12 @@
 package volatility
 
 import (
+	"errors"
 	"math"
+	"strings"
 	"testing"
+
+	"github.com/evdnx/goti/indicator/core"
 )
 
 /*
@@ -74,6 +78,55 @@ func TestWithCloseValidation_Disabled(t *testing.T) {
 	}
 }
 
+func TestWithAutoCorrect_RepairsDirtyCandles(t *testing.T) {
+	atr, err := NewAverageTrueRangeWithParams(3, WithAutoCorrect(true))
+	if err != nil {
+		t.Fatalf("constructor error: %v", err)
+	}
+	// Swapped high/low.
+	if err := atr.AddCandle(9, 10, 9.5); err != nil {
+		t.Fatalf("expected swapped candle to be corrected, got %v", err)
+	}
+	// Close above the high gets clamped.
+	if err := atr.AddCandle(11, 10, 12); err != nil {
+		t.Fatalf("expected out-of-range close to be corrected, got %v", err)
+	}
+	// A clean candle leaves the counter alone.
+	if err := atr.AddCandle(12, 11, 11.5); err != nil {
+		t.Fatalf("AddCandle failed: %v", err)
+	}
+	if atr.CorrectionCount() != 2 {
+		t.Fatalf("expected 2 corrections, got %d", atr.CorrectionCount())
+	}
+	highs, lows, closes := atr.GetHighs(), atr.GetLows(), atr.GetCloses()
+	if highs[0] != 10 || lows[0] != 9 || closes[1] != 11 {
+		t.Fatalf("unexpected corrected data: highs=%v lows=%v closes=%v", highs, lows, closes)
+	}
+}
+
+func TestWithEarlyValues_ReturnsApproximation(t *testing.T) {
+	atr, err := NewAverageTrueRangeWithParams(5, WithEarlyValues(true))
+	if err != nil {
+		t.Fatalf("constructor error: %v", err)
+	}
+	if err := atr.AddCandle(12, 10, 11); err != nil {
+		t.Fatalf("AddCandle failed: %v", err)
+	}
+	v, warm, err := atr.CalculateWithWarmup()
+	if err != nil || warm || v != 2 {
+		t.Fatalf("expected early ATR 2 (warm=false), got %v warm=%v err=%v", v, warm, err)
+	}
+	if len(atr.GetATRValues()) != 0 {
+		t.Fatal("early values must not be appended to the ATR series")
+	}
+
+	strict, _ := NewAverageTrueRangeWithParams(5)
+	_ = strict.AddCandle(12, 10, 11)
+	if _, err := strict.Calculate(); err == nil {
+		t.Fatal("default mode must still error before warm-up")
+	}
+}
+
 /*
 -------------------------------------------------------------
 
@@ -167,6 +220,61 @@ func TestATR_Calculation_SimpleSeries(t *testing.T) {
 	}
 }
 
+/*
+-------------------------------------------------------------
+
+	Smoothing modes – Wilder (default), SMA and EMA of true range
+	-------------------------------------------------------------
+*/
+func TestATR_SetSmoothing_Modes(t *testing.T) {
+	// Closes stay at 100 and each candle straddles it, so TR = high-low.
+	// Ranges 2,4,6,8,16,4 give true ranges 4,6,8,16,4 from the second candle.
+	ranges := []float64{2, 4, 6, 8, 16, 4}
+	want := map[core.MovingAverageType][]float64{
+		core.RMAMovingAverage: {6, 28.0 / 3, (56.0/3 + 4) / 3},
+		core.SMAMovingAverage: {6, 10, 28.0 / 3},
+		core.EMAMovingAverage: {6, 11, 7.5},
+	}
+
+	def, _ := NewAverageTrueRangeWithParams(3)
+	if def.Smoothing() != core.RMAMovingAverage {
+		t.Fatalf("expected Wilder smoothing by default, got %s", def.Smoothing())
+	}
+
+	for mode, expected := range want {
+		atr, _ := NewAverageTrueRangeWithParams(3)
+		if err := atr.SetSmoothing(mode); err != nil {
+			t.Fatalf("SetSmoothing(%s) failed: %v", mode, err)
+		}
+		for _, r := range ranges {
+			if err := atr.AddCandle(100+r/2, 100-r/2, 100); err != nil {
+				t.Fatalf("AddCandle failed: %v", err)
+			}
+		}
+		got := atr.GetATRValues()
+		if len(got) != len(expected) {
+			t.Fatalf("%s: expected %d values, got %v", mode, len(expected), got)
+		}
+		for i := range expected {
+			if math.Abs(got[i]-expected[i]) > 1e-9 {
+				t.Fatalf("%s: value %d = %v, want %v", mode, i, got[i], expected[i])
+			}
+		}
+	}
+
+	// The default instance must match explicit Wilder smoothing.
+	for _, r := range ranges {
+		_ = def.AddCandle(100+r/2, 100-r/2, 100)
+	}
+	if v, _ := def.Calculate(); math.Abs(v-want[core.RMAMovingAverage][2]) > 1e-9 {
+		t.Fatalf("default ATR %v does not match Wilder smoothing", v)
+	}
+
+	if err := def.SetSmoothing(core.WMAMovingAverage); err == nil {
+		t.Fatal("expected error for unsupported smoothing")
+	}
+}
+
 /*
 -------------------------------------------------------------
 
@@ -330,3 +438,249 @@ func TestATR_SliceLimits(t *testing.T) {
 		}
 	}
 }
+
+func TestSetMinPeriods_EmitsPartialMean(t *testing.T) {
+	atr, _ := NewAverageTrueRangeWithParams(5)
+	if err := atr.SetMinPeriods(6); err == nil {
+		t.Fatal("expected error for min periods above the period")
+	}
+	if got := atr.Describe().SamplesNeeded; got != 6 {
+		t.Fatalf("expected 6 samples needed before SetMinPeriods, got %d", got)
+	}
+	if err := atr.SetMinPeriods(2); err != nil {
+		t.Fatalf("SetMinPeriods failed: %v", err)
+	}
+	// Two true ranges need three candles, matching the first value below.
+	if got := atr.Describe().SamplesNeeded; got != 3 {
+		t.Fatalf("expected Describe to report 3 samples needed, got %d", got)
+	}
+	ref, _ := NewAverageTrueRangeWithParams(5)
+
+	highs, lows, closes := generateOHLC(100, 1, 12)
+	for i := range closes {
+		// Widen every third candle so the smoothing has something to smooth.
+		if i%3 == 0 {
+			highs[i] += 2
+		}
+		_ = atr.AddCandle(highs[i], lows[i], closes[i])
+		_ = ref.AddCandle(highs[i], lows[i], closes[i])
+		_, warm, err := atr.CalculateWithWarmup()
+		switch {
+		case i < 2 && err == nil:
+			t.Fatalf("candle %d: expected no value yet", i+1)
+		case i >= 2 && err != nil:
+			t.Fatalf("candle %d: expected a value, got %v", i+1, err)
+		}
+		if i >= 2 && warm != (i >= 5) {
+			t.Fatalf("candle %d: unexpected warm state %v", i+1, warm)
+		}
+	}
+	got, _ := atr.Calculate()
+	want, _ := ref.Calculate()
+	if math.Abs(got-want) > 1e-9 {
+		t.Fatalf("expected %v after warm-up, got %v", want, got)
+	}
+}
+
+func TestATR_Peek(t *testing.T) {
+	atr, _ := NewAverageTrueRangeWithParams(3)
+	highs, lows, closes := generateOHLC(100, 1, 6)
+	for i := range closes {
+		if err := atr.AddCandle(highs[i], lows[i], closes[i]); err != nil {
+			t.Fatalf("AddCandle failed: %v", err)
+		}
+	}
+	before := atr.GetATRValues()
+	last, _ := atr.Calculate()
+
+	peeked, err := atr.Peek(112, 101, 104)
+	if err != nil {
+		t.Fatalf("Peek failed: %v", err)
+	}
+	if got, _ := atr.Calculate(); got != last || len(atr.GetATRValues()) != len(before) {
+		t.Fatalf("Peek mutated state: %v -> %v", last, got)
+	}
+	if err := atr.AddCandle(112, 101, 104); err != nil {
+		t.Fatalf("AddCandle failed: %v", err)
+	}
+	if got, _ := atr.Calculate(); got != peeked {
+		t.Fatalf("Peek returned %v, Add+Calculate %v", peeked, got)
+	}
+}
+
+func TestATR_ComputeMatchesLoop(t *testing.T) {
+	highs, lows, closes := generateOHLC(100, 1, 12)
+	atr, _ := NewAverageTrueRangeWithParams(4)
+	got := atr.Compute(highs, lows, closes)
+
+	ref, _ := NewAverageTrueRangeWithParams(4)
+	var want []float64
+	for i := range closes {
+		_ = ref.AddCandle(highs[i], lows[i], closes[i])
+		if v, err := ref.Calculate(); err == nil {
+			want = append(want, v)
+		}
+	}
+	if len(want) == 0 || len(got) != len(want) {
+		t.Fatalf("expected %d values, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("value %d: Compute %v, loop %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestATR_CalculateWithConfidence(t *testing.T) {
+	atr, _ := NewAverageTrueRangeWithParams(4)
+	highs, lows, closes := generateOHLC(100, 0.5, 30)
+	prev := -1.0
+	for i := range closes {
+		if err := atr.AddCandle(highs[i], lows[i], closes[i]); err != nil {
+			t.Fatalf("AddCandle failed: %v", err)
+		}
+		_, conf, err := atr.CalculateWithConfidence()
+		if err != nil {
+			continue
+		}
+		if conf < prev || conf < 0 || conf > 1 {
+			t.Fatalf("bar %d: confidence %v after %v", i, conf, prev)
+		}
+		prev = conf
+	}
+	if prev != 1 {
+		t.Fatalf("expected full confidence after %d bars, got %v", len(closes), prev)
+	}
+}
+
+func TestATR_GetTrueRanges(t *testing.T) {
+	atr, _ := NewAverageTrueRangeWithParams(5)
+	// A 3-point step gaps each candle above the previous close: the true
+	// range is high − prevClose = (base+3+1) − base = 4, not the 2-point bar.
+	highs, lows, closes := generateOHLC(100, 3, 20)
+	for i := range closes {
+		if err := atr.AddCandle(highs[i], lows[i], closes[i]); err != nil {
+			t.Fatalf("AddCandle failed: %v", err)
+		}
+		trs := atr.GetTrueRanges()
+		if len(trs) != i {
+			t.Fatalf("bar %d: expected %d true ranges, got %d", i, i, len(trs))
+		}
+		for j, tr := range trs {
+			if math.Abs(tr-4) > 1e-9 {
+				t.Fatalf("bar %d: true range %d = %v, want 4", i, j, tr)
+			}
+		}
+	}
+
+	// With period 1 the ATR is the latest true range, from the second candle.
+	one, _ := NewAverageTrueRangeWithParams(1)
+	_ = one.AddCandle(highs[0], lows[0], closes[0])
+	if _, err := one.Calculate(); err == nil {
+		t.Fatal("period 1 still needs a previous close")
+	}
+	_ = one.AddCandle(highs[1], lows[1], closes[1])
+	if v, err := one.Calculate(); err != nil || math.Abs(v-4) > 1e-9 {
+		t.Fatalf("expected period-1 ATR 4, got %v (%v)", v, err)
+	}
+
+	atr.Reset()
+	if len(atr.GetTrueRanges()) != 0 {
+		t.Fatal("Reset should clear the true ranges")
+	}
+}
+
+func TestATR_SetRetentionLength(t *testing.T) {
+	atr, _ := NewAverageTrueRangeWithParams(5)
+	if err := atr.SetRetentionLength(-1); err == nil {
+		t.Fatal("expected error for negative retention")
+	}
+	if err := atr.SetRetentionLength(100); err != nil {
+		t.Fatalf("SetRetentionLength failed: %v", err)
+	}
+	highs, lows, closes := generateOHLC(100, 1, 200)
+	for i := range closes {
+		if err := atr.AddCandle(highs[i], lows[i], closes[i]); err != nil {
+			t.Fatalf("AddCandle failed: %v", err)
+		}
+	}
+	if got := len(atr.GetATRValues()); got != 100 {
+		t.Fatalf("expected 100 retained ATR values, got %d", got)
+	}
+	if len(atr.closes) != 6 {
+		t.Fatalf("calculation buffer should stay at period+1 candles, got %d", len(atr.closes))
+	}
+}
+
+func TestATR_SetGapMode(t *testing.T) {
+	// Two-point bars with one 20-point overnight gap up in the middle.
+	var highs, lows, closes []float64
+	for i := 0; i < 10; i++ {
+		base := 100.0
+		if i >= 5 {
+			base = 120
+		}
+		highs = append(highs, base+1)
+		lows = append(lows, base-1)
+		closes = append(closes, base)
+	}
+	run := func(mode GapMode) *AverageTrueRange {
+		atr, _ := NewAverageTrueRangeWithParams(3)
+		if err := atr.SetGapMode(mode); err != nil {
+			t.Fatalf("SetGapMode failed: %v", err)
+		}
+		for i := range closes {
+			if err := atr.AddCandle(highs[i], lows[i], closes[i]); err != nil {
+				t.Fatalf("AddCandle failed: %v", err)
+			}
+		}
+		return atr
+	}
+
+	gapped := run(IncludeGaps)
+	if trs := gapped.GetTrueRanges(); trs[4] != 21 {
+		t.Fatalf("gap bar true range = %v, want 21 (121 − prior close 100)", trs[4])
+	}
+	intrabar := run(ExcludeGaps)
+	for i, tr := range intrabar.GetTrueRanges() {
+		if tr != 2 {
+			t.Fatalf("ExcludeGaps true range %d = %v, want high − low = 2", i, tr)
+		}
+	}
+	v, _ := intrabar.Calculate()
+	if math.Abs(v-2) > 1e-9 {
+		t.Fatalf("ExcludeGaps ATR = %v, want 2", v)
+	}
+	if g, _ := gapped.Calculate(); g <= v {
+		t.Fatalf("gap-inclusive ATR %v should exceed the intrabar ATR %v", g, v)
+	}
+
+	if err := intrabar.SetGapMode(GapMode(5)); err == nil {
+		t.Fatal("expected error for an unknown gap mode")
+	}
+}
+
+func TestAverageTrueRange_ValidateNamesState(t *testing.T) {
+	atr, _ := NewAverageTrueRangeWithParams(3)
+	highs, lows, closes := generateOHLC(100, 1, 6)
+	for i := range highs {
+		if err := atr.AddCandle(highs[i], lows[i], closes[i]); err != nil {
+			t.Fatalf("AddCandle failed: %v", err)
+		}
+	}
+	if err := atr.Validate(); err != nil {
+		t.Fatalf("expected clean state, got %v", err)
+	}
+
+	saved := atr.trQueue[0]
+	atr.trQueue[0] = math.NaN()
+	if err := atr.Validate(); !errors.Is(err, core.ErrNonFinite) || !strings.Contains(err.Error(), "trQueue[0]") {
+		t.Fatalf("expected Validate to flag trQueue[0], got %v", err)
+	}
+	atr.trQueue[0] = saved
+
+	atr.trSum = math.Inf(1)
+	if err := atr.Validate(); !errors.Is(err, core.ErrNonFinite) || !strings.Contains(err.Error(), "trSum") {
+		t.Fatalf("expected Validate to flag trSum, got %v", err)
+	}
+}
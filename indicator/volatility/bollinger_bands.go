@@ -2,6 +2,7 @@ package volatility
 
 import (
 	"errors"
+	"fmt"
 	"math"
 
 	"github.com/evdnx/goti/indicator/core"
@@ -12,6 +13,19 @@ const (
 	DefaultBollingerMultiplier = 2.0
 )
 
+// bollingerMaxBandwidths bounds the bandwidth history used for squeeze
+// ranking; it is independent of the period so long look-backs are possible.
+const bollingerMaxBandwidths = 512
+
+// Adaptive multiplier tuning (see WithAdaptiveMultiplier): each banded close
+// moves the multiplier by bollingerAdaptiveStep·(outside − target), within
+// [bollingerMinMultiplier, bollingerMaxMultiplier].
+const (
+	bollingerAdaptiveStep  = 0.05
+	bollingerMinMultiplier = 0.5
+	bollingerMaxMultiplier = 5.0
+)
+
 // BollingerBands calculates upper/middle/lower bands based on a moving average
 // and standard deviation of closing prices.
 type BollingerBands struct {
@@ -23,13 +37,36 @@ type BollingerBands struct {
 	middle []float64
 	lower  []float64
 
-	runningSum   float64
-	runningSumSq float64
-	sumComp      float64 // Kahan compensation for runningSum
-	sumSqComp    float64 // Kahan compensation for runningSumSq
-	lastUpper    float64
-	lastMiddle   float64
-	lastLower    float64
+	bandCloses []float64 // close of the bar each band value was computed on
+	bandwidths []float64 // (upper-lower)/middle per bar, see BandwidthPercentile
+
+	windowMean float64 // mean of the closes in the window
+	windowM2   float64 // sum of squared deviations from windowMean
+	lastUpper  float64
+	lastMiddle float64
+	lastLower  float64
+	bars       int // closes accepted since the last reset (see GetOverlayPlotData)
+
+	adaptive       bool    // multiplier follows the escape rate (see WithAdaptiveMultiplier)
+	adaptiveTarget float64 // target fraction of closes outside the bands
+	currentMult    float64 // multiplier applied to the next bands (see CurrentMultiplier)
+}
+
+// BollingerOption configures a BollingerBands instance.
+type BollingerOption func(*BollingerBands)
+
+// WithAdaptiveMultiplier lets the multiplier drift so that roughly
+// targetOutsideFrac (e.g. 0.05) of closes finish outside the bands. After
+// every banded close the multiplier rises by 0.05·(1−target) when the close
+// escaped and falls by 0.05·target when it stayed inside, so it settles where
+// the escape rate matches the target, within [0.5, 5]. The configured
+// multiplier is the starting point and is restored by Reset. The target must
+// lie in (0, 1).
+func WithAdaptiveMultiplier(targetOutsideFrac float64) BollingerOption {
+	return func(b *BollingerBands) {
+		b.adaptive = true
+		b.adaptiveTarget = targetOutsideFrac
+	}
 }
 
 // NewBollingerBands creates a Bollinger Bands calculator with default settings.
@@ -38,22 +75,31 @@ func NewBollingerBands() (*BollingerBands, error) {
 }
 
 // NewBollingerBandsWithParams creates a Bollinger Bands calculator with custom
-// period and multiplier.
-func NewBollingerBandsWithParams(period int, multiplier float64) (*BollingerBands, error) {
+// period and multiplier. Functional options are applied last.
+func NewBollingerBandsWithParams(period int, multiplier float64, opts ...BollingerOption) (*BollingerBands, error) {
 	if period < 1 {
 		return nil, errors.New("period must be at least 1")
 	}
 	if multiplier <= 0 {
 		return nil, errors.New("multiplier must be positive")
 	}
-	return &BollingerBands{
-		period:     period,
-		multiplier: multiplier,
-		closes:     make([]float64, 0, period),
-		upper:      make([]float64, 0, period),
-		middle:     make([]float64, 0, period),
-		lower:      make([]float64, 0, period),
-	}, nil
+	b := &BollingerBands{
+		period:      period,
+		multiplier:  multiplier,
+		currentMult: multiplier,
+		closes:      make([]float64, 0, period),
+		upper:       make([]float64, 0, period),
+		middle:      make([]float64, 0, period),
+		lower:       make([]float64, 0, period),
+		bandCloses:  make([]float64, 0, period),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	if b.adaptive && !(b.adaptiveTarget > 0 && b.adaptiveTarget < 1) {
+		return nil, errors.New("adaptive target fraction must lie in (0, 1)")
+	}
+	return b, nil
 }
 
 // Add appends a new closing price and updates the bands when enough data is
@@ -63,31 +109,27 @@ func (b *BollingerBands) Add(close float64) error {
 		return errors.New("invalid price")
 	}
 	b.closes = append(b.closes, close)
-	b.kahanAdd(close)
-	b.kahanAddSq(close)
+	b.bars++
 
 	// Maintain a fixed-size window so updates are O(1).
 	if len(b.closes) > b.period {
 		removed := b.closes[0]
 		b.closes = b.closes[1:]
-		b.kahanAdd(-removed)
-		b.kahanAddSq(-removed)
+		b.slideMoments(removed, close)
+	} else {
+		b.addMoment(close)
 	}
 
 	if len(b.closes) >= b.period {
-		mean := b.runningSum / float64(b.period)
+		mean := b.windowMean
 
 		std := 0.0
 		if b.period > 1 {
-			variance := (b.runningSumSq - (b.runningSum*b.runningSum)/float64(b.period)) / float64(b.period-1)
-			if variance < 0 {
-				variance = 0 // guard against negative zero/rounding
-			}
-			std = math.Sqrt(variance)
+			std = math.Sqrt(max(b.windowM2, 0) / float64(b.period-1))
 		}
 
-		upper := mean + b.multiplier*std
-		lower := mean - b.multiplier*std
+		upper := mean + b.currentMult*std
+		lower := mean - b.currentMult*std
 
 		b.lastMiddle = mean
 		b.lastUpper = upper
@@ -96,6 +138,17 @@ func (b *BollingerBands) Add(close float64) error {
 		b.upper = append(b.upper, upper)
 		b.middle = append(b.middle, mean)
 		b.lower = append(b.lower, lower)
+		b.bandCloses = append(b.bandCloses, close)
+
+		width := 0.0
+		if mean != 0 {
+			width = (upper - lower) / mean
+		}
+		b.bandwidths = append(b.bandwidths, width)
+
+		if b.adaptive {
+			b.adaptMultiplier(close > upper || close < lower)
+		}
 	}
 
 	b.trimSlices()
@@ -116,13 +169,31 @@ func (b *BollingerBands) Reset() {
 	b.upper = b.upper[:0]
 	b.middle = b.middle[:0]
 	b.lower = b.lower[:0]
-	b.runningSum = 0
-	b.runningSumSq = 0
-	b.sumComp = 0
-	b.sumSqComp = 0
+	b.bandCloses = b.bandCloses[:0]
+	b.bandwidths = b.bandwidths[:0]
+	b.windowMean = 0
+	b.windowM2 = 0
 	b.lastUpper, b.lastMiddle, b.lastLower = 0, 0, 0
+	b.bars = 0
+	b.currentMult = b.multiplier
 }
 
+// adaptMultiplier nudges the multiplier towards the adaptive target after a
+// close landed outside (or inside) its bands.
+func (b *BollingerBands) adaptMultiplier(outside bool) {
+	hit := 0.0
+	if outside {
+		hit = 1
+	}
+	next := b.currentMult + bollingerAdaptiveStep*(hit-b.adaptiveTarget)
+	b.currentMult = core.Clamp(next, bollingerMinMultiplier, bollingerMaxMultiplier)
+}
+
+// CurrentMultiplier returns the standard-deviation multiplier the next bands
+// will use: the configured value, or its adapted value under
+// WithAdaptiveMultiplier.
+func (b *BollingerBands) CurrentMultiplier() float64 { return b.currentMult }
+
 // SetParams updates period and multiplier and resets internal state.
 func (b *BollingerBands) SetParams(period int, multiplier float64) error {
 	if period < 1 {
@@ -146,6 +217,47 @@ func (b *BollingerBands) GetMiddle() []float64 { return core.CopySlice(b.middle)
 // GetLower returns a defensive copy of the lower band values.
 func (b *BollingerBands) GetLower() []float64 { return core.CopySlice(b.lower) }
 
+// BandTouchStats counts, over the retained band history, the bars whose close
+// was at or above the upper band and at or below the lower band. A high count
+// relative to the window length marks a stretched, trending regime in which
+// fading band touches is less reliable.
+func (b *BollingerBands) BandTouchStats() (upperTouches, lowerTouches int, err error) {
+	if len(b.upper) == 0 {
+		return 0, 0, errors.New("no Bollinger Bands data")
+	}
+	for i, c := range b.bandCloses {
+		if c >= b.upper[i] {
+			upperTouches++
+		}
+		if c <= b.lower[i] {
+			lowerTouches++
+		}
+	}
+	return upperTouches, lowerTouches, nil
+}
+
+// BandwidthPercentile ranks the current bandwidth, (upper-lower)/middle,
+// against the last window bandwidths including itself and returns the result
+// on a 0–100 scale: 0 means the bands are the tightest in the window (the
+// deepest squeeze), 100 the widest. Up to 512 bandwidths are retained.
+func (b *BollingerBands) BandwidthPercentile(window int) (float64, error) {
+	if window < 2 || window > bollingerMaxBandwidths {
+		return 0, fmt.Errorf("window must be within [2, %d], got %d", bollingerMaxBandwidths, window)
+	}
+	if len(b.bandwidths) < window {
+		return 0, fmt.Errorf("need %d bandwidths, have %d", window, len(b.bandwidths))
+	}
+	recent := b.bandwidths[len(b.bandwidths)-window:]
+	current := recent[window-1]
+	below := 0
+	for _, w := range recent[:window-1] {
+		if w < current {
+			below++
+		}
+	}
+	return float64(below) / float64(window-1) * 100, nil
+}
+
 // GetPlotData emits plot data for the upper/middle/lower bands.
 func (b *BollingerBands) GetPlotData(startTime, interval int64) []core.PlotData {
 	if len(b.upper) == 0 {
@@ -164,26 +276,66 @@ func (b *BollingerBands) GetPlotData(startTime, interval int64) []core.PlotData
 	}
 }
 
+// GetPlotDataRange returns the Bollinger Bands plot data for bars [from, to).
+func (b *BollingerBands) GetPlotDataRange(startTime, interval int64, from, to int) []core.PlotData {
+	return core.SlicePlotData(b.GetPlotData(startTime, interval), from, to)
+}
+
+// GetOverlayPlotData returns the three bands for drawing over a price chart,
+// with X set to the absolute index of the bar each value was computed on
+// (counted from the first close since construction or Reset) and timestamps
+// startTime + X·interval, so the bands line up with the candles and with
+// other overlays such as the HMA's.
+func (b *BollingerBands) GetOverlayPlotData(startTime, interval int64) []core.PlotData {
+	if len(b.upper) == 0 {
+		return nil
+	}
+	last := b.bars - 1
+	return []core.PlotData{
+		core.OverlayPlotData("Bollinger Upper", b.upper, last, startTime, interval),
+		core.OverlayPlotData("Bollinger Middle", b.middle, last, startTime, interval),
+		core.OverlayPlotData("Bollinger Lower", b.lower, last, startTime, interval),
+	}
+}
+
 func (b *BollingerBands) trimSlices() {
 	b.closes = core.KeepLast(b.closes, b.period)
 	maxKeep := b.period
 	b.upper = core.KeepLast(b.upper, maxKeep)
 	b.middle = core.KeepLast(b.middle, maxKeep)
 	b.lower = core.KeepLast(b.lower, maxKeep)
+	b.bandCloses = core.KeepLast(b.bandCloses, maxKeep)
+	b.bandwidths = core.KeepLast(b.bandwidths, bollingerMaxBandwidths)
 }
 
-// Kahan compensated addition for runningSum.
-func (b *BollingerBands) kahanAdd(v float64) {
-	y := v - b.sumComp
-	t := b.runningSum + y
-	b.sumComp = (t - b.runningSum) - y
-	b.runningSum = t
+// addMoment folds x into the window mean and M2 while the window is still
+// filling (Welford's update).
+func (b *BollingerBands) addMoment(x float64) {
+	n := float64(len(b.closes))
+	delta := x - b.windowMean
+	b.windowMean += delta / n
+	b.windowM2 += delta * (x - b.windowMean)
 }
 
-// Kahan compensated addition for runningSumSq.
-func (b *BollingerBands) kahanAddSq(v float64) {
-	y := v*v - b.sumSqComp
-	t := b.runningSumSq + y
-	b.sumSqComp = (t - b.runningSumSq) - y
-	b.runningSumSq = t
+// slideMoments replaces removed by added in a full window. Working with
+// deviations from the mean rather than raw sums of squares keeps the
+// variance exact to rounding even when prices are large relative to their
+// spread (e.g. 1e6 ± 1), where Σx² − (Σx)²/n cancels catastrophically.
+func (b *BollingerBands) slideMoments(removed, added float64) {
+	oldMean := b.windowMean
+	b.windowMean += (added - removed) / float64(b.period)
+	b.windowM2 += (added - removed) * (added - b.windowMean + removed - oldMean)
+}
+
+// Describe reports the band period and standard-deviation multiplier.
+func (b *BollingerBands) Describe() core.IndicatorInfo {
+	return core.IndicatorInfo{
+		Name: "Bollinger Bands",
+		Params: map[string]any{
+			"period":         b.period,
+			"multiplier":     b.multiplier,
+			"adaptiveTarget": b.adaptiveTarget,
+		},
+		SamplesNeeded: b.period,
+	}
 }
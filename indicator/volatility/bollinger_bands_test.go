@@ -1,6 +1,10 @@
 package volatility
 
-import "testing"
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
 
 func TestBollingerBands_Calculation(t *testing.T) {
 	bb, err := NewBollingerBandsWithParams(3, 2)
@@ -31,3 +35,160 @@ func TestBollingerBands_InvalidPrice(t *testing.T) {
 		t.Fatal("expected error for negative price")
 	}
 }
+
+func TestBollingerBands_BandTouchStats(t *testing.T) {
+	bb, err := NewBollingerBandsWithParams(4, 1)
+	if err != nil {
+		t.Fatalf("constructor error: %v", err)
+	}
+	if _, _, err := bb.BandTouchStats(); err == nil {
+		t.Fatal("expected error before any band exists")
+	}
+
+	// 14 pierces the upper band (13), 6 pierces the lower band (~6.73), and
+	// the trailing 10s stay inside.
+	for _, c := range []float64{10, 10, 10, 14, 6, 10, 10} {
+		if err := bb.Add(c); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	up, down, err := bb.BandTouchStats()
+	if err != nil || up != 1 || down != 1 {
+		t.Fatalf("expected 1 upper / 1 lower touch, got %d/%d (err %v)", up, down, err)
+	}
+
+	// One more bar pushes the upper touch out of the retained window.
+	_ = bb.Add(10)
+	up, down, _ = bb.BandTouchStats()
+	if up != 0 || down != 1 {
+		t.Fatalf("expected 0 upper / 1 lower touch after trimming, got %d/%d", up, down)
+	}
+}
+
+func TestBollingerBands_BandwidthPercentile(t *testing.T) {
+	bb, _ := NewBollingerBandsWithParams(10, 2)
+	if _, err := bb.BandwidthPercentile(1); err == nil {
+		t.Fatal("expected error for window < 2")
+	}
+
+	// Wide swings for a while, then the range collapses.
+	for i := 0; i < 60; i++ {
+		swing := 5.0
+		if i >= 45 {
+			swing = 0.2
+		}
+		price := 100 + swing
+		if i%2 == 0 {
+			price = 100 - swing
+		}
+		if err := bb.Add(price); err != nil {
+			t.Fatalf("add failed: %v", err)
+		}
+	}
+	if _, err := bb.BandwidthPercentile(100); err == nil {
+		t.Fatal("expected error when the history is shorter than the window")
+	}
+	pct, err := bb.BandwidthPercentile(40)
+	if err != nil {
+		t.Fatalf("BandwidthPercentile failed: %v", err)
+	}
+	if pct > 10 {
+		t.Fatalf("expected a squeeze to rank near 0, got %.1f", pct)
+	}
+}
+
+func TestBollingerBands_AdaptiveMultiplier(t *testing.T) {
+	if _, err := NewBollingerBandsWithParams(20, 1, WithAdaptiveMultiplier(0)); err == nil {
+		t.Fatal("expected error for a zero target fraction")
+	}
+	fixed, _ := NewBollingerBandsWithParams(20, 1)
+	adaptive, err := NewBollingerBandsWithParams(20, 1, WithAdaptiveMultiplier(0.05))
+	if err != nil {
+		t.Fatalf("constructor error: %v", err)
+	}
+	if fixed.CurrentMultiplier() != 1 || adaptive.CurrentMultiplier() != 1 {
+		t.Fatal("both bands should start at the configured multiplier")
+	}
+
+	// Volatile random walk with occasional jumps.
+	rng := rand.New(rand.NewSource(7))
+	price := 100.0
+	fixedOut, adaptiveOut, counted := 0, 0, 0
+	for i := 0; i < 3000; i++ {
+		step := rng.NormFloat64()
+		if rng.Intn(10) == 0 {
+			step *= 4
+		}
+		price = max(price+step, 1)
+		_ = fixed.Add(price)
+		_ = adaptive.Add(price)
+		if i < 1000 {
+			continue
+		}
+		counted++
+		if u, _, l, err := fixed.Calculate(); err == nil && (price > u || price < l) {
+			fixedOut++
+		}
+		if u, _, l, err := adaptive.Calculate(); err == nil && (price > u || price < l) {
+			adaptiveOut++
+		}
+	}
+	if fixed.CurrentMultiplier() != 1 {
+		t.Fatalf("fixed multiplier drifted to %v", fixed.CurrentMultiplier())
+	}
+	if m := adaptive.CurrentMultiplier(); m <= 1.5 {
+		t.Fatalf("expected the multiplier to widen well above 1, got %.3f", m)
+	}
+	fixedFrac := float64(fixedOut) / float64(counted)
+	adaptiveFrac := float64(adaptiveOut) / float64(counted)
+	if adaptiveFrac < 0.02 || adaptiveFrac > 0.09 {
+		t.Fatalf("expected about 5%% of closes outside the adaptive bands, got %.3f (fixed %.3f)", adaptiveFrac, fixedFrac)
+	}
+	if fixedFrac <= adaptiveFrac {
+		t.Fatalf("fixed 1σ bands should leak more (%.3f) than the adaptive ones (%.3f)", fixedFrac, adaptiveFrac)
+	}
+
+	adaptive.Reset()
+	if adaptive.CurrentMultiplier() != 1 {
+		t.Fatal("Reset should restore the configured multiplier")
+	}
+}
+
+func TestBollingerBands_RollingVarianceMatchesTwoPass(t *testing.T) {
+	const period = 20
+	bb, _ := NewBollingerBandsWithParams(period, 2)
+	rng := rand.New(rand.NewSource(3))
+	closes := make([]float64, 0, 5000)
+	for i := 0; i < 5000; i++ {
+		c := 1e6 + rng.Float64()*2 - 1 // 1e6 ± 1
+		closes = append(closes, c)
+		if err := bb.Add(c); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+		if i < period-1 {
+			continue
+		}
+		window := closes[len(closes)-period:]
+		mean := 0.0
+		for _, v := range window {
+			mean += v
+		}
+		mean /= period
+		ss := 0.0
+		for _, v := range window {
+			ss += (v - mean) * (v - mean)
+		}
+		std := math.Sqrt(ss / (period - 1))
+
+		upper, middle, lower, err := bb.Calculate()
+		if err != nil {
+			t.Fatalf("Calculate failed: %v", err)
+		}
+		if math.Abs(middle-mean) > 1e-6 {
+			t.Fatalf("bar %d: middle %.9f, want %.9f", i, middle, mean)
+		}
+		if math.Abs((upper-middle)/2-std) > 1e-6*std || math.Abs((middle-lower)/2-std) > 1e-6*std {
+			t.Fatalf("bar %d: band half-width %.9f, want 2σ with σ=%.9f", i, (upper-middle)/2, std)
+		}
+	}
+}
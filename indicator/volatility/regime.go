@@ -0,0 +1,80 @@
+package volatility
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Regime buckets the ATR as a percentage of price.
+type Regime int
+
+const (
+	VolatilityLow Regime = iota
+	VolatilityNormal
+	VolatilityHigh
+	VolatilityExtreme
+)
+
+// String returns the regime name, e.g. "High".
+func (r Regime) String() string {
+	switch r {
+	case VolatilityLow:
+		return "Low"
+	case VolatilityHigh:
+		return "High"
+	case VolatilityExtreme:
+		return "Extreme"
+	default:
+		return "Normal"
+	}
+}
+
+// Default VolatilityRegime boundaries in ATR percent of price. They are the
+// low (0.15%), normal-high (0.3%) and high (0.5%) volatility bands the
+// scalping suite uses to adapt its signal thresholds.
+const (
+	DefaultRegimeLowPct     = 0.15
+	DefaultRegimeHighPct    = 0.3
+	DefaultRegimeExtremePct = 0.5
+)
+
+// VolatilityRegime classifies the latest ATR as a percentage of
+// referencePrice (usually the last close): Low below the low threshold,
+// Extreme above the extreme one, High above the high one and Normal
+// otherwise. A value exactly on a boundary falls into the calmer bucket.
+func (atr *AverageTrueRange) VolatilityRegime(referencePrice float64) (Regime, error) {
+	if !(referencePrice > 0) || math.IsInf(referencePrice, 0) {
+		return VolatilityNormal, errors.New("reference price must be positive")
+	}
+	v, err := atr.Calculate()
+	if err != nil {
+		return VolatilityNormal, err
+	}
+	switch pct := v / referencePrice * 100; {
+	case pct > atr.regimeExtreme:
+		return VolatilityExtreme, nil
+	case pct > atr.regimeHigh:
+		return VolatilityHigh, nil
+	case pct < atr.regimeLow:
+		return VolatilityLow, nil
+	default:
+		return VolatilityNormal, nil
+	}
+}
+
+// SetRegimeThresholds sets the ATR-percent boundaries used by
+// VolatilityRegime; they must satisfy 0 < low ≤ high < extreme. The ATR
+// values are kept.
+func (atr *AverageTrueRange) SetRegimeThresholds(lowPct, highPct, extremePct float64) error {
+	if !(lowPct > 0 && lowPct <= highPct && highPct < extremePct) || math.IsInf(extremePct, 0) {
+		return fmt.Errorf("regime thresholds must satisfy 0 < low ≤ high < extreme, got %v/%v/%v", lowPct, highPct, extremePct)
+	}
+	atr.regimeLow, atr.regimeHigh, atr.regimeExtreme = lowPct, highPct, extremePct
+	return nil
+}
+
+// RegimeThresholds returns the low, high and extreme ATR-percent boundaries.
+func (atr *AverageTrueRange) RegimeThresholds() (lowPct, highPct, extremePct float64) {
+	return atr.regimeLow, atr.regimeHigh, atr.regimeExtreme
+}
@@ -0,0 +1,53 @@
+package volatility
+
+import "testing"
+
+func TestATR_VolatilityRegime(t *testing.T) {
+	atr, _ := NewAverageTrueRangeWithParams(3)
+	if _, err := atr.VolatilityRegime(100); err == nil {
+		t.Fatal("expected error before the ATR is ready")
+	}
+	// Constant 1-point ranges give an ATR of exactly 1.
+	for i := 0; i < 5; i++ {
+		if err := atr.AddCandle(101, 100, 100.5); err != nil {
+			t.Fatalf("AddCandle failed: %v", err)
+		}
+	}
+	if v, _ := atr.Calculate(); v != 1 {
+		t.Fatalf("expected ATR 1, got %v", v)
+	}
+
+	// ATR% = 100/price: 0.1%, 0.2%, 0.3% (boundary), 0.4%, 0.8%.
+	cases := []struct {
+		price float64
+		want  Regime
+	}{
+		{1000, VolatilityLow},
+		{500, VolatilityNormal},
+		{1000.0 / 3, VolatilityNormal},
+		{250, VolatilityHigh},
+		{125, VolatilityExtreme},
+	}
+	for _, c := range cases {
+		got, err := atr.VolatilityRegime(c.price)
+		if err != nil || got != c.want {
+			t.Fatalf("price %v: got %v (%v), want %v", c.price, got, err, c.want)
+		}
+	}
+	if _, err := atr.VolatilityRegime(0); err == nil {
+		t.Fatal("expected error for a zero reference price")
+	}
+
+	if err := atr.SetRegimeThresholds(0.5, 0.3, 1); err == nil {
+		t.Fatal("expected error for low > high")
+	}
+	if err := atr.SetRegimeThresholds(0.25, 1, 2); err != nil {
+		t.Fatalf("SetRegimeThresholds failed: %v", err)
+	}
+	if got, _ := atr.VolatilityRegime(250); got != VolatilityNormal {
+		t.Fatalf("0.4%% with raised thresholds: got %v, want Normal", got)
+	}
+	if got, _ := atr.VolatilityRegime(500); got != VolatilityLow {
+		t.Fatalf("0.2%% with raised thresholds: got %v, want Low", got)
+	}
+}
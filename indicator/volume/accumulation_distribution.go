@@ -0,0 +1,78 @@
+package volume
+
+import (
+	"errors"
+
+	"github.com/evdnx/goti/indicator/core"
+)
+
+// AccumulationDistribution tracks the cumulative Accumulation/Distribution
+// line: each bar adds its close location value ((C-L)-(H-C))/(H-L) scaled by
+// volume. Bars with no range contribute nothing.
+type AccumulationDistribution struct {
+	adValues []float64
+	last     float64
+	hasData  bool
+}
+
+// NewAccumulationDistribution constructs an empty A/D line.
+func NewAccumulationDistribution() *AccumulationDistribution {
+	return &AccumulationDistribution{
+		adValues: make([]float64, 0, 64),
+	}
+}
+
+// Add ingests a new OHLCV candle and extends the A/D line.
+func (a *AccumulationDistribution) Add(high, low, close, volume float64) error {
+	if high < low || !core.IsNonNegativePrice(close) || !core.IsValidVolume(volume) {
+		return errors.New("invalid price or volume")
+	}
+	if close > high || close < low {
+		return errors.New("close must lie between high and low")
+	}
+	mfv := 0.0
+	if rng := high - low; rng > 0 {
+		mfv = ((close - low) - (high - close)) / rng * volume
+	}
+	a.last += mfv
+	a.hasData = true
+	a.adValues = append(a.adValues, a.last)
+	a.adValues = core.KeepLast(a.adValues, 1024)
+	return nil
+}
+
+// Calculate returns the current A/D value.
+func (a *AccumulationDistribution) Calculate() (float64, error) {
+	if !a.hasData {
+		return 0, errors.New("no A/D data")
+	}
+	return a.last, nil
+}
+
+// Reset clears the accumulated line.
+func (a *AccumulationDistribution) Reset() {
+	a.adValues = a.adValues[:0]
+	a.last = 0
+	a.hasData = false
+}
+
+// GetValues returns the A/D series (defensive copy).
+func (a *AccumulationDistribution) GetValues() []float64 { return core.CopySlice(a.adValues) }
+
+// GetPlotData emits the A/D line.
+func (a *AccumulationDistribution) GetPlotData(startTime, interval int64) []core.PlotData {
+	if len(a.adValues) == 0 {
+		return nil
+	}
+	x := make([]float64, len(a.adValues))
+	for i := range x {
+		x[i] = float64(i)
+	}
+	return []core.PlotData{{
+		Name:      "Accumulation/Distribution",
+		X:         x,
+		Y:         a.adValues,
+		Type:      "line",
+		Timestamp: core.GenerateTimestamps(startTime, len(a.adValues), interval),
+	}}
+}
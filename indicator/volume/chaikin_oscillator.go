@@ -0,0 +1,160 @@
+package volume
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/evdnx/goti/indicator/core"
+)
+
+const (
+	DefaultChaikinFastPeriod = 3
+	DefaultChaikinSlowPeriod = 10
+)
+
+// ChaikinOscillator measures the momentum of the Accumulation/Distribution
+// line as EMA(fast) of A/D minus EMA(slow) of A/D.
+type ChaikinOscillator struct {
+	fastPeriod int
+	slowPeriod int
+
+	ad      *AccumulationDistribution
+	fastEMA *core.MovingAverage
+	slowEMA *core.MovingAverage
+
+	values    []float64
+	lastValue float64
+}
+
+// NewChaikinOscillator creates an oscillator with the classic 3/10 periods.
+func NewChaikinOscillator() (*ChaikinOscillator, error) {
+	return NewChaikinOscillatorWithParams(DefaultChaikinFastPeriod, DefaultChaikinSlowPeriod)
+}
+
+// NewChaikinOscillatorWithParams creates an oscillator with custom EMA periods.
+func NewChaikinOscillatorWithParams(fast, slow int) (*ChaikinOscillator, error) {
+	if fast < 1 || slow < 1 {
+		return nil, errors.New("periods must be at least 1")
+	}
+	if fast >= slow {
+		return nil, errors.New("fast period must be less than slow period")
+	}
+	fastEMA, err := core.NewMovingAverage(core.EMAMovingAverage, fast)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fast EMA: %w", err)
+	}
+	slowEMA, err := core.NewMovingAverage(core.EMAMovingAverage, slow)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create slow EMA: %w", err)
+	}
+	return &ChaikinOscillator{
+		fastPeriod: fast,
+		slowPeriod: slow,
+		ad:         NewAccumulationDistribution(),
+		fastEMA:    fastEMA,
+		slowEMA:    slowEMA,
+		values:     make([]float64, 0, slow),
+	}, nil
+}
+
+// Add ingests a new OHLCV candle. Values are produced once the slow EMA is
+// seeded.
+func (c *ChaikinOscillator) Add(high, low, close, volume float64) error {
+	if err := c.ad.Add(high, low, close, volume); err != nil {
+		return err
+	}
+	line, _ := c.ad.Calculate()
+	// The A/D line is unbounded in both directions, so use AddValue.
+	if err := c.fastEMA.AddValue(line); err != nil {
+		return err
+	}
+	if err := c.slowEMA.AddValue(line); err != nil {
+		return err
+	}
+
+	fast, errFast := c.fastEMA.Calculate()
+	slow, errSlow := c.slowEMA.Calculate()
+	if errFast == nil && errSlow == nil {
+		c.lastValue = fast - slow
+		c.values = append(c.values, c.lastValue)
+		c.values = core.KeepLast(c.values, c.slowPeriod*4)
+	}
+	return nil
+}
+
+// Calculate returns the latest oscillator value.
+func (c *ChaikinOscillator) Calculate() (float64, error) {
+	if len(c.values) == 0 {
+		return 0, errors.New("no Chaikin Oscillator data")
+	}
+	return c.lastValue, nil
+}
+
+// IsBullishCrossover reports whether the oscillator just crossed above zero.
+func (c *ChaikinOscillator) IsBullishCrossover() (bool, error) {
+	if len(c.values) < 2 {
+		return false, errors.New("insufficient data for crossover")
+	}
+	prev, curr := c.values[len(c.values)-2], c.values[len(c.values)-1]
+	return prev <= 0 && curr > 0, nil
+}
+
+// IsBearishCrossover reports whether the oscillator just crossed below zero.
+func (c *ChaikinOscillator) IsBearishCrossover() (bool, error) {
+	if len(c.values) < 2 {
+		return false, errors.New("insufficient data for crossover")
+	}
+	prev, curr := c.values[len(c.values)-2], c.values[len(c.values)-1]
+	return prev >= 0 && curr < 0, nil
+}
+
+// Reset clears all state while preserving the periods.
+func (c *ChaikinOscillator) Reset() {
+	c.ad.Reset()
+	c.fastEMA.Reset()
+	c.slowEMA.Reset()
+	c.values = c.values[:0]
+	c.lastValue = 0
+}
+
+// GetValues returns the oscillator series (defensive copy).
+func (c *ChaikinOscillator) GetValues() []float64 { return core.CopySlice(c.values) }
+
+// GetADValues returns the underlying A/D line (defensive copy).
+func (c *ChaikinOscillator) GetADValues() []float64 { return c.ad.GetValues() }
+
+// GetPlotData emits the oscillator line and zero-line crossover markers.
+func (c *ChaikinOscillator) GetPlotData(startTime, interval int64) []core.PlotData {
+	if len(c.values) == 0 {
+		return nil
+	}
+	x := make([]float64, len(c.values))
+	signals := make([]float64, len(c.values))
+	for i := range c.values {
+		x[i] = float64(i)
+		if i > 0 {
+			if c.values[i-1] <= 0 && c.values[i] > 0 {
+				signals[i] = 1
+			} else if c.values[i-1] >= 0 && c.values[i] < 0 {
+				signals[i] = -1
+			}
+		}
+	}
+	ts := core.GenerateTimestamps(startTime, len(c.values), interval)
+	return []core.PlotData{
+		{
+			Name:      "Chaikin Oscillator",
+			X:         x,
+			Y:         c.values,
+			Type:      "line",
+			Timestamp: ts,
+		},
+		{
+			Name:      "Signals",
+			X:         x,
+			Y:         signals,
+			Type:      "scatter",
+			Timestamp: ts,
+		},
+	}
+}
@@ -0,0 +1,75 @@
+package volume
+
+import "testing"
+
+func TestChaikinOscillator_InvalidParams(t *testing.T) {
+	if _, err := NewChaikinOscillatorWithParams(0, 10); err == nil {
+		t.Fatal("expected error for zero fast period")
+	}
+	if _, err := NewChaikinOscillatorWithParams(10, 3); err == nil {
+		t.Fatal("expected error when fast >= slow")
+	}
+}
+
+func TestChaikinOscillator_AccumulationAndDistribution(t *testing.T) {
+	co, err := NewChaikinOscillatorWithParams(3, 10)
+	if err != nil {
+		t.Fatalf("constructor error: %v", err)
+	}
+
+	// Closes pinned near the high: accumulation pushes the A/D line up and
+	// the fast EMA above the slow one.
+	for i := 0; i < 20; i++ {
+		if err := co.Add(11, 9, 10.9, 1000+float64(i)*100); err != nil {
+			t.Fatalf("Add failed at idx %d: %v", i, err)
+		}
+	}
+	val, err := co.Calculate()
+	if err != nil {
+		t.Fatalf("Calculate returned error: %v", err)
+	}
+	if val <= 0 {
+		t.Fatalf("expected positive oscillator during accumulation, got %.4f", val)
+	}
+
+	// Closes pinned near the low: distribution drags it below zero.
+	crossed := false
+	for i := 0; i < 20; i++ {
+		if err := co.Add(11, 9, 9.1, 3000); err != nil {
+			t.Fatalf("Add failed at idx %d: %v", i, err)
+		}
+		if bear, _ := co.IsBearishCrossover(); bear {
+			crossed = true
+		}
+	}
+	val, _ = co.Calculate()
+	if val >= 0 {
+		t.Fatalf("expected negative oscillator during distribution, got %.4f", val)
+	}
+	if !crossed {
+		t.Fatal("expected a bearish zero-line crossover")
+	}
+
+	data := co.GetPlotData(0, 60)
+	if len(data) != 2 || len(data[0].X) != len(co.GetValues()) {
+		t.Fatalf("unexpected plot data: %+v", data)
+	}
+}
+
+func TestAccumulationDistribution_Line(t *testing.T) {
+	ad := NewAccumulationDistribution()
+	// CLV = ((10-8)-(10-10))/2 = 1 -> +500; then CLV = -1 -> -200.
+	if err := ad.Add(10, 8, 10, 500); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := ad.Add(10, 8, 8, 200); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	val, err := ad.Calculate()
+	if err != nil || val != 300 {
+		t.Fatalf("expected A/D 300, got %v (err %v)", val, err)
+	}
+	if err := ad.Add(10, 8, 11, 100); err == nil {
+		t.Fatal("expected error for close outside the bar range")
+	}
+}
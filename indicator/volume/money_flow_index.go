@@ -46,10 +46,75 @@ type MoneyFlowIndex struct {
 	mfiValues []float64
 	lastValue float64
 	config    config.IndicatorConfig
+	emitted   int // values produced since the last reset (see CalculateWithConfidence)
 
 	flows       []float64 // signed money flow for each bar after the first
 	positiveSum float64
 	negativeSum float64
+
+	autoCorrect bool // repair inverted/out-of-range candles instead of rejecting
+	corrections int  // number of candles repaired by autoCorrect
+
+	minPeriods int // flows required before the first (approximate) value; 0 = period
+
+	transform    func(float64) float64 // optional output hook (see SetOutputTransform)
+	extremes     core.Extremes         // output range since the last reset (see ObservedMin)
+	strictOutput bool                  // Add fails on a non-finite MFI (see WithStrictOutputCheck)
+	divergence   core.DivergenceWindow // closes and MFI values for IsDivergenceWithStrength
+
+	historyLen int       // MFI values kept for ValueAtPercentile; 0 = mfiValues only
+	retention  int       // MFI values kept in mfiValues; 0 = period (see SetRetentionLength)
+	history    []float64 // see WithHistory
+
+	inclusivity core.CrossoverInclusivity // boundary rule of the crossover helpers
+	crossDelta  float64                   // minimum move beyond the level (see SetCrossoverThreshold)
+
+	// Volume scale auto-calibration (see WithVolumeAutoScale)
+	volumeAutoScale bool
+	calibVolumes    []float64 // volumes of the first period bars
+	autoScale       float64   // calibrated scale; 0 until the first non-zero median
+}
+
+// MFIOption configures a MoneyFlowIndex instance.
+type MFIOption func(*MoneyFlowIndex)
+
+// WithAutoCorrect swaps an inverted high/low pair and clamps the close into
+// [low, high] instead of rejecting the sample.
+func WithAutoCorrect(enabled bool) MFIOption {
+	return func(m *MoneyFlowIndex) { m.autoCorrect = enabled }
+}
+
+// WithVolumeAutoScale replaces the fixed MFIVolumeScale with the running
+// median volume of the first `period` bars, after which the scale is frozen.
+// The scale cancels in the money ratio, so MFI values are unchanged; the point
+// is to keep the money-flow sums well-conditioned on instruments whose volume
+// is orders of magnitude away from the default scale. While calibrating, the
+// flows already accumulated are rescaled whenever the median moves.
+func WithVolumeAutoScale(enabled bool) MFIOption {
+	return func(m *MoneyFlowIndex) { m.volumeAutoScale = enabled }
+}
+
+// WithStrictOutputCheck makes Add return an error wrapping core.ErrNonFinite
+// as soon as it computes a NaN or ±Inf MFI. The value is still recorded, so
+// Validate keeps reporting it until Reset.
+func WithStrictOutputCheck(enabled bool) MFIOption {
+	return func(m *MoneyFlowIndex) { m.strictOutput = enabled }
+}
+
+// WithHistory keeps the last n MFI values for ValueAtPercentile, which
+// otherwise only sees the values GetValues retains (period values unless
+// SetRetentionLength says otherwise).
+func WithHistory(n int) MFIOption {
+	return func(m *MoneyFlowIndex) { m.historyLen = n }
+}
+
+// WithCrossoverInclusivity sets whether IsBullishCrossover and
+// IsBearishCrossover accept a previous MFI exactly on the threshold. By
+// default the bullish cross is strict and the bearish one inclusive (see
+// IsBullishCrossover); CrossoverInclusive or CrossoverStrict applies one rule
+// to both.
+func WithCrossoverInclusivity(mode core.CrossoverInclusivity) MFIOption {
+	return func(m *MoneyFlowIndex) { m.inclusivity = mode }
 }
 
 // NewMoneyFlowIndex creates a MFI instance with the default period (5) and
@@ -60,8 +125,9 @@ func NewMoneyFlowIndex() (*MoneyFlowIndex, error) {
 
 // NewMoneyFlowIndexWithParams creates a MFI instance with a custom period and
 // configuration.  The function validates the period, the over‑/under‑bought
-// relationship and runs IndicatorConfig.Validate().
-func NewMoneyFlowIndexWithParams(period int, cfg config.IndicatorConfig) (*MoneyFlowIndex, error) {
+// relationship and runs IndicatorConfig.Validate(). Functional options are
+// applied last.
+func NewMoneyFlowIndexWithParams(period int, cfg config.IndicatorConfig, opts ...MFIOption) (*MoneyFlowIndex, error) {
 	if period < 1 {
 		return nil, errors.New("period must be at least 1")
 	}
@@ -71,7 +137,7 @@ func NewMoneyFlowIndexWithParams(period int, cfg config.IndicatorConfig) (*Money
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
-	return &MoneyFlowIndex{
+	mfi := &MoneyFlowIndex{
 		period:    period,
 		highs:     make([]float64, 0, period+1),
 		lows:      make([]float64, 0, period+1),
@@ -79,12 +145,25 @@ func NewMoneyFlowIndexWithParams(period int, cfg config.IndicatorConfig) (*Money
 		volumes:   make([]float64, 0, period+1),
 		mfiValues: make([]float64, 0, period),
 		config:    cfg,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(mfi)
+	}
+	if mfi.historyLen < 0 {
+		return nil, errors.New("history length must be non-negative")
+	}
+	return mfi, nil
 }
 
 // Add appends a new OHLCV sample.  It validates the inputs and, when enough
 // data points have been collected, computes a new MFI value.
 func (mfi *MoneyFlowIndex) Add(high, low, close, volume float64) error {
+	if mfi.autoCorrect {
+		var corrected bool
+		if high, low, close, corrected = core.CorrectCandle(high, low, close); corrected {
+			mfi.corrections++
+		}
+	}
 	if high < low {
 		return fmt.Errorf("high (%f) must be >= low (%f)", high, low)
 	}
@@ -98,24 +177,84 @@ func (mfi *MoneyFlowIndex) Add(high, low, close, volume float64) error {
 	mfi.lows = append(mfi.lows, low)
 	mfi.closes = append(mfi.closes, close)
 	mfi.volumes = append(mfi.volumes, volume)
+	if mfi.volumeAutoScale && len(mfi.calibVolumes) < mfi.period {
+		mfi.calibrate(volume)
+	}
 
+	var outErr error
 	// Update rolling money‑flow sums once we have a previous close to compare to.
 	if len(mfi.closes) >= 2 {
 		flow := mfi.moneyFlow(len(mfi.closes) - 1)
 		mfi.pushFlow(flow)
 
-		if len(mfi.flows) >= mfi.period {
-			val := mfi.currentMFI()
+		if len(mfi.flows) >= mfi.emitAfter() {
+			val := core.ApplyTransform(mfi.transform, mfi.currentMFI())
 			mfi.mfiValues = append(mfi.mfiValues, val)
+			mfi.emitted++
+			mfi.extremes.Observe(val)
+			mfi.divergence.Observe(close, val)
 			mfi.lastValue = val
+			if mfi.historyLen > 0 {
+				mfi.history = core.KeepLast(append(mfi.history, val), mfi.historyLen)
+			}
+			if mfi.strictOutput {
+				outErr = core.CheckFinite("mfi", val)
+			}
 		}
 	}
 	mfi.trimSlices()
+	return outErr
+}
+
+// Validate scans the retained candles, money flows and MFI values for NaN or
+// ±Inf and reports the first one found with its index in the retained slice,
+// then checks the running flow sums and the latest value.
+func (mfi *MoneyFlowIndex) Validate() error {
+	for _, s := range []struct {
+		name   string
+		values []float64
+	}{
+		{"highs", mfi.highs},
+		{"lows", mfi.lows},
+		{"closes", mfi.closes},
+		{"volumes", mfi.volumes},
+		{"flows", mfi.flows},
+		{"mfiValues", mfi.mfiValues},
+	} {
+		if err := core.ValidateFinite(s.name, s.values); err != nil {
+			return err
+		}
+	}
+	for _, s := range []struct {
+		name  string
+		value float64
+	}{
+		{"positiveSum", mfi.positiveSum},
+		{"negativeSum", mfi.negativeSum},
+		{"lastValue", mfi.lastValue},
+	} {
+		if err := core.CheckFinite(s.name, s.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetRetentionLength sets how many MFI values are kept for GetValues and
+// GetPlotData independently of the period, so a chart can show a long
+// history while the money-flow window stays short. 0 restores the default of
+// one period; shrinking trims the retained values at once.
+func (mfi *MoneyFlowIndex) SetRetentionLength(n int) error {
+	if n < 0 {
+		return errors.New("retention length must be non-negative")
+	}
+	mfi.retention = n
+	mfi.trimSlices()
 	return nil
 }
 
 // trimSlices keeps only the most recent period+1 raw samples and the most recent
-// period computed MFI values.
+// period (or retention length) computed MFI values.
 func (mfi *MoneyFlowIndex) trimSlices() {
 	if len(mfi.closes) > mfi.period+1 {
 		mfi.highs = core.KeepLast(mfi.highs, mfi.period+1)
@@ -123,9 +262,11 @@ func (mfi *MoneyFlowIndex) trimSlices() {
 		mfi.closes = core.KeepLast(mfi.closes, mfi.period+1)
 		mfi.volumes = core.KeepLast(mfi.volumes, mfi.period+1)
 	}
-	if len(mfi.mfiValues) > mfi.period {
-		mfi.mfiValues = core.KeepLast(mfi.mfiValues, mfi.period)
+	keep := mfi.period
+	if mfi.retention > 0 {
+		keep = mfi.retention
 	}
+	mfi.mfiValues = core.KeepLast(mfi.mfiValues, keep)
 }
 
 // calculateMFI implements the standard Money Flow Index algorithm.
@@ -136,8 +277,8 @@ func (mfi *MoneyFlowIndex) trimSlices() {
 //   - if only positive money flow exists               → 100 (max)
 //   - if only negative money flow exists               → 0   (min)
 func (mfi *MoneyFlowIndex) calculateMFI() (float64, error) {
-	if len(mfi.flows) < mfi.period {
-		return 0, fmt.Errorf("insufficient data: need %d, have %d", mfi.period+1, len(mfi.closes))
+	if len(mfi.flows) < mfi.emitAfter() {
+		return 0, fmt.Errorf("insufficient data: need %d, have %d", mfi.emitAfter()+1, len(mfi.closes))
 	}
 	return mfi.currentMFI(), nil
 }
@@ -154,6 +295,19 @@ func (mfi *MoneyFlowIndex) Calculate() (float64, error) {
 	return mfi.lastValue, nil
 }
 
+// CalculateWithConfidence returns the latest MFI with a warm-up confidence
+// from WarmupConfidence, rising from 0 on the first value to 1 three periods
+// later. The MFI window sum is exact from its first value; the ramp reflects
+// how little flow history backs an early reading and matches RSI and ATR, so
+// confidences can be compared across indicators.
+func (mfi *MoneyFlowIndex) CalculateWithConfidence() (value, confidence float64, err error) {
+	value, err = mfi.Calculate()
+	if err != nil {
+		return 0, 0, err
+	}
+	return value, core.WarmupConfidence(mfi.emitted, mfi.period), nil
+}
+
 // GetLastValue returns the last computed MFI value without an error.
 func (mfi *MoneyFlowIndex) GetLastValue() float64 { return mfi.lastValue }
 
@@ -170,18 +324,19 @@ func (mfi *MoneyFlowIndex) IsBullishCrossover() (bool, error) {
 	cur := mfi.mfiValues[len(mfi.mfiValues)-1]
 
 	// If we have only one value, treat the “previous” value as 0.
-	// NOTE: we require a *strict* crossing (prev < oversold) so that a
-	// configuration with oversold == 0 does NOT fire on the very first MFI
-	// value (the suite sets oversold to 0 to make the down‑trend trigger a
-	// crossover later on). This change eliminates the spurious bullish
-	// weight after a Reset.
+	// NOTE: by default we require a *strict* crossing (prev < oversold) so
+	// that a configuration with oversold == 0 does NOT fire on the very first
+	// MFI value (the suite sets oversold to 0 to make the down‑trend trigger
+	// a crossover later on). This eliminates the spurious bullish weight
+	// after a Reset; WithCrossoverInclusivity(CrossoverInclusive) restores it.
 
 	prev := 0.0
 	if len(mfi.mfiValues) >= 2 {
 		prev = mfi.mfiValues[len(mfi.mfiValues)-2]
 	}
 
-	return prev < mfi.config.MFIOversold && cur > mfi.config.MFIOversold, nil
+	return core.CrossedAbove(prev, cur, mfi.config.MFIOversold, mfi.inclusivity.AllowsEqual(false)) &&
+		cur-mfi.config.MFIOversold >= mfi.crossDelta, nil
 }
 
 // IsBearishCrossover reports whether the latest MFI crossed below the
@@ -200,7 +355,20 @@ func (mfi *MoneyFlowIndex) IsBearishCrossover() (bool, error) {
 	if len(mfi.mfiValues) >= 2 {
 		prev = mfi.mfiValues[len(mfi.mfiValues)-2]
 	}
-	return prev >= mfi.config.MFIOverbought && cur < mfi.config.MFIOverbought, nil
+	return core.CrossedBelow(prev, cur, mfi.config.MFIOverbought, mfi.inclusivity.AllowsEqual(true)) &&
+		mfi.config.MFIOverbought-cur >= mfi.crossDelta, nil
+}
+
+// SetCrossoverThreshold requires the MFI to finish at least delta points past
+// the oversold or overbought level before IsBullishCrossover or
+// IsBearishCrossover reports a cross. 0 (the default) accepts any cross.
+// GetPlotData's crossover markers ignore the threshold.
+func (mfi *MoneyFlowIndex) SetCrossoverThreshold(delta float64) error {
+	if err := core.ValidateCrossoverThreshold(delta); err != nil {
+		return err
+	}
+	mfi.crossDelta = delta
+	return nil
 }
 
 // GetOverboughtOversold returns a textual description of the current zone.
@@ -219,6 +387,62 @@ func (mfi *MoneyFlowIndex) GetOverboughtOversold() (string, error) {
 	}
 }
 
+// ZoneTransition reports whether the latest MFI entered or left the
+// overbought or oversold zone, comparing the last two values against the
+// configured thresholds (see core.ClassifyZoneTransition). Unlike the
+// crossover helpers it does not invent a previous value for the first bar.
+func (mfi *MoneyFlowIndex) ZoneTransition() (core.ZoneEvent, error) {
+	if len(mfi.mfiValues) < 2 {
+		return core.ZoneNone, errors.New("insufficient data for zone transition")
+	}
+	n := len(mfi.mfiValues)
+	return core.ClassifyZoneTransition(mfi.mfiValues[n-2], mfi.mfiValues[n-1], mfi.config.MFIOverbought, mfi.config.MFIOversold), nil
+}
+
+// Peek returns the MFI that Add followed by Calculate would produce for the
+// given bar, without committing it. The rolling flow sums are updated on a
+// copy and discarded, so the indicator is left exactly as it was.
+func (mfi *MoneyFlowIndex) Peek(high, low, close, volume float64) (float64, error) {
+	c := mfi.clone()
+	if err := c.Add(high, low, close, volume); err != nil {
+		return 0, err
+	}
+	return c.Calculate()
+}
+
+// Compute feeds the bars through Add and returns the MFI emitted for each
+// one, skipping warm-up bars and bars Add rejects. Only the first
+// min(len(...)) bars of the four slices are used. The indicator stays warmed
+// with the whole series.
+func (mfi *MoneyFlowIndex) Compute(highs, lows, closes, volumes []float64) []float64 {
+	n := min(len(highs), len(lows), len(closes), len(volumes))
+	out := make([]float64, 0, n)
+	for i := 0; i < n; i++ {
+		if mfi.Add(highs[i], lows[i], closes[i], volumes[i]) != nil {
+			continue
+		}
+		if v, err := mfi.Calculate(); err == nil {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// clone returns a copy whose slices do not share backing arrays with mfi.
+func (mfi *MoneyFlowIndex) clone() *MoneyFlowIndex {
+	c := *mfi
+	c.highs = core.CopySlice(mfi.highs)
+	c.lows = core.CopySlice(mfi.lows)
+	c.closes = core.CopySlice(mfi.closes)
+	c.volumes = core.CopySlice(mfi.volumes)
+	c.mfiValues = core.CopySlice(mfi.mfiValues)
+	c.flows = core.CopySlice(mfi.flows)
+	c.calibVolumes = core.CopySlice(mfi.calibVolumes)
+	c.history = core.CopySlice(mfi.history)
+	c.divergence = mfi.divergence.Clone()
+	return &c
+}
+
 // Reset clears all stored data and puts the indicator back in its pristine state.
 func (mfi *MoneyFlowIndex) Reset() {
 	// Empty the raw OHLCV buffers.
@@ -230,9 +454,107 @@ func (mfi *MoneyFlowIndex) Reset() {
 	// Empty the computed MFI buffer and clear the cached last value.
 	mfi.mfiValues = mfi.mfiValues[:0]
 	mfi.lastValue = 0
+	mfi.emitted = 0
 	mfi.flows = mfi.flows[:0]
 	mfi.positiveSum = 0
 	mfi.negativeSum = 0
+	mfi.corrections = 0
+	mfi.extremes.Reset()
+	mfi.divergence.Reset()
+	mfi.calibVolumes = mfi.calibVolumes[:0]
+	mfi.autoScale = 0
+	mfi.history = mfi.history[:0]
+}
+
+// ValueAtPercentile returns the p-th percentile (p in [0, 1]) of the retained
+// MFI values – the last WithHistory values when configured, else the values
+// kept under SetRetentionLength – interpolating linearly between ranks.
+// ValueAtPercentile(0.95) is a data-driven overbought level.
+func (mfi *MoneyFlowIndex) ValueAtPercentile(p float64) (float64, error) {
+	if !(p >= 0 && p <= 1) {
+		return 0, fmt.Errorf("percentile must be within [0, 1], got %v", p)
+	}
+	values := mfi.mfiValues
+	if mfi.historyLen > 0 {
+		values = mfi.history
+	}
+	return core.Percentile(values, p*100)
+}
+
+// VolumeScale returns the divisor currently applied to volumes: the
+// calibrated median with WithVolumeAutoScale, else MFIVolumeScale.
+func (mfi *MoneyFlowIndex) VolumeScale() float64 { return mfi.volumeScale() }
+
+func (mfi *MoneyFlowIndex) volumeScale() float64 {
+	if mfi.autoScale > 0 {
+		return mfi.autoScale
+	}
+	return mfi.config.MFIVolumeScale
+}
+
+// calibrate folds a calibration-window volume into the median scale and
+// rescales the accumulated flows so they stay consistent with it.
+func (mfi *MoneyFlowIndex) calibrate(volume float64) {
+	mfi.calibVolumes = append(mfi.calibVolumes, volume)
+	median, err := core.Percentile(mfi.calibVolumes, 50)
+	if err != nil || median <= 0 {
+		return
+	}
+	old := mfi.volumeScale()
+	if median == old {
+		return
+	}
+	factor := old / median
+	for i := range mfi.flows {
+		mfi.flows[i] *= factor
+	}
+	mfi.positiveSum *= factor
+	mfi.negativeSum *= factor
+	mfi.autoScale = median
+}
+
+// CorrectionCount returns how many samples WithAutoCorrect has repaired.
+func (mfi *MoneyFlowIndex) CorrectionCount() int { return mfi.corrections }
+
+// ObservedMin returns the lowest MFI value produced since construction or
+// the last Reset, or 0 before the first value.
+func (mfi *MoneyFlowIndex) ObservedMin() float64 { return mfi.extremes.Min() }
+
+// ObservedMax returns the highest MFI value produced since construction or
+// the last Reset, or 0 before the first value.
+func (mfi *MoneyFlowIndex) ObservedMax() float64 { return mfi.extremes.Max() }
+
+// SetOutputTransform passes every MFI value through fn before it is stored,
+// which Calculate, the crossover/zone checks and GetPlotData then report.
+// nil restores the identity. The MFI is reset so earlier, untransformed
+// values do not linger in the series.
+func (mfi *MoneyFlowIndex) SetOutputTransform(fn func(float64) float64) {
+	mfi.transform = fn
+	mfi.Reset()
+}
+
+// SetMinPeriods lets the MFI emit values once n money flows (n+1 candles)
+// exist rather than a full period. Early values use the ratio of the flows
+// seen so far and are approximate until IsWarm reports true. n must lie in
+// [1, period].
+func (mfi *MoneyFlowIndex) SetMinPeriods(n int) error {
+	if n < 1 || n > mfi.period {
+		return fmt.Errorf("min periods must be within [1, %d], got %d", mfi.period, n)
+	}
+	mfi.minPeriods = n
+	return nil
+}
+
+// IsWarm reports whether the money-flow window holds a full period.
+func (mfi *MoneyFlowIndex) IsWarm() bool { return len(mfi.flows) >= mfi.period }
+
+// emitAfter returns the number of money flows needed before a value is
+// emitted.
+func (mfi *MoneyFlowIndex) emitAfter() int {
+	if mfi.minPeriods > 0 && mfi.minPeriods < mfi.period {
+		return mfi.minPeriods
+	}
+	return mfi.period
 }
 
 // IsDivergence detects classic bullish or bearish divergence between price
@@ -308,6 +630,23 @@ func (mfi *MoneyFlowIndex) IsDivergence() (string, error) {
 	return "none", nil
 }
 
+// IsDivergenceWithStrength compares the last two price pivots (two bars wide)
+// among the last 64 MFI bars with the MFI on the same bars (see
+// core.PivotDivergenceStrength). It returns "bullish" or "bearish" with the
+// per-bar gap between the range-normalised price and MFI slopes joining the
+// pivots, or "none"
+// and 0 when the pivots do not diverge.
+func (mfi *MoneyFlowIndex) IsDivergenceWithStrength() (string, float64, error) {
+	dir, strength, ok := mfi.divergence.Strength()
+	if !ok {
+		return "none", 0, ErrInsufficientDataCalc
+	}
+	if dir == "" {
+		return "none", 0, nil
+	}
+	return dir, strength, nil
+}
+
 // GetPlotData produces two PlotData series:
 //
 //  1. The MFI line (type “line”).
@@ -363,14 +702,38 @@ func (mfi *MoneyFlowIndex) GetPlotData() ([]core.PlotData, error) {
 	return []core.PlotData{mainSeries, signalSeries}, nil
 }
 
+// GetPlotDataRange returns the MFI plot data for bars [from, to).
+func (mfi *MoneyFlowIndex) GetPlotDataRange(from, to int) ([]core.PlotData, error) {
+	data, err := mfi.GetPlotData()
+	if err != nil {
+		return nil, err
+	}
+	return core.SlicePlotData(data, from, to), nil
+}
+
+// GetPlotDataV2 returns the MFI line with its signals as typed markers
+// instead of the ±1/±2 scatter produced by GetPlotData.
+func (mfi *MoneyFlowIndex) GetPlotDataV2() (core.PlotDataWithMarkers, error) {
+	data, err := mfi.GetPlotData()
+	if err != nil {
+		return core.PlotDataWithMarkers{}, err
+	}
+	return core.ExtractMarkers(data, core.ZoneMarkerKinds), nil
+}
+
 // GetValues returns a copy of the raw MFI values slice.
 func (mfi *MoneyFlowIndex) GetValues() []float64 { return core.CopySlice(mfi.mfiValues) }
 
+// OutputSlope returns the change in MFI over the last n bars.
+func (mfi *MoneyFlowIndex) OutputSlope(n int) (float64, error) {
+	return core.OutputSlope(mfi.mfiValues, n)
+}
+
 // moneyFlow returns the signed money flow for the candle at idx (idx refers to
 // the position inside the internal slices).
 func (mfi *MoneyFlowIndex) moneyFlow(idx int) float64 {
 	typicalPrice := (mfi.highs[idx] + mfi.lows[idx] + mfi.closes[idx]) / 3
-	scaledVolume := mfi.volumes[idx] / mfi.config.MFIVolumeScale
+	scaledVolume := mfi.volumes[idx] / mfi.volumeScale()
 	rawMoneyFlow := typicalPrice * scaledVolume
 
 	prevClose := mfi.closes[idx-1]
@@ -424,3 +787,20 @@ func (mfi *MoneyFlowIndex) currentMFI() float64 {
 	mmfi := 100 - (100 / (1 + moneyRatio))
 	return core.Clamp(mmfi, 0, 100)
 }
+
+// Describe reports the MFI's live parameters.
+func (mfi *MoneyFlowIndex) Describe() core.IndicatorInfo {
+	return core.IndicatorInfo{
+		Name: "MFI",
+		Params: map[string]any{
+			"period":      mfi.period,
+			"overbought":  mfi.config.MFIOverbought,
+			"oversold":    mfi.config.MFIOversold,
+			"volumeScale": mfi.volumeScale(),
+			"autoCorrect": mfi.autoCorrect,
+			"autoScale":   mfi.volumeAutoScale,
+			"minPeriods":  mfi.emitAfter(),
+		},
+		SamplesNeeded: mfi.emitAfter() + 1,
+	}
+}
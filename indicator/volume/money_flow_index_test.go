@@ -3,9 +3,11 @@ package volume
 import (
 	"encoding/json"
 	"errors"
+	"math"
 	"testing"
 
 	"github.com/evdnx/goti/config"
+	"github.com/evdnx/goti/indicator/core"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -380,6 +382,27 @@ func TestMoneyFlowIndex_GetPlotData(t *testing.T) {
 	assert.Len(t, sig.Y, len(mfi.GetValues()))
 }
 
+func TestMoneyFlowIndex_GetPlotDataRange(t *testing.T) {
+	mfi := newTestMFI(t)
+	_, err := mfi.GetPlotDataRange(0, 1)
+	require.Error(t, err)
+
+	for i := 0; i < 8; i++ {
+		p := 10 + float64(i%3)
+		require.NoError(t, mfi.Add(p+1, p-1, p, 1000))
+	}
+	full, err := mfi.GetPlotData()
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(full[0].X), 3)
+
+	ranged, err := mfi.GetPlotDataRange(1, 3)
+	require.NoError(t, err)
+	require.Len(t, ranged, 2)
+	assert.Equal(t, []float64{1, 2}, ranged[0].X)
+	assert.Equal(t, full[0].Y[1:3], ranged[0].Y)
+	assert.Equal(t, full[1].Y[1:3], ranged[1].Y)
+}
+
 // ---------------------------------------------------------------------------
 // JSON marshalling sanity – ensures PlotData structs are serialisable
 // ---------------------------------------------------------------------------
@@ -405,3 +428,404 @@ func TestMoneyFlowIndex_Calculate_NoData(t *testing.T) {
 	_, err := mfi.Calculate()
 	assert.True(t, errors.Is(err, errors.New("no MFI data")))
 }
+
+// ---------------------------------------------------------------------------
+// Auto-correction keeps ingestion alive on dirty bars
+// ---------------------------------------------------------------------------
+func TestMoneyFlowIndex_WithAutoCorrect(t *testing.T) {
+	strict := newTestMFI(t)
+	require.Error(t, strict.Add(9, 10, 9.5, 100), "strict mode must reject high < low")
+
+	cfg := config.DefaultConfig()
+	cfg.MFIVolumeScale = 1.0
+	mfi, err := NewMoneyFlowIndexWithParams(3, cfg, WithAutoCorrect(true))
+	require.NoError(t, err)
+
+	require.NoError(t, mfi.Add(9, 10, 9.5, 100))   // swapped high/low
+	require.NoError(t, mfi.Add(11, 10, 12, 100))   // close above high
+	require.NoError(t, mfi.Add(12, 11, 11.5, 100)) // clean
+	require.NoError(t, mfi.Add(13, 12, 12.5, 100)) // clean
+	assert.Equal(t, 2, mfi.CorrectionCount())
+
+	_, err = mfi.Calculate()
+	require.NoError(t, err)
+}
+
+func TestMoneyFlowIndex_DivergenceWithStrength(t *testing.T) {
+	// Closes as offsets from 100 with relative volume: a rally, a fast drop
+	// to a pivot low, a bounce and a slide to a lower low whose up-ticks carry
+	// triple volume, so the MFI's second low is far higher – bullish.
+	shape := [][2]float64{
+		{0.5, 1}, {1, 1}, {1.5, 1}, {2, 1}, {2.5, 1}, {3, 1}, {3.5, 1}, {4, 1},
+		{2, 1}, {0, 1}, {-2, 1}, {-4, 1}, {-6, 1},
+		{-4.5, 1}, {-3, 1}, {-1.5, 1}, {0, 1},
+		{-2, 1}, {-1, 3}, {-3.5, 1}, {-2.5, 3}, {-7, 1},
+		{-6, 1}, {-5, 1}, {-4, 1},
+	}
+	// Stretching the moves barely changes the MFI but steepens the price
+	// slope between the pivots.
+	run := func(scale float64) ([]float64, string, float64) {
+		mfi, err := NewMoneyFlowIndexWithParams(5, config.DefaultConfig())
+		require.NoError(t, err)
+		require.NoError(t, mfi.SetRetentionLength(len(shape)))
+		for _, s := range shape {
+			c := 100 + scale*s[0]
+			require.NoError(t, mfi.Add(c+0.5, c-0.5, c, 1000*s[1]))
+		}
+		dir, strength, err := mfi.IsDivergenceWithStrength()
+		require.NoError(t, err)
+		return mfi.GetValues(), dir, strength
+	}
+	shallowMFI, shallowDir, shallow := run(1)
+	steepMFI, steepDir, steep := run(3)
+	assert.Equal(t, "bullish", shallowDir)
+	assert.Equal(t, "bullish", steepDir)
+	assert.InDeltaSlice(t, shallowMFI, steepMFI, 2)
+	assert.Greater(t, shallow, 0.0)
+	assert.Greater(t, steep, shallow, "a steeper price leg against the same MFI must score higher")
+
+	// No divergence → zero strength.
+	mfi := newTestMFI(t)
+	_, _, err := mfi.IsDivergenceWithStrength()
+	require.ErrorIs(t, err, ErrInsufficientDataCalc)
+	for i := 0; i < 30; i++ {
+		c := 10 + float64(i) + 2*float64(i%3)
+		require.NoError(t, mfi.Add(c+0.5, c-0.5, c, 1000))
+	}
+	dir, strength, err := mfi.IsDivergenceWithStrength()
+	require.NoError(t, err)
+	assert.Equal(t, "none", dir)
+	assert.Zero(t, strength)
+}
+
+func TestMoneyFlowIndex_OutputSlope(t *testing.T) {
+	mfi := newTestMFI(t)
+	_, err := mfi.OutputSlope(1)
+	require.Error(t, err)
+
+	mfi.mfiValues = []float64{30, 33, 36, 39}
+	got, err := mfi.OutputSlope(1)
+	require.NoError(t, err)
+	assert.InDelta(t, 3.0, got, 1e-9)
+	got, err = mfi.OutputSlope(3)
+	require.NoError(t, err)
+	assert.InDelta(t, 9.0, got, 1e-9)
+}
+
+func TestMoneyFlowIndex_SetMinPeriods(t *testing.T) {
+	mfi := newTestMFI(t)
+	require.Error(t, mfi.SetMinPeriods(mfi.period+1))
+	require.NoError(t, mfi.SetMinPeriods(2))
+	assert.Equal(t, 3, mfi.Describe().SamplesNeeded)
+
+	require.NoError(t, mfi.Add(10, 8, 9, 1000))
+	require.NoError(t, mfi.Add(11, 9, 10, 1000))
+	_, err := mfi.Calculate()
+	require.ErrorIs(t, err, ErrNoMFIData)
+
+	require.NoError(t, mfi.Add(12, 10, 11, 1000))
+	v, err := mfi.Calculate()
+	require.NoError(t, err)
+	assert.Equal(t, 100.0, v)
+	assert.False(t, mfi.IsWarm())
+}
+
+func TestMoneyFlowIndex_ObservedExtremes(t *testing.T) {
+	mfi := newTestMFI(t)
+	price := 100.0
+	for i := 0; i < 12; i++ {
+		price++
+		require.NoError(t, mfi.Add(price+1, price-1, price, 1000))
+	}
+	for i := 0; i < 12; i++ {
+		price--
+		require.NoError(t, mfi.Add(price+1, price-1, price, 1000))
+	}
+	assert.Equal(t, 100.0, mfi.ObservedMax())
+	assert.Equal(t, 0.0, mfi.ObservedMin())
+}
+
+func TestMoneyFlowIndex_VolumeAutoScale(t *testing.T) {
+	auto, err := NewMoneyFlowIndexWithParams(5, config.DefaultConfig(), WithVolumeAutoScale(true))
+	require.NoError(t, err)
+	cfg := config.DefaultConfig()
+	cfg.MFIVolumeScale = 1.0
+	ref, err := NewMoneyFlowIndexWithParams(5, cfg)
+	require.NoError(t, err)
+
+	// Volumes near the float64 limit would overflow the fixed 300 000 scale
+	// once multiplied by these prices.
+	for i := 0; i < 30; i++ {
+		price := 1e8 + 1e6*math.Sin(float64(i)/3)
+		vol := (1 + 0.5*math.Cos(float64(i))) * 1e306
+		require.NoError(t, auto.Add(price+1e5, price-1e5, price, vol))
+		require.NoError(t, ref.Add(price+1e5, price-1e5, price, vol/1e300))
+
+		got, gotErr := auto.Calculate()
+		want, wantErr := ref.Calculate()
+		require.Equal(t, wantErr == nil, gotErr == nil, "bar %d readiness", i)
+		if gotErr != nil {
+			continue
+		}
+		require.False(t, math.IsNaN(got) || math.IsInf(got, 0), "bar %d: MFI %v", i, got)
+		assert.InDelta(t, want, got, 1e-6, "bar %d", i)
+	}
+	assert.Greater(t, auto.VolumeScale(), 1e305)
+}
+
+func TestMoneyFlowIndex_Peek(t *testing.T) {
+	mfi := newTestMFI(t)
+	bars := [][4]float64{
+		{11, 9, 10, 100}, {12, 10, 11, 150}, {12, 10, 10.5, 120},
+		{13, 11, 12, 200}, {12.5, 10.5, 11, 90},
+	}
+	for _, b := range bars {
+		require.NoError(t, mfi.Add(b[0], b[1], b[2], b[3]))
+	}
+	before := mfi.GetValues()
+	last, err := mfi.Calculate()
+	require.NoError(t, err)
+
+	peeked, err := mfi.Peek(14, 12, 13.5, 300)
+	require.NoError(t, err)
+	got, _ := mfi.Calculate()
+	assert.Equal(t, last, got, "Peek must not change the latest value")
+	assert.Equal(t, before, mfi.GetValues(), "Peek must not change the series")
+
+	require.NoError(t, mfi.Add(14, 12, 13.5, 300))
+	got, _ = mfi.Calculate()
+	assert.Equal(t, peeked, got)
+}
+
+func TestMoneyFlowIndex_ComputeMatchesLoop(t *testing.T) {
+	highs := []float64{11, 12, 12, 13, 12.5, 13.5, 14, 13}
+	lows := []float64{9, 10, 10, 11, 10.5, 11.5, 12, 11}
+	closes := []float64{10, 11, 10.5, 12, 11, 13, 13.5, 11.5}
+	volumes := []float64{100, 150, 120, 200, 90, 180, 160, 140}
+
+	got := newTestMFI(t).Compute(highs, lows, closes, volumes)
+
+	ref := newTestMFI(t)
+	var want []float64
+	for i := range closes {
+		require.NoError(t, ref.Add(highs[i], lows[i], closes[i], volumes[i]))
+		if v, err := ref.Calculate(); err == nil {
+			want = append(want, v)
+		}
+	}
+	require.NotEmpty(t, want)
+	assert.Equal(t, want, got)
+}
+
+func TestMFI_ValueAtPercentile(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.MFIVolumeScale = 1.0
+	mfi, err := NewMoneyFlowIndexWithParams(3, cfg, WithHistory(12))
+	require.NoError(t, err)
+	_, err = mfi.ValueAtPercentile(0.5)
+	require.Error(t, err)
+
+	// Closes step 10→13→10 with volume 1200/close, so every bar moves the
+	// same 1200 of money flow and the MFI(3) is 100/3 times the up moves in
+	// its window: 100, 66.7, 33.3, 0, 33.3, 66.7 every six bars.
+	for i := 0; i < 52; i++ {
+		c := 10 + float64(i%6)
+		if i%6 > 3 {
+			c = 10 + float64(6-i%6)
+		}
+		require.NoError(t, mfi.Add(c+1, c-1, c, 1200/c))
+	}
+	// The last 12 values are two cycles: 0, 0, 33.3 ×4, 66.7 ×4, 100, 100.
+	got, err := mfi.ValueAtPercentile(0.9)
+	require.NoError(t, err)
+	require.InDelta(t, 290.0/3, got, 1e-9)
+	got, err = mfi.ValueAtPercentile(0.5)
+	require.NoError(t, err)
+	require.InDelta(t, 50, got, 1e-9)
+	_, err = mfi.ValueAtPercentile(-0.1)
+	require.Error(t, err)
+}
+
+func TestMFI_CalculateWithConfidence(t *testing.T) {
+	mfi := newTestMFI(t)
+	var confs []float64
+	for i := 0; i < 16; i++ {
+		c := 10 + float64(i%4)
+		require.NoError(t, mfi.Add(c+1, c-1, c, 100))
+		if _, conf, err := mfi.CalculateWithConfidence(); err == nil {
+			confs = append(confs, conf)
+		}
+	}
+	// Period 3: values from bar 4 on, fully warm nine values later.
+	require.Len(t, confs, 13)
+	require.Equal(t, 0.0, confs[0])
+	for i := 1; i < len(confs); i++ {
+		require.GreaterOrEqual(t, confs[i], confs[i-1])
+	}
+	require.Equal(t, 1.0, confs[9])
+	require.Equal(t, 1.0, confs[len(confs)-1])
+}
+
+func TestMFI_CrossoverInclusivityAfterReset(t *testing.T) {
+	// With oversold at 0 the "previous" value assumed for the first MFI after
+	// a Reset sits exactly on the level; only the inclusive rule fires on it.
+	cfg := config.DefaultConfig()
+	cfg.MFIVolumeScale = 1.0
+	cfg.MFIOversold = 0
+	cases := map[core.CrossoverInclusivity]bool{
+		core.CrossoverDefault:   false,
+		core.CrossoverStrict:    false,
+		core.CrossoverInclusive: true,
+	}
+	for mode, want := range cases {
+		mfi, err := NewMoneyFlowIndexWithParams(3, cfg, WithCrossoverInclusivity(mode))
+		require.NoError(t, err)
+		for i := 0; i < 6; i++ {
+			require.NoError(t, mfi.Add(12, 10, 11+float64(i%2), 100))
+		}
+		mfi.Reset()
+		for i := 0; i < 4; i++ {
+			c := 10 + float64(i)
+			require.NoError(t, mfi.Add(c+1, c-1, c, 100))
+		}
+		v, err := mfi.Calculate()
+		require.NoError(t, err)
+		require.Greater(t, v, 0.0)
+		got, err := mfi.IsBullishCrossover()
+		require.NoError(t, err)
+		require.Equal(t, want, got, "mode %d", mode)
+	}
+}
+
+func TestMFI_ZoneTransition(t *testing.T) {
+	mfi := newTestMFI(t)
+	_, err := mfi.ZoneTransition()
+	require.Error(t, err)
+
+	// Rally into overbought, ease out, sell off into oversold, recover. The
+	// MFI(3) reads 100, 100, 66.8, 33.7, 0, 0, 0, 0, 33.6, 67.4, 100 from
+	// bar 3 on, against the 80/20 levels.
+	closes := []float64{100, 101, 102, 103, 104, 103, 102, 101, 99, 97, 95, 97, 99, 101}
+	want := []string{
+		4: "none", 5: "exited_overbought", 6: "none", 7: "entered_oversold",
+		8: "none", 9: "none", 10: "none", 11: "exited_oversold", 12: "none",
+		13: "entered_overbought",
+	}
+	for i, c := range closes {
+		require.NoError(t, mfi.Add(c+1, c-1, c, 1000))
+		ev, err := mfi.ZoneTransition()
+		if i < 4 {
+			require.Error(t, err, "bar %d", i)
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, want[i], ev.String(), "bar %d", i)
+	}
+}
+
+func TestMFI_SetRetentionLength(t *testing.T) {
+	mfi := newTestMFI(t)
+	require.Error(t, mfi.SetRetentionLength(-1))
+	require.NoError(t, mfi.SetRetentionLength(100))
+	for i := 0; i < 200; i++ {
+		c := 100 + 5*math.Sin(float64(i)/4)
+		require.NoError(t, mfi.Add(c+1, c-1, c, 1000))
+	}
+	require.Len(t, mfi.GetValues(), 100)
+	require.Len(t, mfi.closes, 4, "calculation buffer should stay at period+1")
+}
+
+func TestMFI_SetCrossoverThreshold(t *testing.T) {
+	mfi := newTestMFI(t)
+	require.Error(t, mfi.SetCrossoverThreshold(-0.5))
+	require.NoError(t, mfi.SetCrossoverThreshold(3))
+
+	plain := newTestMFI(t)
+	// A light-volume bounce lifts the MFI to 21.0, just over 20, before a
+	// heavy drop and a clear recovery to 44.0; later a light-volume dip to
+	// 79.1, just under 80, precedes a heavy rally and a clear break to 57.3.
+	// The first value is compared with an assumed previous of 80, so the
+	// opening 0 counts as a bearish cross under either setting.
+	bars := []struct{ close, volume float64 }{
+		{100, 1000}, {99, 1000}, {98, 1000}, {97, 1000}, {98, 530}, {96, 2000}, {99, 1000},
+		{100, 1000}, {101, 1000}, {102, 1000}, {101, 530}, {103, 2000}, {100, 1000},
+	}
+	var plainBull, plainBear, bull, bear []int
+	for i, b := range bars {
+		require.NoError(t, plain.Add(b.close+1, b.close-1, b.close, b.volume))
+		require.NoError(t, mfi.Add(b.close+1, b.close-1, b.close, b.volume))
+		if i < 3 {
+			continue
+		}
+		if ok, err := plain.IsBullishCrossover(); err == nil && ok {
+			plainBull = append(plainBull, i)
+		}
+		if ok, err := plain.IsBearishCrossover(); err == nil && ok {
+			plainBear = append(plainBear, i)
+		}
+		if ok, err := mfi.IsBullishCrossover(); err == nil && ok {
+			bull = append(bull, i)
+		}
+		if ok, err := mfi.IsBearishCrossover(); err == nil && ok {
+			bear = append(bear, i)
+		}
+	}
+	assert.Equal(t, []int{4, 6}, plainBull)
+	assert.Equal(t, []int{3, 10, 12}, plainBear)
+	assert.Equal(t, []int{6}, bull)
+	assert.Equal(t, []int{3, 12}, bear)
+}
+
+func TestMoneyFlowIndex_SetOutputTransform(t *testing.T) {
+	plain := newTestMFI(t)
+	mirror := newTestMFI(t)
+	// Reflecting the MFI about 50 swaps the 80/20 zones and crossovers.
+	mirror.SetOutputTransform(func(v float64) float64 { return 100 - v })
+
+	flipped := map[string]string{"Overbought": "Oversold", "Oversold": "Overbought", "Neutral": "Neutral"}
+	zones := map[string]int{}
+	crosses := 0
+	for i := 0; i < 80; i++ {
+		p := 100 + 10*math.Sin(float64(i)/4)
+		for _, m := range []*MoneyFlowIndex{plain, mirror} {
+			require.NoError(t, m.Add(p+1, p-1, p, 1000))
+		}
+		want, err := plain.Calculate()
+		if err != nil {
+			continue
+		}
+		got, err := mirror.Calculate()
+		require.NoError(t, err)
+		assert.InDelta(t, 100-want, got, 1e-9, "bar %d", i)
+
+		zone, _ := plain.GetOverboughtOversold()
+		mirrorZone, _ := mirror.GetOverboughtOversold()
+		assert.Equal(t, flipped[zone], mirrorZone, "bar %d", i)
+		zones[zone]++
+
+		if len(plain.GetValues()) < 2 {
+			continue
+		}
+		bull, _ := plain.IsBullishCrossover()
+		bear, _ := plain.IsBearishCrossover()
+		mirrorBull, _ := mirror.IsBullishCrossover()
+		mirrorBear, _ := mirror.IsBearishCrossover()
+		assert.Equal(t, bull, mirrorBear, "bar %d", i)
+		assert.Equal(t, bear, mirrorBull, "bar %d", i)
+		if bull || bear {
+			crosses++
+		}
+	}
+	require.Positive(t, zones["Overbought"])
+	require.Positive(t, zones["Oversold"])
+	require.Positive(t, crosses)
+
+	want, err := plain.GetPlotData()
+	require.NoError(t, err)
+	got, err := mirror.GetPlotData()
+	require.NoError(t, err)
+	require.Len(t, got[0].Y, len(want[0].Y))
+	for i := range want[0].Y {
+		assert.InDelta(t, 100-want[0].Y[i], got[0].Y[i], 1e-9)
+	}
+}
@@ -0,0 +1,210 @@
+package volume
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/evdnx/goti/indicator/core"
+)
+
+const (
+	DefaultVolumeRSIPeriod     = 14
+	DefaultVolumeRSIOverbought = 70.0
+	DefaultVolumeRSIOversold   = 30.0
+)
+
+// volumeRSIMaxValues bounds the retained VolumeRSI history.
+const volumeRSIMaxValues = 256
+
+// VolumeRSI applies the RSI formula to volume instead of price: bar-to-bar
+// volume increases count as gains and decreases as losses, smoothed with
+// Wilder's recursion after a simple-average seed. Readings near 100 mean
+// volume has been expanding bar after bar, which at the end of a move often
+// marks a climax; near 0, that participation is drying up.
+type VolumeRSI struct {
+	period     int
+	overbought float64
+	oversold   float64
+
+	volumes []float64 // last period+1 volumes
+	avgGain float64
+	avgLoss float64
+	warm    bool
+
+	values    []float64
+	lastValue float64
+}
+
+// NewVolumeRSI builds a VolumeRSI with the default 14-bar period and 70/30
+// zones.
+func NewVolumeRSI() (*VolumeRSI, error) {
+	return NewVolumeRSIWithParams(DefaultVolumeRSIPeriod)
+}
+
+// NewVolumeRSIWithParams builds a VolumeRSI with a custom period.
+func NewVolumeRSIWithParams(period int) (*VolumeRSI, error) {
+	if period < 1 {
+		return nil, errors.New("period must be at least 1")
+	}
+	return &VolumeRSI{
+		period:     period,
+		overbought: DefaultVolumeRSIOverbought,
+		oversold:   DefaultVolumeRSIOversold,
+		volumes:    make([]float64, 0, period+1),
+	}, nil
+}
+
+// Add ingests a bar's volume, which may be zero but not negative. The first
+// value appears once period+1 volumes exist.
+func (v *VolumeRSI) Add(volume float64) error {
+	if !core.IsValidVolume(volume) {
+		return fmt.Errorf("invalid volume: %v", volume)
+	}
+	v.volumes = core.KeepLast(append(v.volumes, volume), v.period+1)
+	n := len(v.volumes)
+	if n < v.period+1 {
+		return nil
+	}
+
+	if !v.warm {
+		var gainSum, lossSum float64
+		for i := 1; i < n; i++ {
+			gain, loss := core.GainLoss(v.volumes[i] - v.volumes[i-1])
+			gainSum += gain
+			lossSum += loss
+		}
+		v.avgGain = gainSum / float64(v.period)
+		v.avgLoss = lossSum / float64(v.period)
+		v.warm = true
+	} else {
+		gain, loss := core.GainLoss(v.volumes[n-1] - v.volumes[n-2])
+		p := float64(v.period)
+		v.avgGain = (v.avgGain*(p-1) + gain) / p
+		v.avgLoss = (v.avgLoss*(p-1) + loss) / p
+	}
+
+	v.lastValue = core.RSIFromAverages(v.avgGain, v.avgLoss)
+	v.values = core.KeepLast(append(v.values, v.lastValue), volumeRSIMaxValues)
+	return nil
+}
+
+// Calculate returns the latest VolumeRSI value.
+func (v *VolumeRSI) Calculate() (float64, error) {
+	if len(v.values) == 0 {
+		return 0, errors.New("no VolumeRSI data")
+	}
+	return v.lastValue, nil
+}
+
+// SetThresholds changes the overbought and oversold levels.
+func (v *VolumeRSI) SetThresholds(overbought, oversold float64) error {
+	if oversold < 0 || overbought > 100 || oversold >= overbought {
+		return fmt.Errorf("invalid thresholds: need 0 <= oversold (%v) < overbought (%v) <= 100", oversold, overbought)
+	}
+	v.overbought = overbought
+	v.oversold = oversold
+	return nil
+}
+
+// GetOverboughtOversold reports whether the latest value is above the
+// overbought level (volume expanding, a possible climax), below the oversold
+// level (volume drying up) or in between.
+func (v *VolumeRSI) GetOverboughtOversold() (string, error) {
+	if len(v.values) == 0 {
+		return "", errors.New("no VolumeRSI data")
+	}
+	switch {
+	case v.lastValue > v.overbought:
+		return "Overbought", nil
+	case v.lastValue < v.oversold:
+		return "Oversold", nil
+	default:
+		return "Neutral", nil
+	}
+}
+
+// IsBullishCrossover reports whether the VolumeRSI crossed above the oversold
+// level on the latest bar, i.e. volume is returning after drying up.
+func (v *VolumeRSI) IsBullishCrossover() (bool, error) {
+	prev, cur, err := v.lastTwo()
+	if err != nil {
+		return false, err
+	}
+	return core.CrossedAbove(prev, cur, v.oversold, true), nil
+}
+
+// IsBearishCrossover reports whether the VolumeRSI crossed below the
+// overbought level on the latest bar, i.e. a volume surge is exhausting.
+func (v *VolumeRSI) IsBearishCrossover() (bool, error) {
+	prev, cur, err := v.lastTwo()
+	if err != nil {
+		return false, err
+	}
+	return core.CrossedBelow(prev, cur, v.overbought, true), nil
+}
+
+func (v *VolumeRSI) lastTwo() (prev, cur float64, err error) {
+	n := len(v.values)
+	if n < 2 {
+		return 0, 0, errors.New("insufficient data for crossover")
+	}
+	return v.values[n-2], v.values[n-1], nil
+}
+
+// Reset clears all state while preserving the period and thresholds.
+func (v *VolumeRSI) Reset() {
+	v.volumes = v.volumes[:0]
+	v.avgGain, v.avgLoss = 0, 0
+	v.warm = false
+	v.values = v.values[:0]
+	v.lastValue = 0
+}
+
+// GetValues returns a defensive copy of the VolumeRSI series.
+func (v *VolumeRSI) GetValues() []float64 { return core.CopySlice(v.values) }
+
+// GetPlotData returns the VolumeRSI line and a scatter of its zone
+// crossovers (1 bullish, -1 bearish).
+func (v *VolumeRSI) GetPlotData(startTime, interval int64) []core.PlotData {
+	if len(v.values) == 0 {
+		return nil
+	}
+	x := make([]float64, len(v.values))
+	signals := make([]float64, len(v.values))
+	for i := range x {
+		x[i] = float64(i)
+		if i == 0 {
+			continue
+		}
+		prev, cur := v.values[i-1], v.values[i]
+		switch {
+		case core.CrossedAbove(prev, cur, v.oversold, true):
+			signals[i] = 1
+		case core.CrossedBelow(prev, cur, v.overbought, true):
+			signals[i] = -1
+		}
+	}
+	ts := core.GenerateTimestamps(startTime, len(v.values), interval)
+	return []core.PlotData{
+		{
+			Name:      "Volume RSI",
+			X:         x,
+			Y:         core.CopySlice(v.values),
+			Type:      "line",
+			Timestamp: ts,
+		},
+		{
+			Name:      "Volume RSI Signals",
+			X:         x,
+			Y:         signals,
+			Type:      "scatter",
+			Signal:    "crossover",
+			Timestamp: ts,
+		},
+	}
+}
+
+// GetPlotDataRange returns the volume RSI plot data for bars [from, to).
+func (v *VolumeRSI) GetPlotDataRange(startTime, interval int64, from, to int) []core.PlotData {
+	return core.SlicePlotData(v.GetPlotData(startTime, interval), from, to)
+}
@@ -0,0 +1,59 @@
+package volume
+
+import (
+	"math"
+	"testing"
+)
+
+func TestVolumeRSI_RisingVolumeReadsHigh(t *testing.T) {
+	if _, err := NewVolumeRSIWithParams(0); err == nil {
+		t.Fatal("expected error for period 0")
+	}
+	v, err := NewVolumeRSIWithParams(5)
+	if err != nil {
+		t.Fatalf("constructor error: %v", err)
+	}
+	if err := v.Add(-1); err == nil {
+		t.Fatal("expected error for negative volume")
+	}
+	if err := v.Add(0); err != nil {
+		t.Fatalf("zero volume must be accepted: %v", err)
+	}
+
+	// Mostly rising volume with a small dip: gains 100×5 and 100×6, loss 50.
+	for _, vol := range []float64{1000, 1100, 1200, 1150, 1250, 1350, 1450, 1550, 1650, 1750, 1850} {
+		if err := v.Add(vol); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	val, err := v.Calculate()
+	if err != nil {
+		t.Fatalf("Calculate error: %v", err)
+	}
+	if val <= DefaultVolumeRSIOverbought || val > 100 {
+		t.Fatalf("expected a high volume RSI for rising volume, got %v", val)
+	}
+	if zone, _ := v.GetOverboughtOversold(); zone != "Overbought" {
+		t.Fatalf("expected Overbought, got %q", zone)
+	}
+
+	// Seeded value: deltas 1000, 100, 100, -50, 100 → gains 1300/5, loss 50/5.
+	if first := v.GetValues()[0]; math.Abs(first-100*1300.0/1350) > 1e-9 {
+		t.Fatalf("first value = %v, want %v", first, 100*1300.0/1350)
+	}
+
+	// Volume collapses: the oscillator falls back through the overbought level.
+	crossed := false
+	for i := 0; i < 5; i++ {
+		_ = v.Add(200)
+		if c, _ := v.IsBearishCrossover(); c {
+			crossed = true
+		}
+	}
+	if !crossed {
+		t.Fatal("expected a bearish crossover as volume dries up")
+	}
+	if plots := v.GetPlotData(0, 60); len(plots) != 2 || len(plots[0].Y) != len(v.GetValues()) {
+		t.Fatalf("unexpected plot data: %+v", plots)
+	}
+}
@@ -2,6 +2,7 @@ package volume
 
 import (
 	"errors"
+	"math"
 
 	"github.com/evdnx/goti/indicator/core"
 )
@@ -12,13 +13,33 @@ type VWAP struct {
 	cumVol   float64 // cumulative volume
 	vwapVals []float64
 	last     float64
+
+	halfLife float64 // decay half-life in bars; 0 for a plain cumulative VWAP
+	decay    float64 // per-bar multiplier applied to cumPV/cumVol (1 = none)
+
+	transform func(float64) float64 // optional output hook (see SetOutputTransform)
 }
 
 // NewVWAP constructs a VWAP calculator with an empty state.
 func NewVWAP() *VWAP {
 	return &VWAP{
 		vwapVals: make([]float64, 0, 64),
+		decay:    1,
+	}
+}
+
+// NewDecayVWAP constructs a VWAP whose cumulative price×volume and volume sums
+// decay by 0.5^(1/halfLifeBars) every bar, so a bar's weight halves after
+// halfLifeBars bars. The result tracks recent activity without a hard session
+// reset, which suits markets that trade around the clock.
+func NewDecayVWAP(halfLifeBars float64) (*VWAP, error) {
+	if !(halfLifeBars > 0) || math.IsInf(halfLifeBars, 0) {
+		return nil, errors.New("half-life must be a positive number of bars")
 	}
+	v := NewVWAP()
+	v.halfLife = halfLifeBars
+	v.decay = math.Pow(0.5, 1/halfLifeBars)
+	return v, nil
 }
 
 // Add ingests a new OHLCV candle. Typical price is used for VWAP.
@@ -27,11 +48,11 @@ func (v *VWAP) Add(high, low, close, volume float64) error {
 		return errors.New("invalid price or volume")
 	}
 	typicalPrice := (high + low + close) / 3
-	v.cumPV += typicalPrice * volume
-	v.cumVol += volume
+	v.cumPV = v.cumPV*v.decay + typicalPrice*volume
+	v.cumVol = v.cumVol*v.decay + volume
 
 	if v.cumVol > 0 {
-		v.last = v.cumPV / v.cumVol
+		v.last = core.ApplyTransform(v.transform, v.cumPV/v.cumVol)
 		v.vwapVals = append(v.vwapVals, v.last)
 		v.trimSlices()
 	}
@@ -46,7 +67,7 @@ func (v *VWAP) Calculate() (float64, error) {
 	return v.last, nil
 }
 
-// Reset clears all accumulated state.
+// Reset clears all accumulated state; the decay setting is kept.
 func (v *VWAP) Reset() {
 	v.cumPV = 0
 	v.cumVol = 0
@@ -54,6 +75,29 @@ func (v *VWAP) Reset() {
 	v.vwapVals = v.vwapVals[:0]
 }
 
+// SetOutputTransform passes every VWAP value through fn before it is stored
+// and returned by Calculate, GetValues and GetPlotData; the cumulative sums
+// are unaffected. nil restores the identity; the VWAP is reset.
+func (v *VWAP) SetOutputTransform(fn func(float64) float64) {
+	v.transform = fn
+	v.Reset()
+}
+
+// Validate checks the cumulative sums, the retained VWAP values and the
+// latest value for NaN or ±Inf and reports the first one found.
+func (v *VWAP) Validate() error {
+	if err := core.CheckFinite("cumPV", v.cumPV); err != nil {
+		return err
+	}
+	if err := core.CheckFinite("cumVol", v.cumVol); err != nil {
+		return err
+	}
+	if err := core.ValidateFinite("vwapValues", v.vwapVals); err != nil {
+		return err
+	}
+	return core.CheckFinite("last", v.last)
+}
+
 // GetValues returns the VWAP series (defensive copy).
 func (v *VWAP) GetValues() []float64 { return core.CopySlice(v.vwapVals) }
 
@@ -76,7 +120,23 @@ func (v *VWAP) GetPlotData(startTime, interval int64) []core.PlotData {
 	}}
 }
 
+// GetPlotDataRange returns the VWAP plot data for bars [from, to).
+func (v *VWAP) GetPlotDataRange(startTime, interval int64, from, to int) []core.PlotData {
+	return core.SlicePlotData(v.GetPlotData(startTime, interval), from, to)
+}
+
 func (v *VWAP) trimSlices() {
 	const maxKeep = 1024
 	v.vwapVals = core.KeepLast(v.vwapVals, maxKeep)
 }
+
+// Describe reports the VWAP metadata. A plain VWAP is cumulative and has no
+// tunable parameters; a decaying one reports its half-life. A single bar is
+// enough either way.
+func (v *VWAP) Describe() core.IndicatorInfo {
+	params := map[string]any{}
+	if v.halfLife > 0 {
+		params["halfLifeBars"] = v.halfLife
+	}
+	return core.IndicatorInfo{Name: "VWAP", Params: params, SamplesNeeded: 1}
+}
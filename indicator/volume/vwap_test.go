@@ -38,3 +38,62 @@ func TestVWAP_InvalidInput(t *testing.T) {
 		t.Fatal("expected error for negative volume")
 	}
 }
+
+func TestDecayVWAP_ConvergesFasterAfterRegimeShift(t *testing.T) {
+	if _, err := NewDecayVWAP(0); err == nil {
+		t.Fatal("expected error for zero half-life")
+	}
+
+	plain := NewVWAP()
+	decayed, err := NewDecayVWAP(5)
+	if err != nil {
+		t.Fatalf("constructor error: %v", err)
+	}
+
+	// 50 bars around 100, then the market reprices to 120.
+	for i := 0; i < 70; i++ {
+		price := 100.0
+		if i >= 50 {
+			price = 120
+		}
+		for _, v := range []*VWAP{plain, decayed} {
+			if err := v.Add(price+1, price-1, price, 1000); err != nil {
+				t.Fatalf("Add failed: %v", err)
+			}
+		}
+	}
+
+	p, _ := plain.Calculate()
+	d, _ := decayed.Calculate()
+	if math.Abs(120-d) >= math.Abs(120-p) {
+		t.Fatalf("decayed VWAP %.4f should be closer to 120 than plain %.4f", d, p)
+	}
+	// Twenty bars are four half-lives: the old regime's weight has shrunk
+	// 16-fold, leaving the decayed VWAP within about 1% of the new level.
+	if math.Abs(120-d) > 1.5 {
+		t.Fatalf("decayed VWAP %.4f has not converged to the new level", d)
+	}
+	if math.Abs(p-120*20/70.0-100*50/70.0) > 1e-9 {
+		t.Fatalf("plain VWAP should stay volume-weighted over all bars, got %.4f", p)
+	}
+}
+
+func TestVWAP_SetOutputTransform(t *testing.T) {
+	raw, doubled := NewVWAP(), NewVWAP()
+	doubled.SetOutputTransform(func(v float64) float64 { return v * 2 })
+	for i := 0; i < 5; i++ {
+		p := 100 + float64(i)
+		_ = raw.Add(p+1, p-1, p, 1000+float64(i)*10)
+		_ = doubled.Add(p+1, p-1, p, 1000+float64(i)*10)
+	}
+	want, _ := raw.Calculate()
+	if got, _ := doubled.Calculate(); math.Abs(got-2*want) > 1e-9 {
+		t.Fatalf("expected %v, got %v", 2*want, got)
+	}
+	rawVals, vals := raw.GetValues(), doubled.GetPlotData(0, 1)[0].Y
+	for i := range rawVals {
+		if math.Abs(vals[i]-2*rawVals[i]) > 1e-9 {
+			t.Fatalf("plot value %d not doubled: %v vs %v", i, vals[i], rawVals[i])
+		}
+	}
+}
@@ -70,3 +70,25 @@ func TestScalpingIndicatorSuite(t *testing.T) {
 		}
 	})
 }
+
+func TestScalpingIndicatorSuiteAutoCorrect(t *testing.T) {
+	suite, err := NewScalpingIndicatorSuiteWithConfig(DefaultConfig(), WithSuiteAutoCorrect(true))
+	if err != nil {
+		t.Fatalf("NewScalpingIndicatorSuiteWithConfig failed: %v", err)
+	}
+	if err := suite.Add(100, 101, 100.5, 1000); err != nil { // high < low
+		t.Fatalf("expected swapped bar to be corrected, got %v", err)
+	}
+	if err := suite.Add(102, 100, 105, 1000); err != nil { // close above high
+		t.Fatalf("expected out-of-range close to be corrected, got %v", err)
+	}
+	if err := suite.Add(103, 101, 102, 1000); err != nil {
+		t.Fatalf("clean bar failed: %v", err)
+	}
+	if got := suite.CorrectionCount(); got != 2 {
+		t.Fatalf("expected 2 corrections, got %d", got)
+	}
+	if got := suite.GetATR().GetCloses(); got[1] != 102 {
+		t.Fatalf("expected clamped close 102 to reach ATR, got %v", got)
+	}
+}
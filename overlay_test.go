@@ -0,0 +1,57 @@
+package goti
+
+import (
+	"math"
+	"testing"
+)
+
+func TestOverlayPlotDataSharesBarAxis(t *testing.T) {
+	hma, _ := NewHullMovingAverageWithParams(9)
+	bb, _ := NewBollingerBandsWithParams(9, 2)
+	sar, _ := NewParabolicSAR()
+	const bars = 60
+	for i := 0; i < bars; i++ {
+		c := 100 + 5*math.Sin(float64(i)/6)
+		if err := hma.Add(c); err != nil {
+			t.Fatalf("HMA Add failed: %v", err)
+		}
+		if err := bb.Add(c); err != nil {
+			t.Fatalf("Bollinger Add failed: %v", err)
+		}
+		if err := sar.Add(c+1, c-1); err != nil {
+			t.Fatalf("SAR Add failed: %v", err)
+		}
+	}
+
+	hmaLine := hma.GetOverlayPlotData(1000, 60)[0]
+	bands := bb.GetOverlayPlotData(1000, 60)
+	sarLine := sar.GetOverlayPlotData(1000, 60)[0]
+	if len(bands) != 3 {
+		t.Fatalf("expected three bands, got %d", len(bands))
+	}
+	// Both indicators retain 9 values, so their overlays cover the same bars.
+	if len(hmaLine.X) != len(bands[0].X) {
+		t.Fatalf("HMA covers %d bars, Bollinger %d", len(hmaLine.X), len(bands[0].X))
+	}
+	for i := range hmaLine.X {
+		for _, band := range bands {
+			if band.X[i] != hmaLine.X[i] || band.Timestamp[i] != hmaLine.Timestamp[i] {
+				t.Fatalf("point %d: %s at %v/%d, HMA at %v/%d", i, band.Name, band.X[i], band.Timestamp[i], hmaLine.X[i], hmaLine.Timestamp[i])
+			}
+		}
+	}
+	for _, s := range []PlotData{hmaLine, bands[0], sarLine} {
+		last := len(s.X) - 1
+		if s.X[last] != bars-1 || s.Timestamp[last] != 1000+(bars-1)*60 {
+			t.Fatalf("%s should end on bar %d, got %v at %d", s.Name, bars-1, s.X[last], s.Timestamp[last])
+		}
+	}
+
+	// The re-based GetPlotData starts at 0 instead.
+	if x := hma.GetPlotData(1000, 60)[0].X[0]; x != 0 {
+		t.Fatalf("GetPlotData should stay re-based, got first X %v", x)
+	}
+	if hmaLine.X[0] != float64(bars-len(hmaLine.X)) {
+		t.Fatalf("overlay should start on bar %d, got %v", bars-len(hmaLine.X), hmaLine.X[0])
+	}
+}
@@ -0,0 +1,158 @@
+package suite
+
+import "github.com/evdnx/goti/indicator"
+
+// Signal is a categorical per-indicator reading, ordered from most bearish to
+// most bullish so that signals can be compared numerically.
+type Signal int
+
+const (
+	StrongSell Signal = -2
+	Sell       Signal = -1
+	Neutral    Signal = 0
+	Buy        Signal = 1
+	StrongBuy  Signal = 2
+)
+
+// String returns the signal name, e.g. "StrongBuy".
+func (s Signal) String() string {
+	switch s {
+	case StrongSell:
+		return "StrongSell"
+	case Sell:
+		return "Sell"
+	case Buy:
+		return "Buy"
+	case StrongBuy:
+		return "StrongBuy"
+	default:
+		return "Neutral"
+	}
+}
+
+// BarSignals rolls each indicator's crossover and zone state up into a single
+// Signal for the latest bar, suitable for a signal-grid UI:
+//
+//   - MACD, ADMO: Buy/Sell by the sign of the histogram / oscillator, Strong
+//     on the bar it crossed zero
+//   - SAR: Buy/Sell by trend direction, Strong on the bar it flipped
+//   - HMA: +1 for the close above the line and +1 for a rising line (-1 each
+//     for the opposite), summed
+//   - VWAO: Buy/Sell by sign, Strong beyond the configured strong-trend level
+//   - MFI: Strong on a bullish/bearish crossover, otherwise Buy when
+//     oversold and Sell when overbought
+//   - Bollinger: Buy below the lower band, Sell above the upper band
+//     (mean reversion)
+//   - VWAP: Buy above, Sell below
+//
+// ATR carries no direction and is not included. Indicators without a value
+// yet are omitted.
+func (suite *ScalpingIndicatorSuite) BarSignals() map[string]Signal {
+	out := make(map[string]Signal, 8)
+	if !suite.hasClose {
+		return out
+	}
+	close := suite.lastClose
+
+	// Crossovers detected for this bar by recordEvents.
+	crossed := make(map[string]int)
+	bar := suite.closeCount - 1
+	for i := len(suite.events) - 1; i >= 0 && suite.events[i].Index == bar; i-- {
+		ev := suite.events[i]
+		if ev.Kind == indicator.EventBullishCrossover || ev.Kind == indicator.EventBearishCrossover {
+			crossed[ev.Source] = ev.Direction
+		}
+	}
+	directional := func(source string, v float64) {
+		s := Neutral
+		if v > 0 {
+			s = Buy
+		} else if v < 0 {
+			s = Sell
+		}
+		if dir := crossed[source]; dir != 0 && Signal(dir) == s {
+			s *= 2
+		}
+		out[source] = s
+	}
+
+	if _, _, hist, err := suite.macd.Calculate(); err == nil {
+		directional("MACD", hist)
+	}
+	if v, err := suite.admo.Calculate(); err == nil {
+		directional("ADMO", v)
+	}
+	if _, err := suite.sar.Calculate(); err == nil {
+		dir := -1.0
+		if suite.sar.IsUptrend() {
+			dir = 1
+		}
+		directional("SAR", dir)
+	}
+
+	if v, err := suite.hma.Calculate(); err == nil {
+		s := Neutral
+		if close > v {
+			s++
+		} else if close < v {
+			s--
+		}
+		switch trend, _ := suite.hma.GetTrendDirection(); trend {
+		case "Bullish":
+			s++
+		case "Bearish":
+			s--
+		}
+		out["HMA"] = s
+	}
+
+	if v, err := suite.vwao.Calculate(); err == nil {
+		s := Neutral
+		if v > 0 {
+			s = Buy
+		} else if v < 0 {
+			s = Sell
+		}
+		if strong, err := suite.vwao.IsStrongTrend(); err == nil && strong {
+			s *= 2
+		}
+		out["VWAO"] = s
+	}
+
+	if _, err := suite.mfi.Calculate(); err == nil {
+		s := Neutral
+		switch zone, _ := suite.mfi.GetOverboughtOversold(); zone {
+		case "Oversold":
+			s = Buy
+		case "Overbought":
+			s = Sell
+		}
+		if up, err := suite.mfi.IsBullishCrossover(); err == nil && up {
+			s = StrongBuy
+		} else if down, err := suite.mfi.IsBearishCrossover(); err == nil && down {
+			s = StrongSell
+		}
+		out["MFI"] = s
+	}
+
+	if upper, _, lower, err := suite.bollinger.Calculate(); err == nil {
+		s := Neutral
+		if close < lower {
+			s = Buy
+		} else if close > upper {
+			s = Sell
+		}
+		out["Bollinger"] = s
+	}
+
+	if v, err := suite.vwap.Calculate(); err == nil {
+		s := Neutral
+		if close > v {
+			s = Buy
+		} else if close < v {
+			s = Sell
+		}
+		out["VWAP"] = s
+	}
+	return out
+}
@@ -0,0 +1,46 @@
+package suite
+
+import "testing"
+
+func TestBarSignalsAfterBullishSetup(t *testing.T) {
+	s, err := NewScalpingIndicatorSuite()
+	if err != nil {
+		t.Fatalf("constructor failed: %v", err)
+	}
+	if got := s.BarSignals(); len(got) != 0 {
+		t.Fatalf("expected no signals before data, got %v", got)
+	}
+
+	// A slide followed by a clean, steady advance.
+	price := 120.0
+	for i := 0; i < 90; i++ {
+		if i < 40 {
+			price -= 0.5
+		} else {
+			price += 0.6
+		}
+		if err := s.Add(price+0.3, price-0.3, price, 1000); err != nil {
+			t.Fatalf("add failed at %d: %v", i, err)
+		}
+	}
+
+	got := s.BarSignals()
+	for _, name := range []string{"HMA", "SAR", "MACD", "VWAP"} {
+		sig, ok := got[name]
+		if !ok {
+			t.Fatalf("missing %s in %v", name, got)
+		}
+		if sig < Buy {
+			t.Fatalf("expected a Buy-family signal for %s, got %v", name, sig)
+		}
+	}
+	if got["HMA"] != StrongBuy {
+		t.Fatalf("expected StrongBuy for HMA with price above a rising line, got %v", got["HMA"])
+	}
+	if _, ok := got["ATR"]; ok {
+		t.Fatal("ATR has no direction and should not be reported")
+	}
+	if StrongSell.String() != "StrongSell" || Signal(7).String() != "Neutral" {
+		t.Fatal("unexpected Signal names")
+	}
+}
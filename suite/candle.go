@@ -0,0 +1,44 @@
+package suite
+
+import (
+	"fmt"
+
+	"github.com/evdnx/goti/indicator"
+)
+
+// AddOHLCV feeds a full bar including its open. The open is kept for
+// BodyStrength and for any open-aware indicator added to the suite later;
+// the current indicators only use high, low, close and volume, so the bar is
+// otherwise processed exactly like Add. The open must lie within [low, high];
+// WithAutoCorrect repairs high, low and close only, so the open is checked
+// against the repaired range and never adjusted itself.
+func (suite *ScalpingIndicatorSuite) AddOHLCV(open, high, low, close, volume float64) error {
+	if !indicator.IsNonNegativePrice(open) {
+		return fmt.Errorf("invalid price: open (%v)", open)
+	}
+	hi, lo := high, low
+	if suite.autoCorrect {
+		hi, lo, _, _ = indicator.CorrectCandle(high, low, close)
+	}
+	if hi >= lo && (open < lo || open > hi) {
+		return fmt.Errorf("invalid price: open (%v) outside [%v, %v]", open, lo, hi)
+	}
+	return suite.addBar(open, high, low, close, volume)
+}
+
+// BodyStrength returns the latest candle body normalised by its range,
+// (close-open)/(high-low): +1 for a bar that opened on its low and closed on
+// its high, -1 for the reverse, near 0 for a doji. A bar without range reads
+// 0. AddOHLCV keeps the open inside the range, but bars fed through Add use
+// the previous close as the open, so a gap can push their raw ratio past ±1;
+// the result is clamped to [-1, 1].
+func (suite *ScalpingIndicatorSuite) BodyStrength() (float64, error) {
+	if !suite.hasClose {
+		return 0, fmt.Errorf("no bars added")
+	}
+	rng := suite.lastHigh - suite.lastLow
+	if rng == 0 {
+		return 0, nil
+	}
+	return indicator.Clamp((suite.lastClose-suite.lastOpen)/rng, -1, 1), nil
+}
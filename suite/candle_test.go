@@ -0,0 +1,47 @@
+package suite
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBodyStrengthStrongBullishCandle(t *testing.T) {
+	s, err := NewScalpingIndicatorSuite()
+	if err != nil {
+		t.Fatalf("constructor failed: %v", err)
+	}
+	if _, err := s.BodyStrength(); err == nil {
+		t.Fatal("expected an error before any bar")
+	}
+	if err := s.AddOHLCV(-1, 101, 99, 100, 1000); err == nil {
+		t.Fatal("expected an error for a negative open")
+	}
+	for _, open := range []float64{98.5, 101.5} {
+		if err := s.AddOHLCV(open, 101, 99, 100, 1000); err == nil {
+			t.Fatalf("expected an error for open %v outside [99, 101]", open)
+		}
+	}
+	if _, err := s.BodyStrength(); err == nil {
+		t.Fatal("rejected bars must not be recorded")
+	}
+
+	// Opens near the low and closes near the high: body 9 of a 10-point range.
+	if err := s.AddOHLCV(100.5, 110, 100, 109.5, 1000); err != nil {
+		t.Fatalf("AddOHLCV failed: %v", err)
+	}
+	got, err := s.BodyStrength()
+	if err != nil {
+		t.Fatalf("BodyStrength failed: %v", err)
+	}
+	if math.Abs(got-0.9) > 1e-9 {
+		t.Fatalf("body strength = %v, want 0.9", got)
+	}
+
+	// Add uses the previous close (109.5) as the open: (105-109.5)/(111-104).
+	if err := s.Add(111, 104, 105, 1000); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if got, _ := s.BodyStrength(); math.Abs(got-(-4.5/7)) > 1e-9 {
+		t.Fatalf("body strength after Add = %v, want %v", got, -4.5/7)
+	}
+}
@@ -0,0 +1,77 @@
+package suite
+
+import "github.com/evdnx/goti/indicator"
+
+// SuiteDiff lists what one bar changed, for pushing compact updates to a
+// client. Indicator names match the FeatureMatrix columns.
+type SuiteDiff struct {
+	// Bar is the index of the bar, matching SignalEvent.Index.
+	Bar int `json:"bar"`
+	// Changed holds every indicator reading that is new or differs from the
+	// previous bar. Indicators still warming up are absent.
+	Changed map[string]float64 `json:"changed,omitempty"`
+	// Events are the crossover/zone events recorded on this bar.
+	Events []indicator.SignalEvent `json:"events,omitempty"`
+}
+
+// AddAndDiff feeds a bar like Add and reports only the readings that changed
+// and the events it produced, so a streaming client receives a delta instead
+// of the full state. A rejected bar returns the Add error and an empty diff.
+func (suite *ScalpingIndicatorSuite) AddAndDiff(high, low, close, volume float64) (SuiteDiff, error) {
+	before := suite.readings()
+	if err := suite.Add(high, low, close, volume); err != nil {
+		return SuiteDiff{}, err
+	}
+	diff := SuiteDiff{Bar: suite.closeCount - 1}
+	for name, v := range suite.readings() {
+		if old, ok := before[name]; ok && old == v {
+			continue
+		}
+		if diff.Changed == nil {
+			diff.Changed = make(map[string]float64)
+		}
+		diff.Changed[name] = v
+	}
+	first := len(suite.events)
+	for first > 0 && suite.events[first-1].Index == diff.Bar {
+		first--
+	}
+	if first < len(suite.events) {
+		diff.Events = append([]indicator.SignalEvent(nil), suite.events[first:]...)
+	}
+	return diff, nil
+}
+
+// readings returns the latest value of every indicator that has one, keyed by
+// its FeatureMatrix column name.
+func (suite *ScalpingIndicatorSuite) readings() map[string]float64 {
+	r := make(map[string]float64, len(featureColumns))
+	if v, err := suite.admo.Calculate(); err == nil {
+		r["ADMO"] = v
+	}
+	if v, err := suite.vwao.Calculate(); err == nil {
+		r["VWAO"] = v
+	}
+	if macd, signal, hist, err := suite.macd.Calculate(); err == nil {
+		r["MACD"], r["MACDSignal"], r["MACDHistogram"] = macd, signal, hist
+	}
+	if v, err := suite.hma.Calculate(); err == nil {
+		r["HMA"] = v
+	}
+	if v, err := suite.sar.Calculate(); err == nil {
+		r["SAR"] = v
+	}
+	if upper, middle, lower, err := suite.bollinger.Calculate(); err == nil {
+		r["BollingerUpper"], r["BollingerMiddle"], r["BollingerLower"] = upper, middle, lower
+	}
+	if v, err := suite.atr.Calculate(); err == nil {
+		r["ATR"] = v
+	}
+	if v, err := suite.vwap.Calculate(); err == nil {
+		r["VWAP"] = v
+	}
+	if v, err := suite.mfi.Calculate(); err == nil {
+		r["MFI"] = v
+	}
+	return r
+}
@@ -0,0 +1,60 @@
+package suite
+
+import "testing"
+
+func TestAddAndDiffReportsOnlyWarmChanges(t *testing.T) {
+	s, err := NewScalpingIndicatorSuite()
+	if err != nil {
+		t.Fatalf("constructor failed: %v", err)
+	}
+	seenWarmup, events := false, 0
+	for i, b := range syntheticBars(2, 80) {
+		diff, err := s.AddAndDiff(b.High, b.Low, b.Close, b.Volume)
+		if err != nil {
+			t.Fatalf("AddAndDiff failed at %d: %v", i, err)
+		}
+		if diff.Bar != i {
+			t.Fatalf("bar %d reported as %d", i, diff.Bar)
+		}
+		now := s.readings()
+		for _, name := range featureColumns[1:] {
+			got, inDiff := diff.Changed[name]
+			cur, warm := now[name]
+			switch {
+			case !warm && inDiff:
+				t.Fatalf("bar %d: %s is warming up but appears in the diff", i, name)
+			case !warm:
+				seenWarmup = true
+			case inDiff && got != cur:
+				t.Fatalf("bar %d: %s diff %v, current %v", i, name, got, cur)
+			}
+		}
+		for _, e := range diff.Events {
+			if e.Index != i {
+				t.Fatalf("bar %d: event from bar %d in diff", i, e.Index)
+			}
+		}
+		events += len(diff.Events)
+	}
+	if !seenWarmup {
+		t.Fatal("expected some indicators to start in warm-up")
+	}
+	if len(s.readings()) != len(featureColumns)-1 {
+		t.Fatalf("expected every indicator warm after 80 bars, got %v", s.readings())
+	}
+	if events != len(s.RecentEvents(0)) {
+		t.Fatalf("diffs carried %d events, suite recorded %d", events, len(s.RecentEvents(0)))
+	}
+
+	// A much wider bar moves the warmed ATR, so it must be in the diff.
+	diff, err := s.AddAndDiff(200, 190, 195, 5000)
+	if err != nil {
+		t.Fatalf("AddAndDiff failed: %v", err)
+	}
+	if _, ok := diff.Changed["ATR"]; !ok {
+		t.Fatalf("expected the ATR jump in the diff, got %v", diff.Changed)
+	}
+	if _, err := s.AddAndDiff(1, 2, 1.5, 100); err == nil {
+		t.Fatal("expected error for an invalid bar")
+	}
+}
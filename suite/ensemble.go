@@ -0,0 +1,140 @@
+package suite
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/evdnx/goti/config"
+)
+
+// EnsembleSuite runs several ScalpingIndicatorSuites with different presets
+// (e.g. fast, medium and slow) on the same feed and lets them vote on the
+// direction.
+type EnsembleSuite struct {
+	members []*ScalpingIndicatorSuite
+}
+
+// EnsembleMember describes one member of an EnsembleSuite: the config and
+// options passed to NewScalpingIndicatorSuiteWithConfig. The suite fixes its
+// own periods and its MFI, ADMO and VWAO thresholds, so presets that should
+// vote differently mostly differ in their options, e.g.
+// WithMomentumConfirmation for a fast member and WithSignalHysteresis for a
+// slow one.
+type EnsembleMember struct {
+	Config  config.IndicatorConfig
+	Options []SuiteOption
+}
+
+// NewEnsembleSuite builds one member suite per configuration, in order, with
+// the default suite options. Configurations alone barely change a member's
+// vote; use NewEnsembleSuiteWithMembers to give members distinct options.
+func NewEnsembleSuite(cfgs ...config.IndicatorConfig) (*EnsembleSuite, error) {
+	members := make([]EnsembleMember, len(cfgs))
+	for i, cfg := range cfgs {
+		members[i] = EnsembleMember{Config: cfg}
+	}
+	return NewEnsembleSuiteWithMembers(members...)
+}
+
+// NewEnsembleSuiteWithMembers builds one member suite per EnsembleMember, in
+// order.
+func NewEnsembleSuiteWithMembers(members ...EnsembleMember) (*EnsembleSuite, error) {
+	if len(members) == 0 {
+		return nil, errors.New("at least one member is required")
+	}
+	e := &EnsembleSuite{members: make([]*ScalpingIndicatorSuite, 0, len(members))}
+	for i, m := range members {
+		s, err := NewScalpingIndicatorSuiteWithConfig(m.Config, m.Options...)
+		if err != nil {
+			return nil, fmt.Errorf("member %d: %w", i, err)
+		}
+		e.members = append(e.members, s)
+	}
+	return e, nil
+}
+
+// Add feeds the bar to every member. The first member error is returned
+// after all members have seen the bar.
+func (e *EnsembleSuite) Add(high, low, close, volume float64) error {
+	var firstErr error
+	for i, s := range e.members {
+		if err := s.Add(high, low, close, volume); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("member %d: %w", i, err)
+		}
+	}
+	return firstErr
+}
+
+// VoteSignal reduces every member's GetCombinedSignal to "Bullish",
+// "Bearish" or "Neutral" (strength is ignored) and returns the direction with
+// the most votes and the fraction of members that cast it. When two
+// directions tie for the most votes the result is "Neutral", with the
+// fraction of members that actually voted Neutral. It errors if any
+// member's GetCombinedSignal fails.
+func (e *EnsembleSuite) VoteSignal() (string, float64, error) {
+	signals := make([]string, len(e.members))
+	for i, s := range e.members {
+		signal, err := s.GetCombinedSignal()
+		if err != nil {
+			return "", 0, fmt.Errorf("member %d: %w", i, err)
+		}
+		signals[i] = signal
+	}
+	direction, agreement := voteDirection(signals)
+	return direction, agreement, nil
+}
+
+// voteDirection tallies combined-signal labels by direction.
+func voteDirection(signals []string) (string, float64) {
+	votes := map[string]int{}
+	for _, signal := range signals {
+		switch level := signalLevels[signal]; {
+		case level > 0:
+			votes["Bullish"]++
+		case level < 0:
+			votes["Bearish"]++
+		default:
+			votes["Neutral"]++
+		}
+	}
+	direction := "Neutral"
+	switch bull, bear, neutral := votes["Bullish"], votes["Bearish"], votes["Neutral"]; {
+	case bull > bear && bull > neutral:
+		direction = "Bullish"
+	case bear > bull && bear > neutral:
+		direction = "Bearish"
+	}
+	return direction, float64(votes[direction]) / float64(len(signals))
+}
+
+// Len returns the number of member suites.
+func (e *EnsembleSuite) Len() int { return len(e.members) }
+
+// Member returns the i-th member suite, in constructor order, or nil when i
+// is out of range.
+func (e *EnsembleSuite) Member(i int) *ScalpingIndicatorSuite {
+	if i < 0 || i >= len(e.members) {
+		return nil
+	}
+	return e.members[i]
+}
+
+// MemberSnapshots returns every member's Snapshot, in constructor order.
+func (e *EnsembleSuite) MemberSnapshots() ([]SuiteSnapshot, error) {
+	out := make([]SuiteSnapshot, len(e.members))
+	for i, s := range e.members {
+		snap, err := s.Snapshot()
+		if err != nil {
+			return nil, fmt.Errorf("member %d: %w", i, err)
+		}
+		out[i] = snap
+	}
+	return out, nil
+}
+
+// Reset clears every member suite.
+func (e *EnsembleSuite) Reset() {
+	for _, s := range e.members {
+		s.Reset()
+	}
+}
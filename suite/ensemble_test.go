@@ -0,0 +1,106 @@
+package suite
+
+import (
+	"math"
+	"testing"
+
+	"github.com/evdnx/goti/config"
+)
+
+func TestVoteDirectionMajority(t *testing.T) {
+	cases := []struct {
+		signals   []string
+		direction string
+		agreement float64
+	}{
+		{[]string{"Bullish", "Strong Bullish", "Weak Bearish"}, "Bullish", 2.0 / 3},
+		{[]string{"Bearish", "Neutral", "Strong Bearish"}, "Bearish", 2.0 / 3},
+		{[]string{"Bullish", "Bearish", "Neutral"}, "Neutral", 1.0 / 3},
+		{[]string{"Weak Bullish", "Weak Bearish"}, "Neutral", 0},
+	}
+	for _, c := range cases {
+		dir, agree := voteDirection(c.signals)
+		if dir != c.direction || math.Abs(agree-c.agreement) > 1e-9 {
+			t.Fatalf("%v: got %s %.2f, want %s %.2f", c.signals, dir, agree, c.direction, c.agreement)
+		}
+	}
+}
+
+func TestEnsembleSuiteVoteMatchesMembers(t *testing.T) {
+	if _, err := NewEnsembleSuite(); err == nil {
+		t.Fatal("expected error without configurations")
+	}
+	if _, err := NewEnsembleSuiteWithMembers(EnsembleMember{Config: config.DefaultConfig(), Options: []SuiteOption{WithSignalHysteresis(-1)}}); err == nil {
+		t.Fatal("expected error for an invalid member option")
+	}
+	// The members differ only in how they are built: fast confirms momentum
+	// after a single close, slow waits for a label to hold for three bars,
+	// medium keeps the suite defaults.
+	cfg := config.DefaultConfig()
+	e, err := NewEnsembleSuiteWithMembers(
+		EnsembleMember{Config: cfg, Options: []SuiteOption{WithMomentumConfirmation(1, 0.3)}},
+		EnsembleMember{Config: cfg},
+		EnsembleMember{Config: cfg, Options: []SuiteOption{WithSignalHysteresis(3)}},
+	)
+	if err != nil {
+		t.Fatalf("constructor failed: %v", err)
+	}
+	for _, b := range syntheticBars(2, 41) {
+		if err := e.Add(b.High, b.Low, b.Close, b.Volume); err != nil {
+			t.Fatalf("add failed: %v", err)
+		}
+	}
+
+	snaps, err := e.MemberSnapshots()
+	if err != nil || len(snaps) != 3 || e.Len() != 3 {
+		t.Fatalf("MemberSnapshots: %d snapshots, err %v", len(snaps), err)
+	}
+	want := []string{"Strong Bullish", "Strong Bullish", "Neutral"}
+	for i, snap := range snaps {
+		if snap.Bars != 41 || snap.Signal != want[i] {
+			t.Fatalf("member %d: %d bars, signal %q, want 41 bars, %q", i, snap.Bars, snap.Signal, want[i])
+		}
+	}
+	direction, agreement, err := e.VoteSignal()
+	if err != nil {
+		t.Fatalf("VoteSignal failed: %v", err)
+	}
+	if direction != "Bullish" || math.Abs(agreement-2.0/3) > 1e-9 {
+		t.Fatalf("vote = %s %.2f, want Bullish 0.67", direction, agreement)
+	}
+	if e.Member(3) != nil || e.Member(0) == nil {
+		t.Fatal("Member bounds check failed")
+	}
+}
+
+func TestSuiteOptionsMatchSetters(t *testing.T) {
+	if _, err := NewScalpingIndicatorSuiteWithConfig(config.DefaultConfig(), WithMomentumConfirmation(0, 0.1)); err == nil {
+		t.Fatal("expected error for zero momentum bars")
+	}
+	if _, err := NewScalpingIndicatorSuiteWithConfig(config.DefaultConfig(), WithMomentumConfirmation(1, math.NaN())); err == nil {
+		t.Fatal("expected error for a NaN momentum boost")
+	}
+	built, err := NewScalpingIndicatorSuiteWithConfig(config.DefaultConfig(), WithMomentumConfirmation(1, 0.3), WithSignalHysteresis(3))
+	if err != nil {
+		t.Fatalf("constructor failed: %v", err)
+	}
+	set, _ := NewScalpingIndicatorSuiteWithConfig(config.DefaultConfig())
+	if err := set.SetMomentumConfirmation(1, 0.3); err != nil {
+		t.Fatalf("SetMomentumConfirmation failed: %v", err)
+	}
+	if err := set.SetSignalHysteresis(3); err != nil {
+		t.Fatalf("SetSignalHysteresis failed: %v", err)
+	}
+	if built.SignalHysteresis() != 3 {
+		t.Fatalf("expected hysteresis 3, got %d", built.SignalHysteresis())
+	}
+	for i, b := range syntheticBars(5, 60) {
+		_ = built.Add(b.High, b.Low, b.Close, b.Volume)
+		_ = set.Add(b.High, b.Low, b.Close, b.Volume)
+		got, errGot := built.GetCombinedSignal()
+		want, errWant := set.GetCombinedSignal()
+		if got != want || (errGot == nil) != (errWant == nil) {
+			t.Fatalf("bar %d: option-built suite says %q, setter-built %q", i, got, want)
+		}
+	}
+}
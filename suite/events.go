@@ -0,0 +1,123 @@
+package suite
+
+import "github.com/evdnx/goti/indicator"
+
+// maxSuiteEvents bounds the retained event history; older events are
+// dropped from the front once it is exceeded.
+const maxSuiteEvents = 256
+
+// eventState remembers the previous bar's readings so that Add can detect
+// transitions without re-reporting a crossover on bars that produce no new
+// indicator value.
+type eventState struct {
+	macdHist    float64
+	hasMACD     bool
+	admo        float64
+	hasADMO     bool
+	sarUptrend  bool
+	hasSAR      bool
+	mfiZone     string
+	bollingerZn string
+}
+
+// RecentEvents returns up to n of the most recent crossover/zone events
+// recorded during Add, oldest first and newest last. n <= 0 returns all
+// retained events (at most 256).
+func (suite *ScalpingIndicatorSuite) RecentEvents(n int) []indicator.SignalEvent {
+	if n <= 0 || n > len(suite.events) {
+		n = len(suite.events)
+	}
+	out := make([]indicator.SignalEvent, n)
+	copy(out, suite.events[len(suite.events)-n:])
+	return out
+}
+
+// recordEvents compares the latest indicator readings with the previous bar
+// and appends any transitions to the event history.
+func (suite *ScalpingIndicatorSuite) recordEvents() {
+	bar := suite.closeCount - 1
+	st := &suite.eventState
+	emit := func(source, kind string, dir int, value float64) {
+		suite.events = append(suite.events, indicator.SignalEvent{
+			Index:     bar,
+			Source:    source,
+			Kind:      kind,
+			Direction: dir,
+			Value:     value,
+			Price:     suite.lastClose,
+		})
+	}
+	cross := func(source string, prev, cur float64) {
+		if prev <= 0 && cur > 0 {
+			emit(source, indicator.EventBullishCrossover, 1, cur)
+		} else if prev >= 0 && cur < 0 {
+			emit(source, indicator.EventBearishCrossover, -1, cur)
+		}
+	}
+	zone := func(source, prev, cur string, value float64) {
+		if prev == cur || cur == "" {
+			return
+		}
+		switch cur {
+		case "Overbought":
+			emit(source, indicator.EventOverbought, -1, value)
+		case "Oversold":
+			emit(source, indicator.EventOversold, 1, value)
+		default:
+			if prev != "" {
+				emit(source, indicator.EventNeutral, 0, value)
+			}
+		}
+	}
+
+	// MACD: histogram sign change (MACD line crossing its signal line).
+	if _, _, hist, err := suite.macd.Calculate(); err == nil {
+		if st.hasMACD {
+			cross("MACD", st.macdHist, hist)
+		}
+		st.macdHist, st.hasMACD = hist, true
+	}
+
+	// ADMO: zero-line crossover.
+	if v, err := suite.admo.Calculate(); err == nil {
+		if st.hasADMO {
+			cross("ADMO", st.admo, v)
+		}
+		st.admo, st.hasADMO = v, true
+	}
+
+	// Parabolic SAR: trend flip (price crossing the SAR).
+	if v, err := suite.sar.Calculate(); err == nil {
+		up := suite.sar.IsUptrend()
+		if st.hasSAR && up != st.sarUptrend {
+			if up {
+				emit("SAR", indicator.EventBullishCrossover, 1, v)
+			} else {
+				emit("SAR", indicator.EventBearishCrossover, -1, v)
+			}
+		}
+		st.sarUptrend, st.hasSAR = up, true
+	}
+
+	// MFI: overbought/oversold zone transitions.
+	if z, err := suite.mfi.GetOverboughtOversold(); err == nil {
+		zone("MFI", st.mfiZone, z, suite.mfi.GetLastValue())
+		st.mfiZone = z
+	}
+
+	// Bollinger: close breaking out of / returning inside the bands.
+	if upper, middle, lower, err := suite.bollinger.Calculate(); err == nil {
+		z, band := "Neutral", middle
+		if suite.lastClose > upper {
+			z, band = "Overbought", upper
+		} else if suite.lastClose < lower {
+			z, band = "Oversold", lower
+		}
+		zone("Bollinger", st.bollingerZn, z, band)
+		st.bollingerZn = z
+	}
+
+	if len(suite.events) > maxSuiteEvents {
+		suite.events = append(suite.events[:0], suite.events[len(suite.events)-maxSuiteEvents:]...)
+	}
+}
@@ -0,0 +1,64 @@
+package suite
+
+import (
+	"testing"
+
+	"github.com/evdnx/goti/indicator"
+)
+
+func TestRecentEventsRecordsTransitionsInOrder(t *testing.T) {
+	s, err := NewScalpingIndicatorSuite()
+	if err != nil {
+		t.Fatalf("constructor failed: %v", err)
+	}
+	price := 100.0
+	// Steady rally, then a sharp selloff.
+	for i := 0; i < 30; i++ {
+		price += 1
+		if err := s.Add(price+0.5, price-0.5, price, 1000); err != nil {
+			t.Fatalf("add failed at %d: %v", i, err)
+		}
+	}
+	for _, e := range s.RecentEvents(0) {
+		if e.Direction < 0 && e.Kind != indicator.EventOverbought {
+			t.Fatalf("unexpected bearish event during rally: %+v", e)
+		}
+	}
+	for i := 0; i < 15; i++ {
+		price -= 2
+		if err := s.Add(price+0.5, price-0.5, price, 1500); err != nil {
+			t.Fatalf("add failed at %d: %v", i, err)
+		}
+	}
+
+	events := s.RecentEvents(0)
+	want := []struct{ source, kind string }{
+		{"MFI", indicator.EventOverbought},
+		{"MACD", indicator.EventBearishCrossover},
+		{"SAR", indicator.EventBearishCrossover},
+		{"ADMO", indicator.EventBearishCrossover},
+		{"MFI", indicator.EventOversold},
+	}
+	next := 0
+	for i, e := range events {
+		if i > 0 && e.Index < events[i-1].Index {
+			t.Fatalf("events out of order: %+v before %+v", events[i-1], e)
+		}
+		if next < len(want) && e.Source == want[next].source && e.Kind == want[next].kind {
+			next++
+		}
+	}
+	if next != len(want) {
+		t.Fatalf("expected event sequence %v, got %+v", want, events)
+	}
+
+	last := s.RecentEvents(2)
+	if len(last) != 2 || last[1] != events[len(events)-1] {
+		t.Fatalf("RecentEvents(2) should return the newest events last, got %+v", last)
+	}
+
+	s.Reset()
+	if len(s.RecentEvents(0)) != 0 {
+		t.Fatal("Reset should clear the event history")
+	}
+}
@@ -0,0 +1,128 @@
+package suite
+
+import "github.com/evdnx/goti/indicator"
+
+// maxFeatureRows bounds the retained feature matrix.
+const maxFeatureRows = 512
+
+// featureColumns names the FeatureMatrix columns, in order.
+var featureColumns = []string{
+	"Close",
+	"ADMO",
+	"VWAO",
+	"MACD",
+	"MACDSignal",
+	"MACDHistogram",
+	"HMA",
+	"SAR",
+	"BollingerUpper",
+	"BollingerMiddle",
+	"BollingerLower",
+	"ATR",
+	"VWAP",
+	"MFI",
+}
+
+// FeatureMatrix returns the suite's indicator readings as an aligned matrix:
+// one row per bar, one column per name in cols. A row is recorded by Add only
+// once every indicator has produced a value, so the warm-up bars are dropped
+// rather than padded and every row is fully populated. At most the latest 512
+// rows are retained; the returned slices are copies.
+func (suite *ScalpingIndicatorSuite) FeatureMatrix() (cols []string, rows [][]float64) {
+	cols = append([]string(nil), featureColumns...)
+	rows = make([][]float64, len(suite.features))
+	for i, r := range suite.features {
+		rows[i] = append([]float64(nil), r...)
+	}
+	return cols, rows
+}
+
+// recordFeatures appends the current readings as a feature row when every
+// indicator is warm, and reports whether it did.
+func (suite *ScalpingIndicatorSuite) recordFeatures() bool {
+	admo, err := suite.admo.Calculate()
+	if err != nil {
+		return false
+	}
+	vwao, err := suite.vwao.Calculate()
+	if err != nil {
+		return false
+	}
+	macd, signal, hist, err := suite.macd.Calculate()
+	if err != nil {
+		return false
+	}
+	hma, err := suite.hma.Calculate()
+	if err != nil {
+		return false
+	}
+	sar, err := suite.sar.Calculate()
+	if err != nil {
+		return false
+	}
+	upper, middle, lower, err := suite.bollinger.Calculate()
+	if err != nil {
+		return false
+	}
+	atr, err := suite.atr.Calculate()
+	if err != nil {
+		return false
+	}
+	vwap, err := suite.vwap.Calculate()
+	if err != nil {
+		return false
+	}
+	mfi, err := suite.mfi.Calculate()
+	if err != nil {
+		return false
+	}
+
+	suite.features = append(suite.features, []float64{
+		suite.lastClose, admo, vwao, macd, signal, hist, hma, sar,
+		upper, middle, lower, atr, vwap, mfi,
+	})
+	if len(suite.features) > maxFeatureRows {
+		suite.features = append(suite.features[:0], suite.features[len(suite.features)-maxFeatureRows:]...)
+	}
+	return true
+}
+
+// OutputCorrelations returns the pairwise Pearson correlation of the
+// indicator columns of FeatureMatrix (every column except Close) over the
+// last window rows, or all retained rows when fewer exist. Pairs with a
+// constant column have no defined correlation and report 0; the diagonal is
+// always 1. Both results are nil until at least two rows are recorded.
+// Values near ±1 flag indicators that carry the same information.
+func (suite *ScalpingIndicatorSuite) OutputCorrelations(window int) (names []string, matrix [][]float64) {
+	rows := suite.features
+	if window > 0 && len(rows) > window {
+		rows = rows[len(rows)-window:]
+	}
+	if len(rows) < 2 {
+		return nil, nil
+	}
+
+	names = append([]string(nil), featureColumns[1:]...)
+	series := make([][]float64, len(names))
+	for j := range names {
+		series[j] = make([]float64, len(rows))
+		for i, r := range rows {
+			series[j][i] = r[j+1]
+		}
+	}
+	matrix = make([][]float64, len(names))
+	for i := range matrix {
+		matrix[i] = make([]float64, len(names))
+		matrix[i][i] = 1
+	}
+	for i := range names {
+		for j := i + 1; j < len(names); j++ {
+			c, err := indicator.Correlation(series[i], series[j])
+			if err != nil {
+				c = 0
+			}
+			matrix[i][j], matrix[j][i] = c, c
+		}
+	}
+	return names, matrix
+}
@@ -0,0 +1,100 @@
+package suite
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFeatureMatrixRowsAreAlignedAndComplete(t *testing.T) {
+	s, err := NewScalpingIndicatorSuite()
+	if err != nil {
+		t.Fatalf("constructor failed: %v", err)
+	}
+	if _, rows := s.FeatureMatrix(); len(rows) != 0 {
+		t.Fatalf("expected no rows before warm-up, got %d", len(rows))
+	}
+
+	const bars = 80
+	for i := 0; i < bars; i++ {
+		price := 100 + 5*math.Sin(float64(i)/6) + 0.1*float64(i)
+		if err := s.Add(price+0.6, price-0.6, price, 1000+float64(i%7)*100); err != nil {
+			t.Fatalf("add failed at %d: %v", i, err)
+		}
+	}
+
+	cols, rows := s.FeatureMatrix()
+	if len(rows) == 0 || len(rows) >= bars {
+		t.Fatalf("expected warm-up bars to be dropped, got %d rows from %d bars", len(rows), bars)
+	}
+	for i, row := range rows {
+		if len(row) != len(cols) {
+			t.Fatalf("row %d has %d values, want %d columns", i, len(row), len(cols))
+		}
+		for j, v := range row {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				t.Fatalf("row %d column %s is not finite: %v", i, cols[j], v)
+			}
+		}
+	}
+	if last := rows[len(rows)-1]; last[0] != s.lastClose {
+		t.Fatalf("last row should end on the latest close %v, got %v", s.lastClose, last[0])
+	}
+
+	// Mutating the returned matrix must not leak into the suite.
+	rows[0][0] = -1
+	if _, again := s.FeatureMatrix(); again[0][0] == -1 {
+		t.Fatal("FeatureMatrix must return copies")
+	}
+
+	s.Reset()
+	if _, rows := s.FeatureMatrix(); len(rows) != 0 {
+		t.Fatalf("expected Reset to clear the matrix, got %d rows", len(rows))
+	}
+}
+
+func TestOutputCorrelations(t *testing.T) {
+	s, err := NewScalpingIndicatorSuite()
+	if err != nil {
+		t.Fatalf("constructor failed: %v", err)
+	}
+	if names, m := s.OutputCorrelations(20); names != nil || m != nil {
+		t.Fatal("expected nil results before any rows are recorded")
+	}
+
+	// On a steady ramp with a constant bar range the Bollinger bands move in
+	// parallel (the window's deviation is constant) and the HMA, whose lag
+	// cancels on linear input, tracks the middle band exactly.
+	for i := 0; i < 80; i++ {
+		price := 100 + 0.5*float64(i)
+		if err := s.Add(price+0.5, price-0.5, price, 1000); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	names, m := s.OutputCorrelations(20)
+	idx := make(map[string]int, len(names))
+	for i, n := range names {
+		idx[n] = i
+	}
+	if _, ok := idx["Close"]; ok {
+		t.Fatal("Close is price, not an indicator output")
+	}
+	for _, pair := range [][2]string{
+		{"BollingerUpper", "BollingerMiddle"},
+		{"BollingerLower", "BollingerMiddle"},
+		{"HMA", "BollingerMiddle"},
+	} {
+		if c := m[idx[pair[0]]][idx[pair[1]]]; math.Abs(c-1) > 1e-9 {
+			t.Fatalf("expected ~1.0 between %s and %s, got %v", pair[0], pair[1], c)
+		}
+	}
+	for i := range m {
+		if m[i][i] != 1 {
+			t.Fatalf("diagonal %d should be 1, got %v", i, m[i][i])
+		}
+		for j := range m {
+			if m[i][j] != m[j][i] {
+				t.Fatalf("matrix not symmetric at %d,%d", i, j)
+			}
+		}
+	}
+}
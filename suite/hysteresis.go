@@ -0,0 +1,85 @@
+package suite
+
+import (
+	"fmt"
+	"strings"
+)
+
+// hysteresisState debounces GetCombinedSignal (see SetSignalHysteresis).
+type hysteresisState struct {
+	side   int    // side of the latest raw signal: +1 bullish, -1 bearish, 0 neutral
+	run    int    // consecutive bars the raw signal has stayed on side
+	stable string // signal reported while a flip is still unconfirmed
+}
+
+// SetSignalHysteresis makes GetCombinedSignal hold its side until a new one
+// is confirmed: a raw signal on the other side of zero is only reported once
+// it has stayed on that side for `bars` consecutive bars, and until then the
+// last stable signal is returned. Leaving Neutral for either side needs the
+// same confirmation, while changes of strength on the same side and moves to
+// Neutral pass through immediately. The first bar fed after the call sets the
+// initial signal. 0 (the default) disables the filter.
+func (suite *ScalpingIndicatorSuite) SetSignalHysteresis(bars int) error {
+	if err := validateSignalHysteresis(bars); err != nil {
+		return err
+	}
+	suite.hysteresisBars = bars
+	suite.hysteresis = hysteresisState{}
+	return nil
+}
+
+// WithSignalHysteresis sets the signal hysteresis at construction; see
+// SetSignalHysteresis.
+func WithSignalHysteresis(bars int) SuiteOption {
+	return func(s *ScalpingIndicatorSuite) { s.hysteresisBars = bars }
+}
+
+func validateSignalHysteresis(bars int) error {
+	if bars < 0 {
+		return fmt.Errorf("hysteresis bars must be non-negative, got %d", bars)
+	}
+	return nil
+}
+
+// SignalHysteresis returns the configured hysteresis in bars.
+func (suite *ScalpingIndicatorSuite) SignalHysteresis() int { return suite.hysteresisBars }
+
+// updateHysteresis feeds the bar's raw combined signal into the filter.
+func (suite *ScalpingIndicatorSuite) updateHysteresis() {
+	if suite.hysteresisBars == 0 {
+		return
+	}
+	raw, err := suite.rawCombinedSignal()
+	if err != nil {
+		return
+	}
+	suite.applyHysteresis(raw)
+}
+
+// applyHysteresis records one bar's raw signal and returns the debounced one.
+func (suite *ScalpingIndicatorSuite) applyHysteresis(raw string) string {
+	h := &suite.hysteresis
+	side := signalSide(raw)
+	if side == h.side {
+		h.run++
+	} else {
+		h.side, h.run = side, 1
+	}
+	stableSide := signalSide(h.stable)
+	if h.stable == "" || side == 0 || side == stableSide || h.run >= suite.hysteresisBars {
+		h.stable = raw
+	}
+	return h.stable
+}
+
+// signalSide maps a combined signal onto +1 (any bullish), -1 (any bearish)
+// or 0 (Neutral).
+func signalSide(signal string) int {
+	switch {
+	case strings.HasSuffix(signal, "Bullish"):
+		return 1
+	case strings.HasSuffix(signal, "Bearish"):
+		return -1
+	}
+	return 0
+}
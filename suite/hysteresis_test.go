@@ -0,0 +1,58 @@
+package suite
+
+import "testing"
+
+func TestSignalHysteresisSuppressesSingleBarFlips(t *testing.T) {
+	s, err := NewScalpingIndicatorSuite()
+	if err != nil {
+		t.Fatalf("constructor failed: %v", err)
+	}
+	if err := s.SetSignalHysteresis(-1); err == nil {
+		t.Fatal("expected an error for negative hysteresis")
+	}
+	if err := s.SetSignalHysteresis(2); err != nil {
+		t.Fatalf("SetSignalHysteresis failed: %v", err)
+	}
+
+	// An established uptrend, then a score alternating sides every bar.
+	raw := []string{"Bullish", "Strong Bullish", "Bearish", "Bullish", "Weak Bearish", "Bullish", "Bearish", "Bearish", "Neutral", "Bullish", "Bullish"}
+	want := []string{"Bullish", "Strong Bullish", "Strong Bullish", "Bullish", "Bullish", "Bullish", "Bullish", "Bearish", "Neutral", "Neutral", "Bullish"}
+	for i, r := range raw {
+		if got := s.applyHysteresis(r); got != want[i] {
+			t.Fatalf("bar %d: raw %q reported as %q, want %q", i, r, got, want[i])
+		}
+	}
+}
+
+func TestSignalHysteresisReducesFlipsOnChoppyBars(t *testing.T) {
+	flips := func(hysteresis int) int {
+		s, _ := NewScalpingIndicatorSuite()
+		if err := s.SetSignalHysteresis(hysteresis); err != nil {
+			t.Fatalf("SetSignalHysteresis failed: %v", err)
+		}
+		n, prev := 0, 0
+		for _, b := range syntheticBars(4, 300) {
+			if err := s.Add(b.High, b.Low, b.Close, b.Volume); err != nil {
+				t.Fatalf("add failed: %v", err)
+			}
+			sig, err := s.GetCombinedSignal()
+			if err != nil {
+				t.Fatalf("GetCombinedSignal failed: %v", err)
+			}
+			if side := signalSide(sig); side != 0 {
+				if prev != 0 && side != prev {
+					n++
+				}
+				prev = side
+			}
+		}
+		return n
+	}
+	raw, damped := flips(0), flips(3)
+	if raw == 0 {
+		t.Fatal("expected the choppy series to flip without hysteresis")
+	}
+	if damped >= raw {
+		t.Fatalf("hysteresis should reduce side flips: %d without, %d with", raw, damped)
+	}
+}
@@ -0,0 +1,168 @@
+package suite
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/evdnx/goti/config"
+	"github.com/evdnx/goti/indicator"
+)
+
+// Timeframe configures one layer of a MultiTimeframeSuite: its bar length in
+// milliseconds (e.g. 5*indicator.MillisPerMinute) and its weight in
+// ConfluenceSignal.
+type Timeframe struct {
+	Interval int64
+	Weight   float64
+}
+
+// signalLevels maps GetCombinedSignal verdicts onto a signed strength.
+var signalLevels = map[string]float64{
+	"Strong Bullish": 3,
+	"Bullish":        2,
+	"Weak Bullish":   1,
+	"Neutral":        0,
+	"Weak Bearish":   -1,
+	"Bearish":        -2,
+	"Strong Bearish": -3,
+}
+
+type mtfFrame struct {
+	Timeframe
+	resampler *indicator.Resampler
+	suite     *ScalpingIndicatorSuite
+	bars      int // completed bars fed to suite
+}
+
+// MultiTimeframeSuite runs one ScalpingIndicatorSuite per timeframe on a
+// single base-interval feed, resampling the base bars for each timeframe, and
+// combines their verdicts into a confluence signal.
+type MultiTimeframeSuite struct {
+	frames []*mtfFrame // ascending interval
+}
+
+// NewMultiTimeframeSuite creates a suite fed with bars of baseInterval
+// milliseconds, stamped in Unix milliseconds, that evaluates every given
+// timeframe; each interval must be a multiple of baseInterval (the base
+// timeframe itself may be included) and each weight positive. Every sub-suite is built from cfg.
+func NewMultiTimeframeSuite(cfg config.IndicatorConfig, baseInterval int64, timeframes ...Timeframe) (*MultiTimeframeSuite, error) {
+	if len(timeframes) == 0 {
+		return nil, errors.New("at least one timeframe is required")
+	}
+	m := &MultiTimeframeSuite{}
+	seen := make(map[int64]bool, len(timeframes))
+	for _, tf := range timeframes {
+		if !(tf.Weight > 0) {
+			return nil, fmt.Errorf("timeframe %dms: weight must be positive", tf.Interval)
+		}
+		if seen[tf.Interval] {
+			return nil, fmt.Errorf("timeframe %dms listed twice", tf.Interval)
+		}
+		seen[tf.Interval] = true
+		r, err := indicator.NewResampler(baseInterval, tf.Interval)
+		if err != nil {
+			return nil, fmt.Errorf("timeframe %dms: %w", tf.Interval, err)
+		}
+		s, err := NewScalpingIndicatorSuiteWithConfig(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("timeframe %dms: %w", tf.Interval, err)
+		}
+		m.frames = append(m.frames, &mtfFrame{Timeframe: tf, resampler: r, suite: s})
+	}
+	sort.Slice(m.frames, func(i, j int) bool { return m.frames[i].Interval < m.frames[j].Interval })
+	return m, nil
+}
+
+// Add feeds a base bar, stamped with the start of its interval, to every
+// timeframe. A sub-suite is updated, through AddOHLCV, with each resampled bar
+// the base bar completes. The first sub-suite error is returned after all
+// timeframes have seen the bar.
+func (m *MultiTimeframeSuite) Add(bar indicator.OHLCV) error {
+	var firstErr error
+	for _, f := range m.frames {
+		for _, out := range f.resampler.Add(bar) {
+			if err := f.suite.AddOHLCV(out.Open, out.High, out.Low, out.Close, out.Volume); err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("timeframe %dms: %w", f.Interval, err)
+				}
+				continue
+			}
+			f.bars++
+		}
+	}
+	return firstErr
+}
+
+// ConfluenceSignal combines the GetCombinedSignal verdict of every timeframe.
+// Verdicts are scored from +3 (Strong Bullish) to −3 (Strong Bearish), averaged
+// with the timeframe weights and mapped back onto the same seven labels, with
+// the boundaries halfway between levels: an average of 2.5 or more is
+// "Strong Bullish", 1.5 "Bullish", 0.5 "Weak Bullish", and so on. Timeframes
+// that disagree therefore pull the verdict towards "Neutral". It errors until
+// every timeframe has completed at least one bar.
+func (m *MultiTimeframeSuite) ConfluenceSignal() (string, error) {
+	var sum, weights float64
+	for _, f := range m.frames {
+		if f.bars == 0 {
+			return "", fmt.Errorf("timeframe %dms has no completed bars", f.Interval)
+		}
+		signal, err := f.suite.GetCombinedSignal()
+		if err != nil {
+			return "", fmt.Errorf("timeframe %dms: %w", f.Interval, err)
+		}
+		sum += signalLevels[signal] * f.Weight
+		weights += f.Weight
+	}
+	switch avg := sum / weights; {
+	case avg >= 2.5:
+		return "Strong Bullish", nil
+	case avg >= 1.5:
+		return "Bullish", nil
+	case avg >= 0.5:
+		return "Weak Bullish", nil
+	case avg <= -2.5:
+		return "Strong Bearish", nil
+	case avg <= -1.5:
+		return "Bearish", nil
+	case avg <= -0.5:
+		return "Weak Bearish", nil
+	default:
+		return "Neutral", nil
+	}
+}
+
+// Suite returns the sub-suite of the given timeframe, or nil if it is not
+// configured.
+func (m *MultiTimeframeSuite) Suite(interval int64) *ScalpingIndicatorSuite {
+	if f := m.frame(interval); f != nil {
+		return f.suite
+	}
+	return nil
+}
+
+// Bars returns how many completed bars the timeframe's sub-suite has received.
+func (m *MultiTimeframeSuite) Bars(interval int64) int {
+	if f := m.frame(interval); f != nil {
+		return f.bars
+	}
+	return 0
+}
+
+// Reset clears every sub-suite and resampler; the timeframes are kept.
+func (m *MultiTimeframeSuite) Reset() {
+	for _, f := range m.frames {
+		f.resampler.Reset()
+		f.suite.Reset()
+		f.bars = 0
+	}
+}
+
+func (m *MultiTimeframeSuite) frame(interval int64) *mtfFrame {
+	for _, f := range m.frames {
+		if f.Interval == interval {
+			return f
+		}
+	}
+	return nil
+}
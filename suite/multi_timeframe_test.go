@@ -0,0 +1,88 @@
+package suite
+
+import (
+	"testing"
+
+	"github.com/evdnx/goti/config"
+	"github.com/evdnx/goti/indicator"
+)
+
+const minute = indicator.MillisPerMinute
+
+func TestMultiTimeframeSuiteResamples(t *testing.T) {
+	m, err := NewMultiTimeframeSuite(config.DefaultConfig(), minute,
+		Timeframe{Interval: 15 * minute, Weight: 3},
+		Timeframe{Interval: minute, Weight: 1},
+		Timeframe{Interval: 5 * minute, Weight: 2},
+	)
+	if err != nil {
+		t.Fatalf("NewMultiTimeframeSuite failed: %v", err)
+	}
+	if _, err := NewMultiTimeframeSuite(config.DefaultConfig(), minute, Timeframe{Interval: 90 * indicator.MillisPerSecond, Weight: 1}); err == nil {
+		t.Fatal("expected error for an interval that is not a multiple of the base")
+	}
+
+	for i, bar := range syntheticBars(2, 60) {
+		bar.Timestamp = int64(i) * minute
+		if err := m.Add(bar); err != nil {
+			t.Fatalf("Add %d failed: %v", i, err)
+		}
+		if got, want := m.Bars(minute), i+1; got != want {
+			t.Fatalf("bar %d: 1m suite has %d bars, want %d", i, got, want)
+		}
+		if got, want := m.Bars(5*minute), (i+1)/5; got != want {
+			t.Fatalf("bar %d: 5m suite has %d bars, want %d", i, got, want)
+		}
+		if snap, err := m.Suite(5 * minute).Snapshot(); err != nil || snap.Bars != (i+1)/5 {
+			t.Fatalf("bar %d: 5m sub-suite saw %d bars (err %v)", i, snap.Bars, err)
+		}
+		if _, err := m.ConfluenceSignal(); (err == nil) != (i >= 14) {
+			t.Fatalf("bar %d: unexpected ConfluenceSignal error state %v", i, err)
+		}
+	}
+	if m.Suite(2*minute) != nil {
+		t.Fatal("expected nil for an unconfigured timeframe")
+	}
+}
+
+func TestMultiTimeframeSuiteConfluence(t *testing.T) {
+	m, _ := NewMultiTimeframeSuite(config.DefaultConfig(), minute,
+		Timeframe{Interval: minute, Weight: 1},
+		Timeframe{Interval: 5 * minute, Weight: 1},
+	)
+	for i := 0; i < 400; i++ {
+		c := 100 + 0.05*float64(i)
+		_ = m.Add(indicator.OHLCV{Timestamp: int64(i) * minute, Open: c - 0.02, High: c + 0.05, Low: c - 0.05, Close: c, Volume: 1000})
+	}
+	got, err := m.ConfluenceSignal()
+	if err != nil {
+		t.Fatalf("ConfluenceSignal failed: %v", err)
+	}
+	fast, _ := m.Suite(minute).GetCombinedSignal()
+	slow, _ := m.Suite(5 * minute).GetCombinedSignal()
+	if signalLevels[fast] > 0 && signalLevels[slow] > 0 && signalLevels[got] <= 0 {
+		t.Fatalf("agreeing bullish timeframes (%s, %s) gave %s", fast, slow, got)
+	}
+	if signalLevels[got] < min(signalLevels[fast], signalLevels[slow]) || signalLevels[got] > max(signalLevels[fast], signalLevels[slow]) {
+		t.Fatalf("confluence %s outside the range of %s and %s", got, fast, slow)
+	}
+}
+
+func TestMultiTimeframeSuiteGapCompletesBothBars(t *testing.T) {
+	m, _ := NewMultiTimeframeSuite(config.DefaultConfig(), minute, Timeframe{Interval: 5 * minute, Weight: 1})
+	for i := 0; i < 4; i++ {
+		_ = m.Add(indicator.OHLCV{Timestamp: int64(i) * minute, Open: 100, High: 101, Low: 99, Close: 100, Volume: 1000})
+	}
+	// Minute 9 closes the unfinished 0–4 bar and is the last minute of its
+	// own bucket, so both 5m bars complete. It opens on its low and closes on
+	// its high, below the previous close.
+	if err := m.Add(indicator.OHLCV{Timestamp: 9 * minute, Open: 90, High: 92, Low: 90, Close: 92, Volume: 1000}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if got := m.Bars(5 * minute); got != 2 {
+		t.Fatalf("expected 2 completed 5m bars, got %d", got)
+	}
+	if body, err := m.Suite(5 * minute).BodyStrength(); err != nil || body != 1 {
+		t.Fatalf("expected the resampled open to reach the sub-suite (body 1), got %v (err %v)", body, err)
+	}
+}
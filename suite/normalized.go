@@ -0,0 +1,77 @@
+package suite
+
+import (
+	"math"
+
+	"github.com/evdnx/goti/indicator"
+)
+
+// GetNormalized maps each indicator's latest reading onto [-1, 1] so the
+// suite's oscillators can be compared side by side or fed to a model as-is.
+// Positive values are bullish, negative values bearish. The transforms are:
+//
+//   - ADMO:      tanh(v); the z-score style ±1 levels land near ±0.76
+//   - VWAO:      v/100 (the oscillator is bounded to ±100)
+//   - MFI:       (v-50)/50
+//   - MACD:      tanh(histogram/scale)
+//   - Bollinger: position of the close between the bands, -1 at the lower
+//     band and +1 at the upper band, clamped
+//   - HMA, VWAP, SAR: tanh((close-line)/scale)
+//
+// scale is the current ATR, or 1% of the close when the ATR is not available
+// (for example when the suite is fed with AddClose). ATR itself has no
+// direction and is not included. Indicators that have not produced a value
+// yet are omitted from the map.
+func (suite *ScalpingIndicatorSuite) GetNormalized() map[string]float64 {
+	out := make(map[string]float64, 8)
+	if !suite.hasClose {
+		return out
+	}
+	close := suite.lastClose
+	scale := suite.normalizationScale()
+	distance := func(line float64) float64 {
+		if scale <= 0 {
+			return 0
+		}
+		return math.Tanh((close - line) / scale)
+	}
+
+	if v, err := suite.admo.Calculate(); err == nil {
+		out["ADMO"] = math.Tanh(v)
+	}
+	if v, err := suite.vwao.Calculate(); err == nil {
+		out["VWAO"] = indicator.Clamp(v/100, -1, 1)
+	}
+	if v, err := suite.mfi.Calculate(); err == nil {
+		out["MFI"] = indicator.Clamp((v-50)/50, -1, 1)
+	}
+	if _, _, hist, err := suite.macd.Calculate(); err == nil && scale > 0 {
+		out["MACD"] = math.Tanh(hist / scale)
+	}
+	if upper, middle, _, err := suite.bollinger.Calculate(); err == nil {
+		pos := 0.0
+		if half := upper - middle; half > 0 {
+			pos = indicator.Clamp((close-middle)/half, -1, 1)
+		}
+		out["Bollinger"] = pos
+	}
+	if v, err := suite.hma.Calculate(); err == nil {
+		out["HMA"] = distance(v)
+	}
+	if v, err := suite.vwap.Calculate(); err == nil {
+		out["VWAP"] = distance(v)
+	}
+	if v, err := suite.sar.Calculate(); err == nil {
+		out["SAR"] = distance(v)
+	}
+	return out
+}
+
+// normalizationScale is the price unit used to squash distances and slopes:
+// the current ATR, or 1% of the last close when the ATR is not available.
+func (suite *ScalpingIndicatorSuite) normalizationScale() float64 {
+	if atr, err := suite.atr.Calculate(); err == nil && atr > 0 {
+		return atr
+	}
+	return suite.lastClose * 0.01
+}
@@ -0,0 +1,43 @@
+package suite
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGetNormalizedStaysInUnitRange(t *testing.T) {
+	s, err := NewScalpingIndicatorSuite()
+	if err != nil {
+		t.Fatalf("constructor failed: %v", err)
+	}
+	if got := s.GetNormalized(); len(got) != 0 {
+		t.Fatalf("expected an empty map before any data, got %v", got)
+	}
+
+	// A calm oscillation followed by a violent breakout pushes the raw
+	// readings far outside their usual ranges.
+	for i := 0; i < 120; i++ {
+		price := 100 + 3*math.Sin(float64(i)/5)
+		if i >= 80 {
+			price += 4 * float64(i-79)
+		}
+		if err := s.Add(price+0.5, price-0.5, price, 1000+float64(i%5)*300); err != nil {
+			t.Fatalf("add failed at %d: %v", i, err)
+		}
+		for name, v := range s.GetNormalized() {
+			if math.IsNaN(v) || v < -1 || v > 1 {
+				t.Fatalf("bar %d: %s normalized to %v", i, name, v)
+			}
+		}
+	}
+
+	got := s.GetNormalized()
+	for _, name := range []string{"ADMO", "VWAO", "MFI", "MACD", "Bollinger", "HMA", "VWAP", "SAR"} {
+		if _, ok := got[name]; !ok {
+			t.Fatalf("missing %s in %v", name, got)
+		}
+	}
+	if got["MACD"] <= 0 || got["VWAP"] <= 0 {
+		t.Fatalf("expected bullish readings after the breakout, got %v", got)
+	}
+}
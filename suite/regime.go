@@ -0,0 +1,109 @@
+package suite
+
+import "errors"
+
+// Regime classifies the prevailing market state for strategy gating.
+type Regime int
+
+const (
+	RegimeRangeBound Regime = iota
+	RegimeTrendingUp
+	RegimeTrendingDown
+	RegimeVolatileChoppy
+)
+
+// String returns the regime name, e.g. "Trending-Up".
+func (r Regime) String() string {
+	switch r {
+	case RegimeTrendingUp:
+		return "Trending-Up"
+	case RegimeTrendingDown:
+		return "Trending-Down"
+	case RegimeVolatileChoppy:
+		return "Volatile-Choppy"
+	default:
+		return "Range-Bound"
+	}
+}
+
+// Regime thresholds, as fractions of the latest close.
+const (
+	// regimeMinBandwidth is the Bollinger width (upper-lower)/close below
+	// which the market is treated as compressed; trends need wider bands.
+	// It matches the chop filter used by the signal engine.
+	regimeMinBandwidth = 0.008
+	// regimeChoppyVolRatio is the ATR/close ratio at or above which a market
+	// without trend agreement counts as volatile rather than range-bound. It
+	// is the boundary of the "normal-high" volatility band in
+	// GetCombinedSignal.
+	regimeChoppyVolRatio = 0.003
+	// regimeChoppyBandwidth classifies a directionless market with bands at
+	// least this wide as volatile even when the ATR is moderate.
+	regimeChoppyBandwidth = 0.03
+	// regimeTrendBars is how many consecutive bars the HMA slope and SAR must
+	// agree before a trend is reported, so a single aligned bar inside a
+	// whipsaw does not count.
+	regimeTrendBars = 3
+)
+
+// MarketRegime classifies the current bar as trending up, trending down,
+// range-bound or volatile-choppy:
+//
+//  1. Trending-Up / Trending-Down when the HMA slope and the Parabolic SAR
+//     have agreed on direction for at least 3 consecutive bars and the
+//     Bollinger width is at least 0.8% of the close.
+//  2. Otherwise Volatile-Choppy when ATR/close is at least 0.3% or the
+//     Bollinger width is at least 3% of the close.
+//  3. Otherwise Range-Bound.
+//
+// It errors until ATR, HMA, SAR and Bollinger Bands have values, so bars fed
+// with AddClose alone never yield a regime.
+func (suite *ScalpingIndicatorSuite) MarketRegime() (Regime, error) {
+	if !suite.hasClose {
+		return RegimeRangeBound, errors.New("insufficient data for regime")
+	}
+	close := suite.lastClose
+	_, errH := suite.hma.Calculate()
+	_, errS := suite.sar.Calculate()
+	upper, _, lower, errB := suite.bollinger.Calculate()
+	_, errA := suite.atr.Calculate()
+	if errH != nil || errS != nil || errB != nil || errA != nil {
+		return RegimeRangeBound, errors.New("insufficient data for regime")
+	}
+	volRatio := suite.currentVolRatio()
+	bandwidth := (upper - lower) / close
+
+	if bandwidth >= regimeMinBandwidth {
+		if suite.trendRun >= regimeTrendBars {
+			return RegimeTrendingUp, nil
+		}
+		if suite.trendRun <= -regimeTrendBars {
+			return RegimeTrendingDown, nil
+		}
+	}
+	if volRatio >= regimeChoppyVolRatio || bandwidth >= regimeChoppyBandwidth {
+		return RegimeVolatileChoppy, nil
+	}
+	return RegimeRangeBound, nil
+}
+
+// updateTrendRun extends or restarts trendRun after each bar.
+func (suite *ScalpingIndicatorSuite) updateTrendRun() {
+	dir := 0
+	if _, err := suite.sar.Calculate(); err == nil {
+		switch hmaDir, _ := suite.hma.GetTrendDirection(); {
+		case hmaDir == "Bullish" && suite.sar.IsUptrend():
+			dir = 1
+		case hmaDir == "Bearish" && !suite.sar.IsUptrend():
+			dir = -1
+		}
+	}
+	switch {
+	case dir > 0:
+		suite.trendRun = max(suite.trendRun, 0) + 1
+	case dir < 0:
+		suite.trendRun = min(suite.trendRun, 0) - 1
+	default:
+		suite.trendRun = 0
+	}
+}
@@ -0,0 +1,65 @@
+package suite
+
+import (
+	"math"
+	"testing"
+)
+
+func regimeAfter(t *testing.T, bars int, bar func(i int) (high, low, close float64)) Regime {
+	t.Helper()
+	s, err := NewScalpingIndicatorSuite()
+	if err != nil {
+		t.Fatalf("constructor failed: %v", err)
+	}
+	if _, err := s.MarketRegime(); err == nil {
+		t.Fatal("expected error before data")
+	}
+	for i := 0; i < bars; i++ {
+		h, l, c := bar(i)
+		if err := s.Add(h, l, c, 1000); err != nil {
+			t.Fatalf("add failed at %d: %v", i, err)
+		}
+	}
+	r, err := s.MarketRegime()
+	if err != nil {
+		t.Fatalf("MarketRegime failed: %v", err)
+	}
+	return r
+}
+
+func TestMarketRegime(t *testing.T) {
+	up := regimeAfter(t, 80, func(i int) (float64, float64, float64) {
+		p := 100 + 0.4*float64(i)
+		return p + 0.2, p - 0.2, p
+	})
+	if up != RegimeTrendingUp {
+		t.Fatalf("clean advance: expected Trending-Up, got %v", up)
+	}
+
+	down := regimeAfter(t, 80, func(i int) (float64, float64, float64) {
+		p := 140 - 0.4*float64(i)
+		return p + 0.2, p - 0.2, p
+	})
+	if down != RegimeTrendingDown {
+		t.Fatalf("clean decline: expected Trending-Down, got %v", down)
+	}
+
+	rng := regimeAfter(t, 80, func(i int) (float64, float64, float64) {
+		p := 100 + 0.1*math.Sin(float64(i))
+		return p + 0.05, p - 0.05, p
+	})
+	if rng != RegimeRangeBound {
+		t.Fatalf("tight range: expected Range-Bound, got %v", rng)
+	}
+
+	choppy := regimeAfter(t, 80, func(i int) (float64, float64, float64) {
+		p := 100 + 3*math.Sin(float64(i)*2.1)
+		return p + 1.5, p - 1.5, p
+	})
+	if choppy != RegimeVolatileChoppy {
+		t.Fatalf("whipsaw: expected Volatile-Choppy, got %v", choppy)
+	}
+	if RegimeTrendingDown.String() != "Trending-Down" {
+		t.Fatal("unexpected Regime name")
+	}
+}
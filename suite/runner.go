@@ -0,0 +1,76 @@
+package suite
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/evdnx/goti/config"
+	"github.com/evdnx/goti/indicator"
+)
+
+// RunMultiSymbol builds an independent ScalpingIndicatorSuite per symbol,
+// feeds it that symbol's bars and returns the final snapshot for each one.
+// Symbols are processed concurrently by at most GOMAXPROCS workers; no
+// indicator state is shared between them. The first failure (in symbol
+// order) is returned together with the snapshots that did complete.
+func RunMultiSymbol(cfg config.IndicatorConfig, data map[string][]indicator.OHLCV) (map[string]SuiteSnapshot, error) {
+	symbols := make([]string, 0, len(data))
+	for symbol := range data {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(symbols) {
+		workers = len(symbols)
+	}
+
+	snapshots := make([]SuiteSnapshot, len(symbols))
+	errs := make([]error, len(symbols))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				snapshots[i], errs[i] = runSymbol(cfg, data[symbols[i]])
+			}
+		}()
+	}
+	for i := range symbols {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	result := make(map[string]SuiteSnapshot, len(symbols))
+	var firstErr error
+	for i, symbol := range symbols {
+		if errs[i] != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("%s: %w", symbol, errs[i])
+			}
+			continue
+		}
+		result[symbol] = snapshots[i]
+	}
+	return result, firstErr
+}
+
+// runSymbol feeds one symbol's bars through a fresh suite.
+func runSymbol(cfg config.IndicatorConfig, bars []indicator.OHLCV) (SuiteSnapshot, error) {
+	s, err := NewScalpingIndicatorSuiteWithConfig(cfg)
+	if err != nil {
+		return SuiteSnapshot{}, err
+	}
+	for i, bar := range bars {
+		if err := s.Add(bar.High, bar.Low, bar.Close, bar.Volume); err != nil {
+			return SuiteSnapshot{}, fmt.Errorf("bar %d: %w", i, err)
+		}
+	}
+	return s.Snapshot()
+}
@@ -0,0 +1,75 @@
+package suite
+
+import (
+	"testing"
+
+	"github.com/evdnx/goti/config"
+	"github.com/evdnx/goti/indicator"
+)
+
+func syntheticBars(seed, n int) []indicator.OHLCV {
+	bars := make([]indicator.OHLCV, n)
+	for i := range bars {
+		base := 100.0 + float64(seed) + float64((i*(seed+3))%17)*0.4
+		bars[i] = indicator.OHLCV{
+			Timestamp: int64(i) * 60_000,
+			Open:      base,
+			High:      base + 1,
+			Low:       base - 1,
+			Close:     base + 0.3,
+			Volume:    1000 + float64((i*seed)%11)*25,
+		}
+	}
+	return bars
+}
+
+func TestRunMultiSymbolMatchesSequential(t *testing.T) {
+	cfg := config.DefaultConfig()
+	data := map[string][]indicator.OHLCV{
+		"AAA": syntheticBars(1, 60),
+		"BBB": syntheticBars(2, 80),
+		"CCC": syntheticBars(3, 45),
+		"DDD": syntheticBars(4, 70),
+	}
+
+	got, err := RunMultiSymbol(cfg, data)
+	if err != nil {
+		t.Fatalf("RunMultiSymbol failed: %v", err)
+	}
+	if len(got) != len(data) {
+		t.Fatalf("expected %d snapshots, got %d", len(data), len(got))
+	}
+
+	for symbol, bars := range data {
+		s, err := NewScalpingIndicatorSuiteWithConfig(cfg)
+		if err != nil {
+			t.Fatalf("constructor failed: %v", err)
+		}
+		for _, b := range bars {
+			if err := s.Add(b.High, b.Low, b.Close, b.Volume); err != nil {
+				t.Fatalf("%s: Add failed: %v", symbol, err)
+			}
+		}
+		want, err := s.Snapshot()
+		if err != nil {
+			t.Fatalf("%s: Snapshot failed: %v", symbol, err)
+		}
+		if got[symbol] != want {
+			t.Fatalf("%s: parallel %+v != sequential %+v", symbol, got[symbol], want)
+		}
+	}
+}
+
+func TestRunMultiSymbolReportsBadBars(t *testing.T) {
+	data := map[string][]indicator.OHLCV{
+		"GOOD": syntheticBars(1, 30),
+		"BAD":  {{High: 9, Low: 10, Close: 9.5, Volume: 100}},
+	}
+	got, err := RunMultiSymbol(config.DefaultConfig(), data)
+	if err == nil {
+		t.Fatal("expected error for invalid bar")
+	}
+	if _, ok := got["GOOD"]; !ok {
+		t.Fatal("valid symbol should still produce a snapshot")
+	}
+}
@@ -0,0 +1,39 @@
+package suite
+
+import "github.com/evdnx/goti/indicator"
+
+// maxScoreHistory bounds the retained net-score series.
+const maxScoreHistory = 512
+
+// GetScoreSeries returns the net score (bull - bear) recorded on every bar
+// since all indicators warmed up, oldest first. Positive values lean bullish.
+// At most the latest 512 values are kept; the slice is a copy.
+func (suite *ScalpingIndicatorSuite) GetScoreSeries() []float64 {
+	return append([]float64(nil), suite.scores...)
+}
+
+// GetScorePlotData returns the net-score series as a bar pane, suitable for
+// overlaying the combined signal strength under the price chart.
+func (suite *ScalpingIndicatorSuite) GetScorePlotData(startTime, interval int64) []indicator.PlotData {
+	if len(suite.scores) == 0 {
+		return nil
+	}
+	x := make([]float64, len(suite.scores))
+	for i := range x {
+		x[i] = float64(i)
+	}
+	return []indicator.PlotData{{
+		Name:      "Net Score",
+		X:         x,
+		Y:         suite.GetScoreSeries(),
+		Type:      "bar",
+		Timestamp: indicator.GenerateTimestamps(startTime, len(suite.scores), interval),
+	}}
+}
+
+// recordScore appends the current net score to the history.
+func (suite *ScalpingIndicatorSuite) recordScore() {
+	bull, bear := suite.computeScores()
+	suite.scores = append(suite.scores, bull-bear)
+	suite.scores = indicator.KeepLast(suite.scores, maxScoreHistory)
+}
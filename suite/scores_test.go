@@ -0,0 +1,125 @@
+package suite
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestScoreSeriesCrossesZeroAtReversal(t *testing.T) {
+	s, err := NewScalpingIndicatorSuite()
+	if err != nil {
+		t.Fatalf("constructor failed: %v", err)
+	}
+	const upBars, downBars = 40, 40
+	price := 100.0
+	for i := 0; i < upBars+downBars; i++ {
+		if i < upBars {
+			price += 0.6
+		} else {
+			price -= 0.6
+		}
+		if err := s.Add(price+0.4, price-0.4, price, 1000); err != nil {
+			t.Fatalf("add failed at %d: %v", i, err)
+		}
+	}
+
+	scores := s.GetScoreSeries()
+	firstWarm := upBars + downBars - len(scores)
+	if len(scores) == 0 || firstWarm >= upBars {
+		t.Fatalf("expected scores well before the reversal, first warm bar %d", firstWarm)
+	}
+
+	// The score flips sign for good once the decline is under way: find the
+	// first run of five consecutive negative scores.
+	flip := -1
+	for i := 0; i+5 <= len(scores); i++ {
+		run := true
+		for _, v := range scores[i : i+5] {
+			if v >= 0 {
+				run = false
+				break
+			}
+		}
+		if run {
+			flip = firstWarm + i
+			break
+		}
+	}
+	if flip < upBars-3 || flip > upBars+3 {
+		t.Fatalf("expected the score to turn negative around bar %d, got %d", upBars, flip)
+	}
+	mean := func(vals []float64) float64 {
+		sum := 0.0
+		for _, v := range vals {
+			sum += v
+		}
+		return sum / float64(len(vals))
+	}
+	rally, decline := scores[:upBars-firstWarm], scores[upBars-firstWarm:]
+	if mean(rally) <= 0 || mean(decline) >= 0 {
+		t.Fatalf("expected a bullish rally and bearish decline, got means %.2f / %.2f", mean(rally), mean(decline))
+	}
+
+	plot := s.GetScorePlotData(0, 60)
+	if len(plot) != 1 || len(plot[0].Y) != len(scores) || plot[0].Type != "bar" {
+		t.Fatalf("unexpected score plot data: %+v", plot)
+	}
+	s.Reset()
+	if len(s.GetScoreSeries()) != 0 {
+		t.Fatal("Reset should clear the score series")
+	}
+}
+
+func TestAddAllocationsDoNotGrowWithHistory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("allocation guard")
+	}
+	s, err := NewScalpingIndicatorSuite()
+	if err != nil {
+		t.Fatalf("constructor failed: %v", err)
+	}
+	add := func(i int) {
+		c := 98 + float64(i%10)*0.1
+		if err := s.Add(c+2, c-3, c, 1000+float64(i%10)*10); err != nil {
+			t.Fatalf("add failed at %d: %v", i, err)
+		}
+	}
+	// Long enough that copying any unbounded indicator history on each bar
+	// (as the score series once did) would cost tens of kilobytes.
+	const warm, measured = 5000, 200
+	for i := 0; i < warm; i++ {
+		add(i)
+	}
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	for i := warm; i < warm+measured; i++ {
+		add(i)
+	}
+	runtime.ReadMemStats(&after)
+	if perBar := (after.TotalAlloc - before.TotalAlloc) / measured; perBar > 8<<10 {
+		t.Fatalf("Add allocated %d bytes per bar after %d bars, want at most 8 KiB", perBar, warm)
+	}
+}
+
+// BenchmarkScalpingIndicatorSuite_AddLongHistory measures Add once the
+// indicators hold a long history, where per-bar copies would dominate.
+func BenchmarkScalpingIndicatorSuite_AddLongHistory(b *testing.B) {
+	s, err := NewScalpingIndicatorSuite()
+	if err != nil {
+		b.Fatalf("constructor failed: %v", err)
+	}
+	for i := 0; i < 5000; i++ {
+		c := 98 + float64(i%10)*0.1
+		if err := s.Add(c+2, c-3, c, 1000); err != nil {
+			b.Fatalf("add failed: %v", err)
+		}
+	}
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		c := 98 + float64(i%10)*0.1
+		if err := s.Add(c+2, c-3, c, 1000+float64(i%10)*10); err != nil {
+			b.Fatalf("Add failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package suite
+
+import (
+	"encoding/json"
+	"math"
+)
+
+// SuiteSnapshot captures the suite's state after the most recent bar.
+type SuiteSnapshot struct {
+	Bars      int     `json:"bars"`
+	Close     float64 `json:"close"`
+	Signal    string  `json:"signal"`
+	BullScore float64 `json:"bullScore"`
+	BearScore float64 `json:"bearScore"`
+}
+
+// Snapshot summarises the current combined signal and normalised scores.
+func (suite *ScalpingIndicatorSuite) Snapshot() (SuiteSnapshot, error) {
+	signal, err := suite.GetCombinedSignal()
+	if err != nil {
+		return SuiteSnapshot{}, err
+	}
+	bull, err := suite.GetBullScore()
+	if err != nil {
+		return SuiteSnapshot{}, err
+	}
+	bear, err := suite.GetBearScore()
+	if err != nil {
+		return SuiteSnapshot{}, err
+	}
+	return SuiteSnapshot{
+		Bars:      suite.closeCount,
+		Close:     suite.lastClose,
+		Signal:    signal,
+		BullScore: bull,
+		BearScore: bear,
+	}, nil
+}
+
+// SuiteState is the "current state" view of the suite for a dashboard API,
+// as serialised by SnapshotJSON. Every field is always present, so clients
+// can rely on the schema; values not available yet are zero or empty.
+type SuiteState struct {
+	Bars  int     `json:"bars"`
+	Close float64 `json:"close"`
+	// Indicators holds the latest reading of every warmed-up indicator,
+	// keyed like the FeatureMatrix columns.
+	Indicators map[string]float64 `json:"indicators"`
+	Signal     string             `json:"signal"`
+	BullScore  float64            `json:"bullScore"`
+	BearScore  float64            `json:"bearScore"`
+	// Confidence is |bullScore − bearScore|: 0 when the two sides balance, 1
+	// when one side scores fully and the other not at all.
+	Confidence float64 `json:"confidence"`
+	// Regime is the MarketRegime name, or "" until it can be classified.
+	Regime string `json:"regime"`
+	// VolatilityRatio is ATR/close, the measure the signal engine gates on.
+	VolatilityRatio float64 `json:"volatilityRatio"`
+	// Divergences maps indicator name to direction for every active
+	// divergence, exactly as GetDivergenceSignals reports them.
+	Divergences map[string]string `json:"divergences"`
+}
+
+// SnapshotJSON returns the suite's current state as compact JSON in the
+// SuiteState schema. Unlike GetPlotData it carries no history, only the
+// latest values. It fails like Snapshot when no signal can be computed yet.
+func (suite *ScalpingIndicatorSuite) SnapshotJSON() ([]byte, error) {
+	snap, err := suite.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	divergences, err := suite.GetDivergenceSignals()
+	if err != nil {
+		return nil, err
+	}
+	state := SuiteState{
+		Bars:            snap.Bars,
+		Close:           snap.Close,
+		Indicators:      suite.readings(),
+		Signal:          snap.Signal,
+		BullScore:       snap.BullScore,
+		BearScore:       snap.BearScore,
+		Confidence:      math.Abs(snap.BullScore - snap.BearScore),
+		VolatilityRatio: suite.currentVolRatio(),
+		Divergences:     divergences,
+	}
+	if regime, err := suite.MarketRegime(); err == nil {
+		state.Regime = regime.String()
+	}
+	return json.Marshal(state)
+}
@@ -0,0 +1,48 @@
+package suite
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSnapshotJSONSchema(t *testing.T) {
+	s, err := NewScalpingIndicatorSuite()
+	if err != nil {
+		t.Fatalf("constructor failed: %v", err)
+	}
+	for _, b := range syntheticBars(7, 120) {
+		if err := s.Add(b.High, b.Low, b.Close, b.Volume); err != nil {
+			t.Fatalf("add failed: %v", err)
+		}
+	}
+
+	data, err := s.SnapshotJSON()
+	if err != nil {
+		t.Fatalf("SnapshotJSON failed: %v", err)
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("invalid JSON %s: %v", data, err)
+	}
+	for _, key := range []string{"bars", "close", "indicators", "signal", "bullScore", "bearScore", "confidence", "regime", "volatilityRatio", "divergences"} {
+		if _, ok := raw[key]; !ok {
+			t.Fatalf("missing key %q in %s", key, data)
+		}
+	}
+
+	var state SuiteState
+	if err := json.Unmarshal(data, &state); err != nil {
+		t.Fatalf("unmarshal into SuiteState failed: %v", err)
+	}
+	if state.Bars != 120 || state.Signal == "" || state.Regime == "" {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+	for _, name := range []string{"MACD", "ATR", "MFI", "VWAP"} {
+		if _, ok := state.Indicators[name]; !ok {
+			t.Fatalf("indicator %s missing after warm-up: %v", name, state.Indicators)
+		}
+	}
+	if state.Divergences == nil {
+		t.Fatal("divergences must be an object, not null")
+	}
+}
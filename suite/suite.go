@@ -2,11 +2,19 @@ package suite
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/evdnx/goti/config"
 	"github.com/evdnx/goti/indicator"
 )
 
+// Default momentum confirmation: two consecutive closes in the direction of
+// the net score add 0.15 to it (see SetMomentumConfirmation).
+const (
+	defaultMomentumBars  = 2
+	defaultMomentumBoost = 0.15
+)
+
 // ---------------------------------------------------------------------
 // ScalpingIndicatorSuite – fast, low-lag bundle tuned for intraday use.
 // Optimized for 1–5 minute scalping with responsive periods and adaptive
@@ -23,6 +31,7 @@ type ScalpingIndicatorSuite struct {
 	vwap      *indicator.VWAP
 	mfi       *indicator.MoneyFlowIndex
 
+	lastOpen   float64 // real open from AddOHLCV, otherwise the prior close
 	lastClose  float64
 	prevClose  float64
 	prev2Close float64 // second-to-last close for momentum confirmation
@@ -30,6 +39,24 @@ type ScalpingIndicatorSuite struct {
 	lastLow    float64
 	hasClose   bool
 	closeCount int // track number of closes for momentum lookback
+	closeRun   int // consecutive up (>0) or down (<0) closes ending at lastClose
+	trendRun   int // consecutive bars HMA slope and SAR agreed up (>0) or down (<0)
+
+	// Momentum confirmation (see SetMomentumConfirmation)
+	momentumBars  int
+	momentumBoost float64
+
+	autoCorrect bool // repair dirty candles in Add instead of rejecting them
+	corrections int
+
+	// Crossover/zone event history (see RecentEvents)
+	events     []indicator.SignalEvent
+	eventState eventState
+
+	// Aligned per-bar indicator readings (see FeatureMatrix)
+	features [][]float64
+	// Net bull-bear score per warm bar (see GetScoreSeries)
+	scores []float64
 
 	// Cached values for performance
 	cachedVolRatio    float64
@@ -37,6 +64,13 @@ type ScalpingIndicatorSuite struct {
 	cachedScoresValid bool
 	cachedBullScore   float64
 	cachedBearScore   float64
+	// per-indicator contributions behind the cached scores
+	cachedBullBreakdown map[string]float64
+	cachedBearBreakdown map[string]float64
+
+	// Combined-signal debounce (see SetSignalHysteresis)
+	hysteresisBars int
+	hysteresis     hysteresisState
 }
 
 // NewScalpingIndicatorSuite creates a suite with scalping-optimised defaults.
@@ -52,7 +86,8 @@ func NewOptimizedScalpingIndicatorSuite() (*OptimizedScalpingIndicatorSuite, err
 }
 
 // NewScalpingIndicatorSuiteWithConfig builds a suite using a custom config and
-// short, responsive periods suitable for 1–5 minute charts.
+// short, responsive periods suitable for 1–5 minute charts. Options are
+// applied last and their values validated like the matching setters.
 //
 // Period rationale for scalping:
 //   - ADMO(8,5,0.3): Adaptive momentum oscillator that adjusts to volatility
@@ -63,7 +98,7 @@ func NewOptimizedScalpingIndicatorSuite() (*OptimizedScalpingIndicatorSuite, err
 //   - Bollinger(12,2.0): Shorter lookback for volatility squeeze detection
 //   - ATR(5): Very responsive volatility measure
 //   - MFI(5): Quick volume-backed momentum
-func NewScalpingIndicatorSuiteWithConfig(cfg config.IndicatorConfig) (*ScalpingIndicatorSuite, error) {
+func NewScalpingIndicatorSuiteWithConfig(cfg config.IndicatorConfig, opts ...SuiteOption) (*ScalpingIndicatorSuite, error) {
 	// Tighten thresholds for faster reversals (asymmetric for mean-reversion).
 	cfg.MFIOverbought = 72
 	cfg.MFIOversold = 28
@@ -127,7 +162,7 @@ func NewScalpingIndicatorSuiteWithConfig(cfg config.IndicatorConfig) (*ScalpingI
 		return nil, fmt.Errorf("failed to create MFI: %w", err)
 	}
 
-	return &ScalpingIndicatorSuite{
+	suite := &ScalpingIndicatorSuite{
 		admo:      admo,
 		vwao:      vwao,
 		macd:      macd,
@@ -137,11 +172,86 @@ func NewScalpingIndicatorSuiteWithConfig(cfg config.IndicatorConfig) (*ScalpingI
 		atr:       atr,
 		vwap:      vwap,
 		mfi:       mfi,
-	}, nil
+
+		momentumBars:  defaultMomentumBars,
+		momentumBoost: defaultMomentumBoost,
+	}
+	for _, opt := range opts {
+		opt(suite)
+	}
+	if err := validateMomentumConfirmation(suite.momentumBars, suite.momentumBoost); err != nil {
+		return nil, err
+	}
+	if err := validateSignalHysteresis(suite.hysteresisBars); err != nil {
+		return nil, err
+	}
+	return suite, nil
+}
+
+// SuiteOption configures a ScalpingIndicatorSuite.
+type SuiteOption func(*ScalpingIndicatorSuite)
+
+// WithAutoCorrect makes Add repair dirty candles (swapping an inverted
+// high/low and clamping the close into range) before forwarding them, rather
+// than rejecting the bar.
+func WithAutoCorrect(enabled bool) SuiteOption {
+	return func(s *ScalpingIndicatorSuite) { s.autoCorrect = enabled }
+}
+
+// WithMomentumConfirmation sets the momentum confirmation at construction;
+// see SetMomentumConfirmation.
+func WithMomentumConfirmation(bars int, boost float64) SuiteOption {
+	return func(s *ScalpingIndicatorSuite) {
+		s.momentumBars = bars
+		s.momentumBoost = boost
+	}
+}
+
+// SetMomentumConfirmation configures the boost GetCombinedSignal applies when
+// price agrees with the net score: after `bars` consecutive higher closes a
+// bullish score gains `boost`, and after `bars` consecutive lower closes a
+// bearish score loses it. The defaults are 2 closes and 0.15; a boost of 0
+// disables the confirmation.
+func (suite *ScalpingIndicatorSuite) SetMomentumConfirmation(bars int, boost float64) error {
+	if err := validateMomentumConfirmation(bars, boost); err != nil {
+		return err
+	}
+	suite.momentumBars = bars
+	suite.momentumBoost = boost
+	return nil
 }
 
-// Add forwards the OHLCV sample to every indicator in the suite.
+func validateMomentumConfirmation(bars int, boost float64) error {
+	if bars < 1 {
+		return fmt.Errorf("momentum bars must be at least 1, got %d", bars)
+	}
+	if boost < 0 || math.IsNaN(boost) || math.IsInf(boost, 0) {
+		return fmt.Errorf("momentum boost must be a finite non-negative number, got %v", boost)
+	}
+	return nil
+}
+
+// CorrectionCount returns how many bars WithAutoCorrect has repaired.
+func (suite *ScalpingIndicatorSuite) CorrectionCount() int { return suite.corrections }
+
+// Add forwards the OHLCV sample to every indicator in the suite. The bar's
+// open is taken to be the previous close (the close itself on the first bar);
+// use AddOHLCV when the real open is known.
 func (suite *ScalpingIndicatorSuite) Add(high, low, close, volume float64) error {
+	open := close
+	if suite.hasClose {
+		open = suite.lastClose
+	}
+	return suite.addBar(open, high, low, close, volume)
+}
+
+func (suite *ScalpingIndicatorSuite) addBar(open, high, low, close, volume float64) error {
+	if suite.autoCorrect {
+		var corrected bool
+		if high, low, close, corrected = indicator.CorrectCandle(high, low, close); corrected {
+			suite.corrections++
+		}
+	}
 	if high < low {
 		return fmt.Errorf("invalid price: high (%v) must be >= low (%v)", high, low)
 	}
@@ -183,21 +293,105 @@ func (suite *ScalpingIndicatorSuite) Add(high, low, close, volume float64) error
 		return fmt.Errorf("MFI add failed: %w", err)
 	}
 
+	suite.lastOpen = open
+	suite.finishBar(high, low, close)
+	return nil
+}
+
+// AddClose feeds a close-only bar. High and low are taken to be the close, and
+// the bar is routed only to the indicators that remain meaningful without a
+// range or volume: ADMO, MACD, HMA and Bollinger Bands.
+//
+// Signals degrade accordingly. Parabolic SAR and ATR receive nothing, so the
+// SAR vote disappears and the ATR/price ratio reads 0, which the scoring treats
+// as a very-low-volatility chop regime: thresholds tighten and trend-following
+// votes are damped. VWAO, VWAP and MFI are also skipped because they cannot
+// weight bars without volume. Mixing AddClose with Add leaves the skipped
+// indicators with gaps in their history.
+func (suite *ScalpingIndicatorSuite) AddClose(close float64) error {
+	return suite.addPartial(close, 0, false)
+}
+
+// AddCloseVolume feeds a bar with only close and volume. In addition to the
+// AddClose set, the volume-weighted indicators (VWAO, VWAP, MFI) are updated
+// using the close as the bar's typical price. VWAO's Aroon windows and MFI's
+// money flow therefore track closes rather than intrabar extremes. Parabolic
+// SAR and ATR are still skipped.
+func (suite *ScalpingIndicatorSuite) AddCloseVolume(close, volume float64) error {
+	return suite.addPartial(close, volume, true)
+}
+
+func (suite *ScalpingIndicatorSuite) addPartial(close, volume float64, hasVolume bool) error {
+	if !indicator.IsValidPrice(close) {
+		return fmt.Errorf("invalid price")
+	}
+	if hasVolume && !indicator.IsValidVolume(volume) {
+		return fmt.Errorf("invalid volume")
+	}
+
+	if err := suite.admo.Add(close, close, close); err != nil {
+		return fmt.Errorf("ADMO add failed: %w", err)
+	}
+	if err := suite.macd.Add(close); err != nil {
+		return fmt.Errorf("MACD add failed: %w", err)
+	}
+	if err := suite.hma.Add(close); err != nil {
+		return fmt.Errorf("HMA add failed: %w", err)
+	}
+	if err := suite.bollinger.Add(close); err != nil {
+		return fmt.Errorf("Bollinger add failed: %w", err)
+	}
+	if hasVolume {
+		if err := suite.vwao.Add(close, close, close, volume); err != nil {
+			return fmt.Errorf("VWAO add failed: %w", err)
+		}
+		if err := suite.vwap.Add(close, close, close, volume); err != nil {
+			return fmt.Errorf("VWAP add failed: %w", err)
+		}
+		if err := suite.mfi.Add(close, close, close, volume); err != nil {
+			return fmt.Errorf("MFI add failed: %w", err)
+		}
+	}
+
+	suite.lastOpen = close
+	if suite.hasClose {
+		suite.lastOpen = suite.lastClose
+	}
+	suite.finishBar(close, close, close)
+	return nil
+}
+
+// finishBar updates the price context and caches after a bar was routed to
+// the sub-indicators.
+func (suite *ScalpingIndicatorSuite) finishBar(high, low, close float64) {
 	if suite.hasClose {
 		suite.prev2Close = suite.prevClose
 		suite.prevClose = suite.lastClose
+		switch {
+		case close > suite.lastClose:
+			suite.closeRun = max(suite.closeRun, 0) + 1
+		case close < suite.lastClose:
+			suite.closeRun = min(suite.closeRun, 0) - 1
+		default:
+			suite.closeRun = 0
+		}
 	}
 	suite.lastClose = close
 	suite.lastHigh = high
 	suite.lastLow = low
 	suite.hasClose = true
 	suite.closeCount++
+	suite.updateTrendRun()
 
 	// Invalidate cached values when new data is added
 	suite.volRatioValid = false
 	suite.cachedScoresValid = false
 
-	return nil
+	suite.recordEvents()
+	if suite.recordFeatures() {
+		suite.recordScore()
+	}
+	suite.updateHysteresis()
 }
 
 // GetCombinedSignal returns the aggregated scalping bias.
@@ -205,7 +399,18 @@ func (suite *ScalpingIndicatorSuite) Add(high, low, close, volume float64) error
 //   - Volatility regime (ATR/price ratio)
 //   - Momentum confirmation (consecutive close direction)
 //   - Signal confluence (number of agreeing indicators)
+//
+// With SetSignalHysteresis enabled, a flip to the other side is held back
+// until it has persisted for the configured number of bars.
 func (suite *ScalpingIndicatorSuite) GetCombinedSignal() (string, error) {
+	if suite.hysteresisBars > 0 && suite.hysteresis.stable != "" {
+		return suite.hysteresis.stable, nil
+	}
+	return suite.rawCombinedSignal()
+}
+
+// rawCombinedSignal classifies the current net score without hysteresis.
+func (suite *ScalpingIndicatorSuite) rawCombinedSignal() (string, error) {
 	bull, bear := suite.computeScores()
 	net := bull - bear
 
@@ -244,19 +449,12 @@ func (suite *ScalpingIndicatorSuite) GetCombinedSignal() (string, error) {
 	}
 
 	// Momentum confirmation boost: if price has moved in the same direction
-	// for 2+ bars, boost the corresponding signal slightly
-	if suite.closeCount >= 3 {
-		if suite.lastClose > suite.prevClose && suite.prevClose > suite.prev2Close {
-			// Two consecutive up closes: momentum confirmation for bulls
-			if net > 0 {
-				net += 0.15
-			}
-		} else if suite.lastClose < suite.prevClose && suite.prevClose < suite.prev2Close {
-			// Two consecutive down closes: momentum confirmation for bears
-			if net < 0 {
-				net -= 0.15
-			}
-		}
+	// for momentumBars consecutive closes (2 by default), boost the
+	// corresponding signal slightly
+	if suite.closeRun >= suite.momentumBars && net > 0 {
+		net += suite.momentumBoost
+	} else if suite.closeRun <= -suite.momentumBars && net < 0 {
+		net -= suite.momentumBoost
 	}
 
 	switch {
@@ -700,6 +898,31 @@ func (suite *ScalpingIndicatorSuite) GetBearScore() (float64, error) {
 	return normalized, nil
 }
 
+// GetSignalBreakdown returns the weight each indicator contributed to the raw
+// bull and bear scores behind GetCombinedSignal, keyed by indicator name
+// ("ADMO", "VWAO", "MACD", "HMA", "SAR", "Bollinger", "ATR", "VWAP", "MFI",
+// "Price"). Indicators that contributed nothing are omitted.
+func (suite *ScalpingIndicatorSuite) GetSignalBreakdown() (map[string]float64, map[string]float64, error) {
+	if !suite.hasClose {
+		return nil, nil, fmt.Errorf("no data in suite")
+	}
+	suite.computeScores()
+	if suite.cachedBullBreakdown == nil {
+		suite.cachedBullBreakdown = map[string]float64{}
+		suite.cachedBearBreakdown = map[string]float64{}
+		suite.scoreBar(suite.cachedBullBreakdown, suite.cachedBearBreakdown)
+	}
+	bullish := make(map[string]float64, len(suite.cachedBullBreakdown))
+	for k, v := range suite.cachedBullBreakdown {
+		bullish[k] = v
+	}
+	bearish := make(map[string]float64, len(suite.cachedBearBreakdown))
+	for k, v := range suite.cachedBearBreakdown {
+		bearish[k] = v
+	}
+	return bullish, bearish, nil
+}
+
 // GetDivergenceSignals checks for divergence signals across momentum/volume.
 func (suite *ScalpingIndicatorSuite) GetDivergenceSignals() (map[string]string, error) {
 	result := make(map[string]string)
@@ -718,6 +941,22 @@ func (suite *ScalpingIndicatorSuite) GetDivergenceSignals() (map[string]string,
 	return result, nil
 }
 
+// HMAvsVWAPCross reports whether the HMA crossed the VWAP on the most recent
+// bar: +1 for a cross above, -1 for a cross below, 0 otherwise. Use
+// indicator.CrossSeries directly to locate older crossings.
+func (suite *ScalpingIndicatorSuite) HMAvsVWAPCross() (int, error) {
+	hma := suite.hma.GetHMAValues()
+	vwap := suite.vwap.GetValues()
+	if len(hma) < 2 || len(vwap) < 2 {
+		return 0, fmt.Errorf("insufficient HMA/VWAP data")
+	}
+	dir, idx := indicator.CrossSeries(hma, vwap)
+	if idx != min(len(hma), len(vwap))-1 {
+		return 0, nil
+	}
+	return dir, nil
+}
+
 // Reset clears all indicator data and cached price context.
 func (suite *ScalpingIndicatorSuite) Reset() {
 	suite.admo.Reset()
@@ -730,6 +969,7 @@ func (suite *ScalpingIndicatorSuite) Reset() {
 	suite.vwap.Reset()
 	suite.mfi.Reset()
 
+	suite.lastOpen = 0
 	suite.lastClose = 0
 	suite.prevClose = 0
 	suite.prev2Close = 0
@@ -737,6 +977,14 @@ func (suite *ScalpingIndicatorSuite) Reset() {
 	suite.lastLow = 0
 	suite.hasClose = false
 	suite.closeCount = 0
+	suite.closeRun = 0
+	suite.trendRun = 0
+	suite.corrections = 0
+	suite.events = suite.events[:0]
+	suite.eventState = eventState{}
+	suite.features = suite.features[:0]
+	suite.scores = suite.scores[:0]
+	suite.hysteresis = hysteresisState{}
 
 	// Clear cached values
 	suite.cachedVolRatio = 0
@@ -825,29 +1073,55 @@ func (suite *ScalpingIndicatorSuite) GetPlotData(startTime, interval int64) []in
 //   - Crossover signals (high weight: first to signal reversals)
 //   - Extreme zone readings (medium weight: mean reversion setups)
 //   - Trend confirmation (lower weight: filters false signals)
+//
+// It runs on every warm bar (see recordScore), so it reads only the latest
+// indicator values and leaves the per-indicator breakdown to
+// GetSignalBreakdown.
 func (suite *ScalpingIndicatorSuite) computeScores() (float64, float64) {
 	if suite.cachedScoresValid {
 		return suite.cachedBullScore, suite.cachedBearScore
 	}
+	bull, bear := suite.scoreBar(nil, nil)
 
-	var bull, bear float64
+	// Cache the computed scores
+	suite.cachedBullScore = bull
+	suite.cachedBearScore = bear
+	suite.cachedBullBreakdown = nil
+	suite.cachedBearBreakdown = nil
+	suite.cachedScoresValid = true
+
+	return bull, bear
+}
+
+// scoreBar evaluates the scoring rules for the current bar. When bullBy and
+// bearBy are non-nil each contribution is also added under its indicator.
+func (suite *ScalpingIndicatorSuite) scoreBar(bullBy, bearBy map[string]float64) (bull, bear float64) {
+	addBull := func(source string, w float64) {
+		bull += w
+		if bullBy != nil {
+			bullBy[source] += w
+		}
+	}
+	addBear := func(source string, w float64) {
+		bear += w
+		if bearBy != nil {
+			bearBy[source] += w
+		}
+	}
 
 	// ---- Regime detection for profit/risk tilt ----
 	volRatio := suite.currentVolRatio()
 	bandwidthPct := 0.0
-	if suite.hasClose {
-		upper := suite.bollinger.GetUpper()
-		lower := suite.bollinger.GetLower()
-		if len(upper) > 0 && len(lower) > 0 && suite.lastClose > 0 {
-			bandwidthPct = (upper[len(upper)-1] - lower[len(lower)-1]) / suite.lastClose
+	if suite.hasClose && suite.lastClose > 0 {
+		if upper, _, lower, err := suite.bollinger.Calculate(); err == nil {
+			bandwidthPct = (upper - lower) / suite.lastClose
 		}
 	}
 	isChop := volRatio < 0.0012 && bandwidthPct < 0.008 // tight range + low vol → avoid trend chasing
 
 	trendBias := 0.0
 	strongTrend := false
-	if vals := suite.vwao.GetVWAOValues(); len(vals) > 0 {
-		last := vals[len(vals)-1]
+	if last, err := suite.vwao.Calculate(); err == nil {
 		if last > 60 {
 			trendBias += 1
 			strongTrend = true
@@ -872,56 +1146,50 @@ func (suite *ScalpingIndicatorSuite) computeScores() (float64, float64) {
 	/* ---- Adaptive DEMA Momentum Oscillator (volatility-adaptive momentum) ---- */
 	// ADMO crossovers are primary scalping signals - adapts to volatility changes
 	if bullish, err := suite.admo.IsBullishCrossover(); err == nil && bullish {
-		bull += 1.3 * trendScale // Slightly higher weight than RSI due to adaptive nature
+		addBull("ADMO", 1.3*trendScale) // Slightly higher weight than RSI due to adaptive nature
 	}
 	if bearish, err := suite.admo.IsBearishCrossover(); err == nil && bearish {
-		bear += 1.3 * trendScale
+		addBear("ADMO", 1.3*trendScale)
 	}
 	// ADMO overbought/oversold zones
-	admoVals := suite.admo.GetAMDOValues()
-	if len(admoVals) > 0 {
-		lastADMO := admoVals[len(admoVals)-1]
+	if lastADMO, err := suite.admo.Calculate(); err == nil {
 		// Check against config thresholds (default ±1.0, but we set ±0.8 for scalping)
 		if lastADMO < -0.8 {
-			bull += 0.6
+			addBull("ADMO", 0.6)
 		} else if lastADMO > 0.8 {
-			bear += 0.6
+			addBear("ADMO", 0.6)
 		}
 		// Strong momentum signals
 		if lastADMO > 1.5 {
-			bear += 0.3
+			addBear("ADMO", 0.3)
 		} else if lastADMO < -1.5 {
-			bull += 0.3
+			addBull("ADMO", 0.3)
 		}
 	}
 
 	/* ---- Volume Weighted Aroon Oscillator (volume-backed trend strength) ---- */
 	// VWAO provides volume-weighted trend signals - excellent for scalping
 	if bullish, err := suite.vwao.IsBullishCrossover(); err == nil && bullish {
-		bull += 1.2 * trendScale // Strong signal: volume-weighted trend shift
+		addBull("VWAO", 1.2*trendScale) // Strong signal: volume-weighted trend shift
 	}
 	if bearish, err := suite.vwao.IsBearishCrossover(); err == nil && bearish {
-		bear += 1.2 * trendScale
+		addBear("VWAO", 1.2*trendScale)
 	}
 
-	// Cache VWAO values (accessed multiple times)
-	vwaoVals := suite.vwao.GetVWAOValues()
-	if len(vwaoVals) > 0 {
-		lastVWAO := vwaoVals[len(vwaoVals)-1]
-
+	if lastVWAO, err := suite.vwao.Calculate(); err == nil {
 		// Strong trend detection
 		if strong, err := suite.vwao.IsStrongTrend(); err == nil && strong {
 			if lastVWAO > 60 {
-				bull += 0.7 // Strong uptrend with volume
+				addBull("VWAO", 0.7) // Strong uptrend with volume
 			} else if lastVWAO < -60 {
-				bear += 0.7 // Strong downtrend with volume
+				addBear("VWAO", 0.7) // Strong downtrend with volume
 			}
 		}
 		// VWAO direction bias
 		if lastVWAO > 30 {
-			bull += 0.3 // Moderate bullish bias
+			addBull("VWAO", 0.3) // Moderate bullish bias
 		} else if lastVWAO < -30 {
-			bear += 0.3 // Moderate bearish bias
+			addBear("VWAO", 0.3) // Moderate bearish bias
 		}
 	}
 
@@ -934,16 +1202,16 @@ func (suite *ScalpingIndicatorSuite) computeScores() (float64, float64) {
 
 		// Histogram zero-line crossover (strong signal)
 		if prevHist < 0 && curHist > 0 {
-			bull += 1.1 * trendScale
+			addBull("MACD", 1.1*trendScale)
 		} else if prevHist > 0 && curHist < 0 {
-			bear += 1.1 * trendScale
+			addBear("MACD", 1.1*trendScale)
 		}
 
 		// Histogram direction (momentum)
 		if curHist > 0 {
-			bull += 0.25 * trendScale
+			addBull("MACD", 0.25*trendScale)
 		} else if curHist < 0 {
-			bear += 0.25 * trendScale
+			addBear("MACD", 0.25*trendScale)
 		}
 
 		// Histogram momentum acceleration (scalping edge)
@@ -951,11 +1219,11 @@ func (suite *ScalpingIndicatorSuite) computeScores() (float64, float64) {
 			prev2Hist := histVals[histLen-3]
 			// Accelerating bullish: histogram increasing
 			if curHist > prevHist && prevHist > prev2Hist && curHist > 0 {
-				bull += 0.2
+				addBull("MACD", 0.2)
 			}
 			// Accelerating bearish: histogram decreasing
 			if curHist < prevHist && prevHist < prev2Hist && curHist < 0 {
-				bear += 0.2
+				addBear("MACD", 0.2)
 			}
 		}
 	}
@@ -963,38 +1231,31 @@ func (suite *ScalpingIndicatorSuite) computeScores() (float64, float64) {
 	/* ---- HMA (low-lag trend) ---- */
 	// HMA crossovers are excellent for scalping due to minimal lag
 	if bullish, err := suite.hma.IsBullishCrossover(); err == nil && bullish {
-		bull += 1.1 * trendScale
+		addBull("HMA", 1.1*trendScale)
 	}
 	if bearish, err := suite.hma.IsBearishCrossover(); err == nil && bearish {
-		bear += 1.1 * trendScale
+		addBear("HMA", 1.1*trendScale)
 	}
 	if dir, err := suite.hma.GetTrendDirection(); err == nil {
 		if dir == "Bullish" {
-			bull += 0.3
+			addBull("HMA", 0.3)
 		} else if dir == "Bearish" {
-			bear += 0.3
+			addBear("HMA", 0.3)
 		}
 	}
 
 	/* ---- Parabolic SAR (stop-and-reverse) ---- */
-	if sar := suite.sar.GetValues(); len(sar) > 0 {
+	if _, err := suite.sar.Calculate(); err == nil {
 		if suite.sar.IsUptrend() {
-			bull += 0.7
+			addBull("SAR", 0.7)
 		} else {
-			bear += 0.7
+			addBear("SAR", 0.7)
 		}
 	}
 
 	/* ---- Bollinger Bands (volatility squeeze/mean reversion) ---- */
 	if suite.hasClose {
-		upper := suite.bollinger.GetUpper()
-		middle := suite.bollinger.GetMiddle()
-		lower := suite.bollinger.GetLower()
-
-		if len(upper) > 0 && len(lower) > 0 && len(middle) > 0 {
-			lastUpper := upper[len(upper)-1]
-			lastLower := lower[len(lower)-1]
-			lastMiddle := middle[len(middle)-1]
+		if lastUpper, lastMiddle, lastLower, err := suite.bollinger.Calculate(); err == nil {
 			bandwidth := lastUpper - lastLower
 			meanRevBullScale := 1.0
 			meanRevBearScale := 1.0
@@ -1018,26 +1279,26 @@ func (suite *ScalpingIndicatorSuite) computeScores() (float64, float64) {
 
 				// Price at or below lower band: strong bullish reversal signal
 				if lowerDist <= 0 {
-					bull += 0.9 * meanRevBullScale
+					addBull("Bollinger", 0.9*meanRevBullScale)
 				} else if lowerDist < 0.1 {
 					// Price touching lower band area
-					bull += 0.6 * meanRevBullScale
+					addBull("Bollinger", 0.6*meanRevBullScale)
 				}
 
 				// Price at or above upper band: strong bearish reversal signal
 				if upperDist <= 0 {
-					bear += 0.9 * meanRevBearScale
+					addBear("Bollinger", 0.9*meanRevBearScale)
 				} else if upperDist < 0.1 {
 					// Price touching upper band area
-					bear += 0.6 * meanRevBearScale
+					addBear("Bollinger", 0.6*meanRevBearScale)
 				}
 			}
 
 			// Middle band cross (trend bias)
 			if suite.lastClose > lastMiddle {
-				bull += 0.2
+				addBull("Bollinger", 0.2)
 			} else if suite.lastClose < lastMiddle {
-				bear += 0.2
+				addBear("Bollinger", 0.2)
 			}
 		}
 	}
@@ -1060,9 +1321,9 @@ func (suite *ScalpingIndicatorSuite) computeScores() (float64, float64) {
 						boost = 0.35 // strong volatility expansion
 					}
 					if priceTrend > 0 {
-						bull += boost
+						addBull("ATR", boost)
 					} else {
-						bear += boost
+						addBear("ATR", boost)
 					}
 				}
 			}
@@ -1072,13 +1333,12 @@ func (suite *ScalpingIndicatorSuite) computeScores() (float64, float64) {
 	/* ---- VWAP (intraday flow) ---- */
 	// VWAP is critical for scalping: institutional level
 	if suite.hasClose {
-		if vals := suite.vwap.GetValues(); len(vals) > 0 {
-			lastVWAP := vals[len(vals)-1]
+		if lastVWAP, err := suite.vwap.Calculate(); err == nil {
 			if lastVWAP > 0 {
 				if suite.lastClose > lastVWAP {
-					bull += 0.8
+					addBull("VWAP", 0.8)
 				} else if suite.lastClose < lastVWAP {
-					bear += 0.8
+					addBear("VWAP", 0.8)
 				}
 			}
 		}
@@ -1087,17 +1347,17 @@ func (suite *ScalpingIndicatorSuite) computeScores() (float64, float64) {
 	/* ---- MFI (volume-backed momentum) ---- */
 	// Volume confirmation is crucial for scalping
 	if bullish, err := suite.mfi.IsBullishCrossover(); err == nil && bullish {
-		bull += 1.0
+		addBull("MFI", 1.0)
 	}
 	if bearish, err := suite.mfi.IsBearishCrossover(); err == nil && bearish {
-		bear += 1.0
+		addBear("MFI", 1.0)
 	}
 	if zone, err := suite.mfi.GetOverboughtOversold(); err == nil {
 		switch zone {
 		case "Oversold":
-			bull += 0.4
+			addBull("MFI", 0.4)
 		case "Overbought":
-			bear += 0.4
+			addBear("MFI", 0.4)
 		}
 	}
 
@@ -1105,17 +1365,12 @@ func (suite *ScalpingIndicatorSuite) computeScores() (float64, float64) {
 	// Simple price direction adds small bias
 	if suite.hasClose && suite.prevClose > 0 {
 		if suite.lastClose > suite.prevClose {
-			bull += 0.2
+			addBull("Price", 0.2)
 		} else if suite.lastClose < suite.prevClose {
-			bear += 0.2
+			addBear("Price", 0.2)
 		}
 	}
 
-	// Cache the computed scores
-	suite.cachedBullScore = bull
-	suite.cachedBearScore = bear
-	suite.cachedScoresValid = true
-
 	return bull, bear
 }
 
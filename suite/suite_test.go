@@ -0,0 +1,197 @@
+package suite
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGetSignalBreakdown(t *testing.T) {
+	s, err := NewScalpingIndicatorSuite()
+	if err != nil {
+		t.Fatalf("constructor failed: %v", err)
+	}
+	if _, _, err := s.GetSignalBreakdown(); err == nil {
+		t.Fatal("expected error on an empty suite")
+	}
+
+	// A steady decline on light volume followed by a single heavy up bar
+	// flips both the HMA and the MFI bullish on the same bar.
+	price := 120.0
+	for i := 0; i < 25; i++ {
+		price--
+		if err := s.Add(price+0.5, price-0.5, price, 1000); err != nil {
+			t.Fatalf("add failed at %d: %v", i, err)
+		}
+	}
+	price += 0.8
+	if err := s.Add(price+0.5, price-0.5, price, 3000); err != nil {
+		t.Fatalf("add failed: %v", err)
+	}
+	if ok, _ := s.GetHMA().IsBullishCrossover(); !ok {
+		t.Fatal("scenario should produce an HMA bullish crossover")
+	}
+	if ok, _ := s.GetMFI().IsBullishCrossover(); !ok {
+		t.Fatal("scenario should produce an MFI bullish crossover")
+	}
+
+	bullish, bearish, err := s.GetSignalBreakdown()
+	if err != nil {
+		t.Fatalf("GetSignalBreakdown failed: %v", err)
+	}
+	// HMA crossover weight (1.1, no chop scaling) and MFI crossover weight (1.0).
+	if math.Abs(bullish["HMA"]-1.1) > 1e-9 {
+		t.Fatalf("expected HMA bullish weight 1.1, got %v", bullish["HMA"])
+	}
+	if math.Abs(bullish["MFI"]-1.0) > 1e-9 {
+		t.Fatalf("expected MFI bullish weight 1.0, got %v", bullish["MFI"])
+	}
+	if _, ok := bullish["VWAO"]; ok {
+		t.Fatalf("VWAO did not fire but appears in breakdown: %v", bullish)
+	}
+
+	// The breakdown must add up to the raw scores used by GetCombinedSignal.
+	bull, bear := s.computeScores()
+	sum := func(m map[string]float64) float64 {
+		total := 0.0
+		for _, v := range m {
+			total += v
+		}
+		return total
+	}
+	if math.Abs(sum(bullish)-bull) > 1e-9 || math.Abs(sum(bearish)-bear) > 1e-9 {
+		t.Fatalf("breakdown %v/%v does not sum to scores %v/%v", bullish, bearish, bull, bear)
+	}
+}
+
+func TestHMAvsVWAPCross(t *testing.T) {
+	s, err := NewScalpingIndicatorSuite()
+	if err != nil {
+		t.Fatalf("constructor failed: %v", err)
+	}
+	if _, err := s.HMAvsVWAPCross(); err == nil {
+		t.Fatal("expected error on an empty suite")
+	}
+
+	// A decline drags the HMA below the cumulative VWAP; a sharp rally then
+	// lifts it back above.
+	price := 100.0
+	var crosses []int
+	for i := 0; i < 60; i++ {
+		if i < 30 {
+			price -= 0.5
+		} else {
+			price += 1.5
+		}
+		if err := s.Add(price+0.5, price-0.5, price, 1000); err != nil {
+			t.Fatalf("add failed at %d: %v", i, err)
+		}
+		dir, err := s.HMAvsVWAPCross()
+		if err != nil {
+			continue
+		}
+		if dir != 0 {
+			crosses = append(crosses, dir)
+		}
+	}
+	if len(crosses) != 1 || crosses[0] != 1 {
+		t.Fatalf("expected exactly one bullish HMA/VWAP cross, got %v", crosses)
+	}
+}
+
+func TestAddCloseFeedsCloseOnlyIndicators(t *testing.T) {
+	s, err := NewScalpingIndicatorSuite()
+	if err != nil {
+		t.Fatalf("constructor failed: %v", err)
+	}
+	if err := s.AddClose(0); err == nil {
+		t.Fatal("expected error for a zero close")
+	}
+
+	for i := 0; i < 60; i++ {
+		price := 100 + 3*math.Sin(float64(i)/4)
+		if err := s.AddClose(price); err != nil {
+			t.Fatalf("AddClose failed at %d: %v", i, err)
+		}
+	}
+	if _, _, _, err := s.GetMACD().Calculate(); err != nil {
+		t.Fatalf("MACD should be ready after close-only bars: %v", err)
+	}
+	if _, err := s.GetHMA().Calculate(); err != nil {
+		t.Fatalf("HMA should be ready after close-only bars: %v", err)
+	}
+	if _, err := s.GetAdaptiveDEMAMomentumOscillator().Calculate(); err != nil {
+		t.Fatalf("ADMO should be ready after close-only bars: %v", err)
+	}
+	if _, err := s.GetCombinedSignal(); err != nil {
+		t.Fatalf("combined signal failed: %v", err)
+	}
+	if _, err := s.GetATR().Calculate(); err == nil {
+		t.Fatal("ATR needs a high/low range and must be skipped by AddClose")
+	}
+	if _, err := s.GetMFI().Calculate(); err == nil {
+		t.Fatal("MFI needs volume and must be skipped by AddClose")
+	}
+
+	s.Reset()
+	for i := 0; i < 30; i++ {
+		if err := s.AddCloseVolume(100+float64(i%5), 1000+float64(i)); err != nil {
+			t.Fatalf("AddCloseVolume failed at %d: %v", i, err)
+		}
+	}
+	if _, err := s.GetVWAP().Calculate(); err != nil {
+		t.Fatalf("VWAP should be fed by AddCloseVolume: %v", err)
+	}
+	if _, err := s.GetMFI().Calculate(); err != nil {
+		t.Fatalf("MFI should be fed by AddCloseVolume: %v", err)
+	}
+	if _, err := s.GetParabolicSAR().Calculate(); err == nil {
+		t.Fatal("Parabolic SAR must be skipped by AddCloseVolume")
+	}
+}
+
+func TestSetMomentumConfirmationBars(t *testing.T) {
+	s, err := NewScalpingIndicatorSuite()
+	if err != nil {
+		t.Fatalf("constructor failed: %v", err)
+	}
+	if err := s.SetMomentumConfirmation(0, 0.15); err == nil {
+		t.Fatal("expected error for zero bars")
+	}
+	if err := s.SetMomentumConfirmation(2, -1); err == nil {
+		t.Fatal("expected error for a negative boost")
+	}
+
+	// Two consecutive up-closes after a down move.
+	for _, c := range []float64{100, 99, 100, 101} {
+		if err := s.AddClose(c); err != nil {
+			t.Fatalf("AddClose failed: %v", err)
+		}
+	}
+	// Pin the score just under the weak threshold (0.35) in a neutral
+	// volatility regime so that only the boost can change the outcome.
+	signal := func() string {
+		s.cachedBullScore, s.cachedBearScore, s.cachedScoresValid = 0.3, 0, true
+		s.cachedVolRatio, s.volRatioValid = 0.002, true
+		got, err := s.GetCombinedSignal()
+		if err != nil {
+			t.Fatalf("GetCombinedSignal failed: %v", err)
+		}
+		return got
+	}
+
+	if got := signal(); got != "Weak Bullish" {
+		t.Fatalf("default 2-close confirmation should boost to Weak Bullish, got %s", got)
+	}
+	if err := s.SetMomentumConfirmation(3, 0.15); err != nil {
+		t.Fatalf("SetMomentumConfirmation failed: %v", err)
+	}
+	if got := signal(); got != "Neutral" {
+		t.Fatalf("requiring 3 up-closes should suppress the boost after 2, got %s", got)
+	}
+	if err := s.AddClose(102); err != nil {
+		t.Fatalf("AddClose failed: %v", err)
+	}
+	if got := signal(); got != "Weak Bullish" {
+		t.Fatalf("third up-close should restore the boost, got %s", got)
+	}
+}
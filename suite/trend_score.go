@@ -0,0 +1,43 @@
+package suite
+
+import (
+	"errors"
+	"math"
+)
+
+// Trend score weighting (see TrendScore).
+const (
+	trendScoreHMAWeight = 0.6
+	trendScoreSARWeight = 0.4
+	trendScoreSlopeGain = 2.0 // an HMA rising half an ATR per bar scores tanh(1) ≈ 0.76
+)
+
+// TrendScore condenses the trend indicators into one dial in [-100, 100];
+// positive means an uptrend, and the magnitude is its strength. It blends
+//
+//   - HMA slope (weight 0.6): tanh(2·Δhma/scale), the latest bar-to-bar change
+//     of the Hull MA in ATRs, so a line rising half an ATR per bar reads 0.76
+//   - Parabolic SAR (weight 0.4): tanh((close−sar)/scale), which carries the
+//     SAR trend direction and grows with the cushion between price and stop
+//
+// and multiplies the weighted sum by 100. scale is the current ATR, or 1% of
+// the close without one, as in GetNormalized. The suite has no ADX, so trend
+// strength comes from the slope and SAR cushion alone. It errors until the
+// HMA has two values and the SAR has one.
+func (suite *ScalpingIndicatorSuite) TrendScore() (float64, error) {
+	hma := suite.hma.GetHMAValues()
+	if len(hma) < 2 {
+		return 0, errors.New("trend score needs two HMA values")
+	}
+	sar, err := suite.sar.Calculate()
+	if err != nil {
+		return 0, err
+	}
+	scale := suite.normalizationScale()
+	if scale <= 0 {
+		return 0, errors.New("trend score needs a positive price scale")
+	}
+	slope := math.Tanh(trendScoreSlopeGain * (hma[len(hma)-1] - hma[len(hma)-2]) / scale)
+	cushion := math.Tanh((suite.lastClose - sar) / scale)
+	return 100 * (trendScoreHMAWeight*slope + trendScoreSARWeight*cushion), nil
+}
@@ -0,0 +1,44 @@
+package suite
+
+import "testing"
+
+func TestTrendScore(t *testing.T) {
+	s, err := NewScalpingIndicatorSuite()
+	if err != nil {
+		t.Fatalf("constructor failed: %v", err)
+	}
+	if _, err := s.TrendScore(); err == nil {
+		t.Fatal("expected an error before any data")
+	}
+
+	// A clean uptrend: every bar closes half a point higher on a one-point range.
+	for i := 0; i < 80; i++ {
+		price := 100 + 0.5*float64(i)
+		if err := s.Add(price+0.5, price-0.5, price, 1000); err != nil {
+			t.Fatalf("add failed at %d: %v", i, err)
+		}
+	}
+	up, err := s.TrendScore()
+	if err != nil {
+		t.Fatalf("TrendScore failed: %v", err)
+	}
+	if up < 60 || up > 100 {
+		t.Fatalf("expected a strongly positive score for a clean uptrend, got %.2f", up)
+	}
+
+	// The mirror image scores strongly negative.
+	s.Reset()
+	for i := 0; i < 80; i++ {
+		price := 200 - 0.5*float64(i)
+		if err := s.Add(price+0.5, price-0.5, price, 1000); err != nil {
+			t.Fatalf("add failed at %d: %v", i, err)
+		}
+	}
+	down, err := s.TrendScore()
+	if err != nil {
+		t.Fatalf("TrendScore failed: %v", err)
+	}
+	if down > -60 || down < -100 {
+		t.Fatalf("expected a strongly negative score for a clean downtrend, got %.2f", down)
+	}
+}